@@ -0,0 +1,113 @@
+package mappath
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// FromArchive is a factory method to create a MapPath from all config files with a supported extension (see
+// FromFile) within a zip, tar or gzipped tar archive. The files are deep merged in the lexical order of their
+// paths within the archive (eg "00-base.json" before "10-plugin.json"): maps are merged, all other values
+// replaced. Other files, directories and hidden files are skipped. Errors name the file they occurred in.
+func FromArchive(r io.ReaderAt, size int64, opts ...Option) (*MapPath, error) {
+	magic := make([]byte, 262)
+	n, _ := r.ReadAt(magic, 0)
+	magic = magic[:n]
+
+	var files map[string][]byte
+	var err error
+	switch {
+	case bytes.HasPrefix(magic, []byte("PK\x03\x04")), bytes.HasPrefix(magic, []byte("PK\x05\x06")):
+		files, err = zipFiles(r, size, newOptions(opts))
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		var gz *gzip.Reader
+		if gz, err = gzip.NewReader(io.NewSectionReader(r, 0, size)); err == nil {
+			files, err = tarFiles(gz, newOptions(opts))
+		}
+	case len(magic) >= 262 && string(magic[257:262]) == "ustar":
+		files, err = tarFiles(io.NewSectionReader(r, 0, size), newOptions(opts))
+	default:
+		return nil, fmt.Errorf("Cannot load archive: unsupported format")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := NewMapPath(map[string]interface{}{}, opts...)
+	for _, name := range names {
+		m, err := fileFormats[strings.ToLower(path.Ext(name))](files[name], opts...)
+		if err == nil {
+			err = mergeInto(map[string]interface{}(result.root), map[string]interface{}(m.root))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Cannot load \"%s\": %w", name, err)
+		}
+	}
+	return result, nil
+}
+
+// archiveFile returns whether the file of the archive is to be loaded
+func archiveFile(name string) bool {
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") && part != "." {
+			return false
+		}
+	}
+	return fileFormats[strings.ToLower(path.Ext(name))] != nil
+}
+
+func zipFiles(r io.ReaderAt, size int64, o *options) (map[string][]byte, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{}
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || !archiveFile(file.Name) {
+			continue
+		}
+		fh, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("Cannot load \"%s\": %w", file.Name, err)
+		}
+		in, err := readLimited(fh, o)
+		fh.Close()
+		if err != nil {
+			return nil, fmt.Errorf("Cannot load \"%s\": %w", file.Name, err)
+		}
+		files[file.Name] = in
+	}
+	return files, nil
+}
+
+func tarFiles(r io.Reader, o *options) (map[string][]byte, error) {
+	archive := tar.NewReader(r)
+	files := map[string][]byte{}
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return files, nil
+		} else if err != nil {
+			return nil, err
+		} else if header.Typeflag != tar.TypeReg || !archiveFile(header.Name) {
+			continue
+		}
+		in, err := readLimited(archive, o)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot load \"%s\": %w", header.Name, err)
+		}
+		files[header.Name] = in
+	}
+}
@@ -0,0 +1,88 @@
+package mappath
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Archives
+ * -------
+ */
+
+var archiveTestFiles = []struct {
+	name    string
+	content string
+}{
+	{"plugin/10-override.json", `{"db":{"host":"localhost"},"name":"plugin"}`},
+	{"plugin/00-base.jsonc", "{\"db\":{\"host\":\"prod\",\"port\":5432}, // base\n}"},
+	{"plugin/README.md", "# not loaded"},
+	{"plugin/.hidden.json", `{"name":"hidden"}`},
+}
+
+func zipArchive() []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, file := range archiveTestFiles {
+		fh, _ := w.Create(file.name)
+		fh.Write([]byte(file.content))
+	}
+	w.Close()
+	return buf.Bytes()
+}
+
+func tarArchive() []byte {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	w.WriteHeader(&tar.Header{Name: "plugin/", Typeflag: tar.TypeDir, Mode: 0755})
+	for _, file := range archiveTestFiles {
+		w.WriteHeader(&tar.Header{Name: file.name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(file.content))})
+		w.Write([]byte(file.content))
+	}
+	w.Close()
+	return buf.Bytes()
+}
+
+func gzipped(in []byte) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write(in)
+	w.Close()
+	return buf.Bytes()
+}
+
+func TestFromArchive(t *testing.T) {
+	archives := map[string][]byte{
+		"zip":    zipArchive(),
+		"tar":    tarArchive(),
+		"tar.gz": gzipped(tarArchive()),
+	}
+	for kind, archive := range archives {
+		m, err := FromArchive(bytes.NewReader(archive), int64(len(archive)))
+		assert.Nil(t, err, "No error on "+kind)
+		assert.Equal(t, "localhost", m.StringV("db/host"), "Files merged in order on "+kind)
+		assert.Equal(t, 5432, m.IntV("db/port"), "Values of first file kept on "+kind)
+		assert.Equal(t, "plugin", m.StringV("name"), "Hidden file skipped on "+kind)
+
+		_, err = FromArchive(bytes.NewReader(archive), int64(len(archive)), WithMaxBytes(10))
+		var limit *LimitError
+		assert.True(t, errors.As(err, &limit), "Limits applied on "+kind)
+	}
+
+	_, err := FromArchive(bytes.NewReader([]byte("plain text")), 10)
+	assert.EqualError(t, err, "Cannot load archive: unsupported format", "Unsupported format")
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	fh, _ := w.Create("broken.json")
+	fh.Write([]byte("{"))
+	w.Close()
+	_, err = FromArchive(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.Contains(t, err.Error(), `Cannot load "broken.json": `, "Error names file")
+}
@@ -0,0 +1,302 @@
+package mappath
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// arrayCoercer converts an array element into the element type of the result, according to the options
+type arrayCoercer func(item interface{}, o *options) (interface{}, bool)
+
+// arrayCoercers contains the supported element types of Array and how elements are converted into them
+var arrayCoercers = map[reflect.Type]arrayCoercer{
+	reflect.TypeOf(int(0)):                   coerceInt,
+	reflect.TypeOf(int64(0)):                 coerceInt64,
+	reflect.TypeOf(uint64(0)):                coerceUint64,
+	reflect.TypeOf(float64(0)):               coerceFloat,
+	reflect.TypeOf(false):                    coerceBool,
+	reflect.TypeOf(""):                       coerceString,
+	reflect.TypeOf(json.Number("")):          coerceNumber,
+	reflect.TypeOf(time.Time{}):              coerceTime,
+	reflect.TypeOf(map[string]interface{}{}): coerceMap,
+}
+
+// ArrayInto fills the slice dst points to with the array at path, eg
+//
+//	var ports []int64
+//	err := mp.ArrayInto(&ports, "server/ports")
+//
+// Elements of the types supported by Array are converted like Array, all other elements (eg structs)
+// are bound like Bind.
+func (this *MapPath) ArrayInto(dst interface{}, path string) error {
+	ref := reflect.ValueOf(dst)
+	if ref.Kind() != reflect.Ptr || ref.IsNil() || ref.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("Target must be a non-nil pointer to a slice, got %T", dst)
+	}
+	target := ref.Elem()
+	if _, ok := arrayCoercers[target.Type().Elem()]; !ok {
+		if _, err := this.arrayValue(path); err != nil {
+			return err
+		}
+		return this.bindValue(target, path)
+	}
+	res, _, err := this.Array(target.Type().Elem(), path)
+	if err != nil {
+		return err
+	}
+	target.Set(reflect.ValueOf(res).Convert(target.Type()))
+	return nil
+}
+
+func coerceInt(item interface{}, o *options) (interface{}, bool) {
+	i, ok := coerceInt64(item, o)
+	if !ok {
+		return nil, false
+	}
+	return int(i.(int64)), true
+}
+
+func coerceInt64(item interface{}, o *options) (interface{}, bool) {
+	if item == nil {
+		return nil, false
+	}
+	ref := reflect.ValueOf(item)
+	switch kind := ref.Kind(); {
+	case kind == reflect.Bool:
+		if ref.Bool() {
+			return int64(1), true
+		}
+		return int64(0), true
+	case kind == reflect.String:
+		v, err := o.parseInt(ref.String())
+		if err != nil {
+			f, ferr := o.parseFloat(ref.String())
+			if ferr != nil {
+				return nil, false
+			}
+			v = int64(f)
+		}
+		return v, true
+	case kind == reflect.Int, kind == reflect.Int8, kind == reflect.Int16, kind == reflect.Int32, kind == reflect.Int64:
+		return ref.Int(), true
+	case isOfKind(kind, kindsInt):
+		return int64(ref.Uint()), true
+	case isOfKind(kind, kindsFloat):
+		return int64(ref.Float()), true
+	}
+	return nil, false
+}
+
+func coerceUint64(item interface{}, o *options) (interface{}, bool) {
+	if item == nil {
+		return nil, false
+	}
+	ref := reflect.ValueOf(item)
+	switch kind := ref.Kind(); {
+	case kind == reflect.String:
+		v, err := o.parseUint(ref.String())
+		return v, err == nil
+	case kind == reflect.Uint, kind == reflect.Uint8, kind == reflect.Uint16, kind == reflect.Uint32, kind == reflect.Uint64:
+		return ref.Uint(), true
+	case isOfKind(kind, kindsInt):
+		return uint64(ref.Int()), ref.Int() >= 0
+	case isOfKind(kind, kindsFloat):
+		f := ref.Float()
+		return uint64(f), f >= 0 && f == math.Trunc(f) && f <= math.MaxUint64
+	}
+	return nil, false
+}
+
+func coerceFloat(item interface{}, o *options) (interface{}, bool) {
+	if item == nil {
+		return nil, false
+	}
+	ref := reflect.ValueOf(item)
+	switch kind := ref.Kind(); {
+	case kind == reflect.Bool:
+		if ref.Bool() {
+			return 1.0, true
+		}
+		return 0.0, true
+	case kind == reflect.String:
+		v, err := o.parseFloat(ref.String())
+		return v, err == nil
+	case isOfKind(kind, kindsInt), isOfKind(kind, kindsFloat):
+		f, err := toFloat(item)
+		return f, err == nil
+	}
+	return nil, false
+}
+
+func coerceBool(item interface{}, o *options) (interface{}, bool) {
+	if item == nil {
+		return nil, false
+	}
+	ref := reflect.ValueOf(item)
+	switch kind := ref.Kind(); {
+	case kind == reflect.Bool:
+		return ref.Bool(), true
+	case kind == reflect.String:
+		switch ref.String() {
+		case "true", "yes":
+			return true, true
+		case "false", "no":
+			return false, true
+		}
+	case isOfKind(kind, kindsInt), isOfKind(kind, kindsFloat):
+		f, err := toFloat(item)
+		return f != 0, err == nil
+	}
+	return nil, false
+}
+
+func coerceString(item interface{}, o *options) (interface{}, bool) {
+	if item == nil {
+		return nil, false
+	}
+	ref := reflect.ValueOf(item)
+	switch kind := ref.Kind(); {
+	case kind == reflect.Bool:
+		return strconv.FormatBool(ref.Bool()), true
+	case kind == reflect.String:
+		return ref.String(), true
+	case kind == reflect.Int, kind == reflect.Int8, kind == reflect.Int16, kind == reflect.Int32, kind == reflect.Int64:
+		return strconv.FormatInt(ref.Int(), 10), true
+	case isOfKind(kind, kindsInt):
+		return strconv.FormatUint(ref.Uint(), 10), true
+	case isOfKind(kind, kindsFloat):
+		return o.formatFloat(ref.Float()), true
+	}
+	return nil, false
+}
+
+func coerceNumber(item interface{}, o *options) (interface{}, bool) {
+	if item == nil {
+		return nil, false
+	}
+	ref := reflect.ValueOf(item)
+	switch kind := ref.Kind(); {
+	case kind == reflect.String:
+		if _, err := strconv.ParseFloat(ref.String(), 64); err != nil {
+			return nil, false
+		}
+		return json.Number(ref.String()), true
+	case kind == reflect.Int, kind == reflect.Int8, kind == reflect.Int16, kind == reflect.Int32, kind == reflect.Int64:
+		return json.Number(strconv.FormatInt(ref.Int(), 10)), true
+	case isOfKind(kind, kindsInt):
+		return json.Number(strconv.FormatUint(ref.Uint(), 10)), true
+	case isOfKind(kind, kindsFloat):
+		return json.Number(strconv.FormatFloat(ref.Float(), 'g', -1, 64)), true
+	}
+	return nil, false
+}
+
+func coerceTime(item interface{}, o *options) (interface{}, bool) {
+	t, ok := toTime(item)
+	return t, ok
+}
+
+func coerceMap(item interface{}, o *options) (interface{}, bool) {
+	m, ok := toStringMap(item)
+	return m, ok && m != nil
+}
+
+// Bools returns []bool value of path. See Array for the conversion of the elements.
+func (this *MapPath) Bools(path string, fallback ...[]bool) ([]bool, error) {
+	res, _, err := this.Array(reflect.TypeOf(false), path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); len(fallback) > 0 && ok {
+			return fallback[0], nil
+		}
+		return nil, err
+	}
+	return res.([]bool), nil
+}
+
+// BoolsV returns []bool value of path. If value cannot be parsed or converted then fallback or nil is returned. Handy in single value context.
+func (this *MapPath) BoolsV(path string, fallback ...[]bool) []bool {
+	if val, err := this.Bools(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return nil
+	} else {
+		return val
+	}
+}
+
+// Int64s returns []int64 value of path. See Array for the conversion of the elements.
+func (this *MapPath) Int64s(path string, fallback ...[]int64) ([]int64, error) {
+	res, _, err := this.Array(reflect.TypeOf(int64(0)), path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); len(fallback) > 0 && ok {
+			return fallback[0], nil
+		}
+		return nil, err
+	}
+	return res.([]int64), nil
+}
+
+// Int64sV returns []int64 value of path. If value cannot be parsed or converted then fallback or nil is returned. Handy in single value context.
+func (this *MapPath) Int64sV(path string, fallback ...[]int64) []int64 {
+	if val, err := this.Int64s(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return nil
+	} else {
+		return val
+	}
+}
+
+// Uint64s returns []uint64 value of path. See Array for the conversion of the elements.
+func (this *MapPath) Uint64s(path string, fallback ...[]uint64) ([]uint64, error) {
+	res, _, err := this.Array(reflect.TypeOf(uint64(0)), path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); len(fallback) > 0 && ok {
+			return fallback[0], nil
+		}
+		return nil, err
+	}
+	return res.([]uint64), nil
+}
+
+// Uint64sV returns []uint64 value of path. If value cannot be parsed or converted then fallback or nil is returned. Handy in single value context.
+func (this *MapPath) Uint64sV(path string, fallback ...[]uint64) []uint64 {
+	if val, err := this.Uint64s(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return nil
+	} else {
+		return val
+	}
+}
+
+// Times returns []time.Time value of path. See Array for the conversion of the elements.
+func (this *MapPath) Times(path string, fallback ...[]time.Time) ([]time.Time, error) {
+	res, _, err := this.Array(reflect.TypeOf(time.Time{}), path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); len(fallback) > 0 && ok {
+			return fallback[0], nil
+		}
+		return nil, err
+	}
+	return res.([]time.Time), nil
+}
+
+// TimesV returns []time.Time value of path. If value cannot be parsed or converted then fallback or nil is returned. Handy in single value context.
+func (this *MapPath) TimesV(path string, fallback ...[]time.Time) []time.Time {
+	if val, err := this.Times(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return nil
+	} else {
+		return val
+	}
+}
@@ -0,0 +1,126 @@
+package mappath
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * Get: Array element types
+ * -------
+ */
+
+var arrayTest = map[string]interface{}{
+	"bools":    []interface{}{true, "yes", "no", 0, 1.5},
+	"badbools": []interface{}{"maybe"},
+	"ints":     []interface{}{1, "2", 3.0, int64(-4), uint8(5)},
+	"uints":    []interface{}{1, "18446744073709551615", uint64(3)},
+	"negative": []interface{}{-1},
+	"times":    []interface{}{"2020-06-01T10:00:00Z", "2020-06-02", 0},
+	"badtimes": []interface{}{"yesterday"},
+	"badnums":  []interface{}{"1", "foo"},
+	"numbers":  []interface{}{"1.50", 2, 0.25, json.Number("12345678901234567890")},
+	"nulls":    []interface{}{nil},
+}
+
+func TestGetArrayElementTypes(t *testing.T) {
+	m := NewMapPath(arrayTest)
+
+	bools, err := m.Bools("bools")
+	assert.Nil(t, err, "No error for bools")
+	assert.Equal(t, []bool{true, true, false, false, true}, bools, "Bools converted")
+
+	i64s, err := m.Int64s("ints")
+	assert.Nil(t, err, "No error for int64s")
+	assert.Equal(t, []int64{1, 2, 3, -4, 5}, i64s, "Int64s converted")
+
+	u64s, err := m.Uint64s("uints")
+	assert.Nil(t, err, "No error for uint64s")
+	assert.Equal(t, []uint64{1, 18446744073709551615, 3}, u64s, "Uint64s converted")
+
+	times, err := m.Times("times")
+	assert.Nil(t, err, "No error for times")
+	assert.Equal(t, []time.Time{
+		time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2020, 6, 2, 0, 0, 0, 0, time.UTC),
+		time.Unix(0, 0).UTC(),
+	}, times, "Times converted")
+
+	numbers, _, err := m.Array(reflect.TypeOf(json.Number("")), "numbers")
+	assert.Nil(t, err, "No error for numbers")
+	assert.Equal(t, []json.Number{"1.50", "2", "0.25", "12345678901234567890"}, numbers, "Numbers converted exactly")
+}
+
+func TestGetArrayElementTypeErrors(t *testing.T) {
+	m := NewMapPath(arrayTest)
+	tests := map[string]func(string) error{
+		"badbools": func(p string) error { _, err := m.Bools(p); return err },
+		"negative": func(p string) error { _, err := m.Uint64s(p); return err },
+		"badtimes": func(p string) error { _, err := m.Times(p); return err },
+		"nulls":    func(p string) error { _, err := m.Int64s(p); return err },
+		"badnums":  func(p string) error { _, err := m.Int64s(p); return err },
+	}
+	for path, fn := range tests {
+		_, ok := fn(path).(*InvalidTypeError)
+		assert.True(t, ok, "Invalid type error returned for "+path)
+	}
+	_, err := m.Floats("badnums")
+	_, ok := err.(*InvalidTypeError)
+	assert.True(t, ok, "Invalid type error returned for unparsable floats")
+	assert.Equal(t, []bool{true}, m.BoolsV("missing", []bool{true}), "Fallback returned")
+	assert.Nil(t, m.TimesV("badtimes"), "Nil returned on error")
+}
+
+/*
+ * -------
+ * ArrayInto
+ * -------
+ */
+
+type arrayIntoTestItem struct {
+	Name string
+}
+
+type arrayIntoTestInts []int
+
+func TestArrayInto(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"ints":  []interface{}{1, "2"},
+		"empty": []interface{}{},
+		"items": []interface{}{map[string]interface{}{"name": "a"}, map[string]interface{}{"name": "b"}},
+		"text":  "foo",
+	})
+
+	var ints []int64
+	assert.Nil(t, m.ArrayInto(&ints, "ints"), "No error returned")
+	assert.Equal(t, []int64{1, 2}, ints, "Slice filled")
+
+	var named arrayIntoTestInts
+	assert.Nil(t, m.ArrayInto(&named, "ints"), "No error for named slice type")
+	assert.Equal(t, arrayIntoTestInts{1, 2}, named, "Named slice filled")
+
+	strs := []string{"old"}
+	assert.Nil(t, m.ArrayInto(&strs, "empty"), "No error for empty array")
+	assert.Equal(t, []string{}, strs, "Empty slice set")
+
+	var items []arrayIntoTestItem
+	assert.Nil(t, m.ArrayInto(&items, "items"), "No error for structs")
+	assert.Equal(t, []arrayIntoTestItem{{"a"}, {"b"}}, items, "Structs bound")
+
+	assert.NotNil(t, m.ArrayInto(ints, "ints"), "Error on non pointer")
+	assert.NotNil(t, m.ArrayInto(&items, "text"), "Error on non array")
+	_, ok := m.ArrayInto(&ints, "missing").(NotFoundError)
+	assert.True(t, ok, "Not found error returned")
+}
+
+func TestGetArrayEmpty(t *testing.T) {
+	m := NewMapPath(defaultTest)
+	r, found, err := m.Array(reflect.TypeOf(""), "array/empty")
+	assert.Nil(t, err, "No error returned")
+	assert.True(t, found, "Empty array found")
+	assert.Equal(t, []string{}, r, "Typed empty slice returned")
+}
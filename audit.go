@@ -0,0 +1,112 @@
+package mappath
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// AuditEvent describes a read access of a path, see WithAuditHook
+type AuditEvent struct {
+	// Path is the accessed path, relative to the MapPath it was read from (eg a Child)
+	Path string
+
+	// Found is true if the path exists
+	Found bool
+
+	// Err is the error of the access, if any (eg NotFoundError)
+	Err error
+
+	// Caller is the "file:line" of the code outside of this package, which accessed the path
+	Caller string
+
+	// Time is the time of the access
+	Time time.Time
+}
+
+type auditHook struct {
+	globs [][]string
+	fn    func(AuditEvent)
+}
+
+// WithAuditHook calls fn for every read access (successful or not) of a path matching any of the
+// glob expressions (see Paths), eg "secrets/*". Reading a map, which contains matching paths (eg
+// Child("secrets")), is reported as well. Globs are matched against paths from the root, so that reading
+// "password" from Child("secrets") matches "secrets/*", while the reported paths are relative to the
+// MapPath they are read from.
+//
+// Besides Get, which all getters use, Has and IsNull report the checked path, Paths and ToLabels every
+// path matching their glob, and Copy the copied path. Bulk readers report every matching path they
+// expose: Walk and Flatten the visited paths, ToJson, Partition and Outline all paths of the structure
+// and Restrict the visible paths. Only Root() bypasses the hook.
+func WithAuditHook(fn func(AuditEvent), globs ...string) Option {
+	hook := &auditHook{fn: fn}
+	for _, glob := range globs {
+		hook.globs = append(hook.globs, splitGlob(glob))
+	}
+	return func(o *options) {
+		o.audit = hook
+	}
+}
+
+// auditing checks whether reads are reported, see WithAuditHook
+func (this *MapPath) auditing() bool {
+	return this != nil && this.opts().audit != nil
+}
+
+// audit reports the access of path, if it or any of its descendants matches
+func (this *MapPath) audit(path string, found bool, err error) {
+	this.auditMatching(path, found, err, true)
+}
+
+// auditTree reports the read of val at path (the root for "") and of all of its descendants, as
+// exposed by bulk readers
+func (this *MapPath) auditTree(path string, val interface{}) {
+	if !this.auditing() {
+		return
+	} else if path != "" {
+		this.audit(path, true, nil)
+	}
+	walk(val, path, true, func(p string, _ interface{}) error {
+		this.auditMatching(p, true, nil, false)
+		return nil
+	})
+}
+
+// auditMatching reports the access of path, if it matches or, with ancestors, any of its descendants does
+func (this *MapPath) auditMatching(path string, found bool, err error, ancestors bool) {
+	parts, perr := splitPath(this.prefix + path)
+	if perr != nil {
+		parts = []string{this.prefix + path}
+	}
+	for _, glob := range this.opts().audit.globs {
+		if matchGlob(glob, parts, ancestors) {
+			this.opts().audit.fn(AuditEvent{
+				Path:   path,
+				Found:  found,
+				Err:    err,
+				Caller: auditCaller(),
+				Time:   this.now(),
+			})
+			return
+		}
+	}
+}
+
+var auditPackage = reflect.TypeOf(MapPath{}).PkgPath() + "."
+
+// auditCaller returns the "file:line" of the first caller outside of this package
+func auditCaller() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, auditPackage) || strings.HasSuffix(frame.File, "_test.go") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		} else if !more {
+			return ""
+		}
+	}
+}
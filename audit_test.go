@@ -0,0 +1,140 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+/*
+ * -------
+ * Audit
+ * -------
+ */
+
+var auditTest = map[string]interface{}{
+	"secrets": map[string]interface{}{"db": "s3cr3t"},
+	"public":  "hello",
+}
+
+func TestAuditHook(t *testing.T) {
+	events := []AuditEvent{}
+	m := NewMapPath(auditTest, WithAuditHook(func(e AuditEvent) {
+		events = append(events, e)
+	}, "secrets/*"))
+
+	m.StringV("public")
+	assert.Equal(t, 0, len(events), "Public access not reported")
+
+	m.StringV("secrets/db")
+	if assert.Equal(t, 1, len(events), "Secret access reported") {
+		assert.Equal(t, "secrets/db", events[0].Path, "Path reported")
+		assert.True(t, events[0].Found, "Success reported")
+		assert.Nil(t, events[0].Err, "No error reported")
+		assert.True(t, strings.Contains(events[0].Caller, "audit_test.go:"), "Caller reported: "+events[0].Caller)
+		assert.False(t, events[0].Time.IsZero(), "Time reported")
+	}
+
+	m.StringV("secrets/missing")
+	if assert.Equal(t, 2, len(events), "Failed access reported") {
+		assert.False(t, events[1].Found, "Failure reported")
+		assert.Equal(t, NotFoundError("secrets/missing"), events[1].Err, "Error reported")
+	}
+
+	m.ChildV("secrets")
+	if assert.Equal(t, 3, len(events), "Access of parent reported") {
+		assert.Equal(t, "secrets", events[2].Path, "Parent path reported")
+	}
+}
+
+func TestAuditHookChild(t *testing.T) {
+	events := []AuditEvent{}
+	m := NewMapPath(map[string]interface{}{
+		"secrets": map[string]interface{}{"password": "s3cr3t", "nested": map[string]interface{}{"key": "k"}},
+		"users":   []interface{}{map[string]interface{}{"name": "a", "token": "t"}},
+		"public":  map[string]interface{}{"password": "hello"},
+	}, WithAuditHook(func(e AuditEvent) {
+		events = append(events, e)
+	}, "secrets/**", "users/*/token"))
+
+	secrets := m.ChildV("secrets")
+	events = events[:0]
+	secrets.StringV("password")
+	if assert.Equal(t, 1, len(events), "Access through child reported") {
+		assert.Equal(t, "password", events[0].Path, "Path relative to the child reported")
+	}
+	secrets.ChildV("nested").StringV("key")
+	assert.Equal(t, 3, len(events), "Access through nested child reported")
+
+	m.ChildV("public").StringV("password")
+	assert.Equal(t, 3, len(events), "Access of same key elsewhere not reported")
+
+	users := m.ChildsV("users")
+	events = events[:0]
+	users[0].StringV("name")
+	assert.Equal(t, 0, len(events), "Access of other keys not reported")
+	users[0].StringV("token")
+	assert.Equal(t, 1, len(events), "Access through array element reported")
+	m.EachChunk("users", 1, func(start int, items []*MapPath) error {
+		items[0].StringV("token")
+		return nil
+	})
+	assert.Equal(t, 3, len(events), "Access of array and through chunk reported")
+}
+
+func TestAuditHookBulk(t *testing.T) {
+	events := []string{}
+	m := NewMapPath(map[string]interface{}{
+		"secrets": map[string]interface{}{"db": "s3cr3t"},
+		"public":  "hello",
+	}, WithAuditHook(func(e AuditEvent) {
+		events = append(events, e.Path)
+	}, "secrets/*"))
+	read := func(fn func()) []string {
+		events = []string{}
+		fn()
+		return events
+	}
+
+	assert.Equal(t, []string{"secrets/db"}, read(func() { m.Has("secrets/db") }), "Has reported")
+	assert.Equal(t, []string{"secrets/db"}, read(func() { m.IsNull("secrets/db") }), "IsNull reported")
+	assert.Equal(t, []string{"secrets/db"}, read(func() {
+		m.Walk(func(path string, val interface{}) error { return nil })
+	}), "Walk reported")
+	assert.Equal(t, []string{"secrets/db"}, read(func() { m.Flatten() }), "Flatten reported")
+	assert.Equal(t, []string{"secrets/db"}, read(func() { m.ToJson() }), "ToJson reported")
+	assert.Equal(t, []string{"secrets", "secrets/db"}, read(func() { m.Paths("**") }), "Paths reported")
+	assert.Equal(t, []string{"secrets/db"}, read(func() { m.ToLabels("secrets/*") }), "ToLabels reported")
+	assert.Equal(t, []string{"secrets/db"}, read(func() { m.Partition("public") }), "Partition reported")
+	assert.Equal(t, []string{"secrets/db"}, read(func() { m.Restrict(nil, nil) }), "Restrict reported")
+	assert.Equal(t, []string{}, read(func() { m.Restrict(nil, []string{"secrets"}) }), "Hidden paths not reported")
+	assert.Equal(t, []string{"secrets/db"}, read(func() { m.Outline(0) }), "Outline reported")
+	assert.Equal(t, []string{}, read(func() { m.Outline(1) }), "Paths beyond depth not reported")
+	assert.Equal(t, []string{"secrets", "secrets/db"}, read(func() { m.Copy("secrets", "copy") }), "Copy reported")
+	assert.Equal(t, []string{}, read(func() { m.ToLabels("public") }), "Public reads not reported")
+}
+
+var matchGlobTests = []struct {
+	glob      string
+	path      string
+	ancestors bool
+	expect    bool
+}{
+	{"secrets/*", "secrets/db", false, true},
+	{"secrets/*", "secrets/db/x", false, false},
+	{"secrets/*", "secrets", false, false},
+	{"secrets/*", "secrets", true, true},
+	{"secrets/*", "public", true, false},
+	{"**/password", "a/b/password", false, true},
+	{"**/password", "password", false, true},
+	{"**/password", "a/b", true, true},
+	{"a/**", "a", false, true},
+	{"a/[xy]", "a/z", false, false},
+}
+
+func TestMatchGlob(t *testing.T) {
+	for _, test := range matchGlobTests {
+		r := matchGlob(strings.Split(test.glob, "/"), strings.Split(test.path, "/"), test.ancestors)
+		assert.Equal(t, test.expect, r, "Expected match of "+test.path+" against "+test.glob)
+	}
+}
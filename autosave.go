@@ -0,0 +1,116 @@
+package mappath
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// AutoSaver writes the structure of a MapPath back to a file after modifications, see AutoSave. The
+// embedded mutex is held while saving: lock it around modifications done from other goroutines.
+type AutoSaver struct {
+	sync.Mutex
+	m        *MapPath
+	file     string
+	debounce time.Duration
+	remove   func()
+
+	state   sync.Mutex
+	timer   *time.Timer
+	pending bool
+	closed  bool
+	err     error
+}
+
+// AutoSave writes the structure as JSON (see ToJson) to file once no modification happened for the debounce
+// duration. Modifications are noticed if done through the MapPath, MapPaths created by With and sub structures
+// created afterwards. Files are replaced atomically by writing a temporary file in the same directory, which is
+// renamed to file. Close the returned AutoSaver to stop saving and write pending modifications.
+func (this *MapPath) AutoSave(file string, debounce time.Duration) *AutoSaver {
+	saver := &AutoSaver{m: this, file: file, debounce: debounce}
+	saver.remove = this.onChange(saver.schedule)
+	return saver
+}
+
+// schedule (re)starts the debounce timer
+func (this *AutoSaver) schedule() {
+	this.state.Lock()
+	defer this.state.Unlock()
+	if this.closed {
+		return
+	}
+	this.pending = true
+	if this.timer == nil {
+		this.timer = time.AfterFunc(this.debounce, func() { this.Flush() })
+	} else {
+		this.timer.Reset(this.debounce)
+	}
+}
+
+// Flush writes pending modifications immediately
+func (this *AutoSaver) Flush() error {
+	this.state.Lock()
+	pending := this.pending
+	this.pending = false
+	if this.timer != nil {
+		this.timer.Stop()
+	}
+	this.state.Unlock()
+	if !pending {
+		return this.Err()
+	}
+
+	this.Lock()
+	err := writeFileAtomic(this.file, this.m.ToJson)
+	this.Unlock()
+
+	this.state.Lock()
+	defer this.state.Unlock()
+	this.err = err
+	return err
+}
+
+// Err returns the error of the last save, if any
+func (this *AutoSaver) Err() error {
+	this.state.Lock()
+	defer this.state.Unlock()
+	return this.err
+}
+
+// Close stops saving and writes pending modifications
+func (this *AutoSaver) Close() error {
+	this.remove()
+	err := this.Flush()
+	this.state.Lock()
+	defer this.state.Unlock()
+	this.closed = true
+	return err
+}
+
+// writeFileAtomic writes the output of render into a temporary file, which is then renamed to file
+func writeFileAtomic(file string, render func() ([]byte, error)) error {
+	out, err := render()
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(file), "."+filepath.Base(file)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(out); err != nil {
+		tmp.Close()
+		return err
+	} else if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	} else if err := tmp.Close(); err != nil {
+		return err
+	}
+	if info, err := os.Stat(file); err == nil {
+		os.Chmod(tmp.Name(), info.Mode())
+	}
+	return os.Rename(tmp.Name(), file)
+}
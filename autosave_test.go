@@ -0,0 +1,58 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * AutoSave
+ * -------
+ */
+
+func TestAutoSave(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "mappath")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "state.json")
+	ioutil.WriteFile(file, []byte(`{"counter":1,"sub":{"name":"a"}}`), 0600)
+
+	m, _ := FromJsonFile(file)
+	saver := m.AutoSave(file, 20*time.Millisecond)
+	assert.Nil(t, m.Set("counter", 2), "Value set")
+	assert.Nil(t, m.ChildV("sub").Set("name", "b"), "Value of sub structure set")
+
+	loaded, _ := FromJsonFile(file)
+	assert.Equal(t, 1, loaded.IntV("counter"), "Not saved before quiet period")
+	assert.Eventually(t, func() bool {
+		loaded, _ := FromJsonFile(file)
+		return loaded.IntV("counter") == 2 && loaded.StringV("sub/name") == "b"
+	}, time.Second, 5*time.Millisecond, "Saved after quiet period")
+	assert.Nil(t, saver.Err(), "No error on save")
+	info, _ := os.Stat(file)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "File mode kept")
+
+	assert.Nil(t, m.Set("counter", 3), "Value set")
+	assert.Nil(t, saver.Close(), "Closed")
+	loaded, _ = FromJsonFile(file)
+	assert.Equal(t, 3, loaded.IntV("counter"), "Pending modification written on close")
+
+	assert.Nil(t, m.Set("counter", 4), "Value set after close")
+	time.Sleep(40 * time.Millisecond)
+	loaded, _ = FromJsonFile(file)
+	assert.Equal(t, 3, loaded.IntV("counter"), "Not saved after close")
+	files, _ := ioutil.ReadDir(dir)
+	assert.Len(t, files, 1, "No temporary files left")
+}
+
+func TestAutoSaveError(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{})
+	saver := m.AutoSave(filepath.Join(os.TempDir(), "missing", "dir", "state.json"), time.Hour)
+	assert.Nil(t, m.Set("foo", "bar"), "Value set")
+	assert.NotNil(t, saver.Flush(), "Error on flush")
+	assert.NotNil(t, saver.Err(), "Error kept")
+}
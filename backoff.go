@@ -0,0 +1,122 @@
+package mappath
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// Backoff is a schedule of delays between retries, see MapPath.Backoff. It is either a list of explicit
+// delays or an exponential growth of the initial delay.
+type Backoff struct {
+	// Delays are the explicit delays of the attempts, the last one is used for all further attempts
+	Delays []time.Duration
+	// Initial is the delay of the first attempt of the exponential schedule
+	Initial time.Duration
+	// Max limits the delays of the exponential schedule, unless it is 0
+	Max time.Duration
+	// Factor by which the delay of the exponential schedule grows with each attempt
+	Factor float64
+	// Jitter is the fraction (0 to 1) by which delays are randomly increased or decreased
+	Jitter float64
+
+	random func() float64
+}
+
+// Backoff returns the retry schedule of path, which is either a list of delays or a map of an exponential
+// schedule, with durations as understood by Duration:
+//
+//	{"retry": ["1s", "5s", "30s"]}
+//	{"retry": {"initial": "100ms", "max": "1m", "factor": 2, "jitter": 0.2}}
+//
+// The initial delay is required, factor defaults to 2, max and jitter to 0. The random numbers of the
+// jitter can be set with WithRand.
+func (this *MapPath) Backoff(path string, fallback ...*Backoff) (*Backoff, error) {
+	if this == nil {
+		this = empty
+	}
+	val, err := this.Get(path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return nil, err
+	} else if val == nil {
+		return nil, NullValueError(path)
+	}
+
+	result := &Backoff{random: this.random}
+	if isMap(val) {
+		child, _ := this.Child(path)
+		if result.Initial, err = child.Duration("initial"); err != nil {
+			return nil, fmt.Errorf("Invalid backoff \"%s\": %s", path, err)
+		} else if result.Max, err = child.Duration("max", 0); err != nil {
+			return nil, fmt.Errorf("Invalid backoff \"%s\": %s", path, err)
+		} else if result.Factor, err = child.Float("factor", 2); err != nil {
+			return nil, fmt.Errorf("Invalid backoff \"%s\": %s", path, err)
+		} else if result.Jitter, err = child.Float("jitter", 0); err != nil {
+			return nil, fmt.Errorf("Invalid backoff \"%s\": %s", path, err)
+		} else if result.Initial <= 0 {
+			return nil, &RangeError{path + "/initial", result.Initial, "0s", math.Inf(1)}
+		} else if result.Factor < 1 {
+			return nil, &RangeError{path + "/factor", result.Factor, 1, math.Inf(1)}
+		}
+	} else if ref := reflect.ValueOf(val); ref.Kind() == reflect.Slice && ref.Len() > 0 {
+		for i := 0; i < ref.Len(); i++ {
+			d, ok := toDuration(ref.Index(i).Interface())
+			if !ok || d < 0 {
+				return nil, &InvalidTypeError{ref.Index(i).Interface(), fmt.Sprintf("[%d]array<duration>", i)}
+			}
+			result.Delays = append(result.Delays, d)
+		}
+	} else {
+		return nil, &InvalidTypeError{val, "backoff"}
+	}
+	if result.Jitter < 0 || result.Jitter > 1 {
+		return nil, &RangeError{path + "/jitter", result.Jitter, 0, 1}
+	}
+	return result, nil
+}
+
+// BackoffV returns the retry schedule of path. If value cannot be parsed then fallback or nil is returned. Handy in single value context.
+func (this *MapPath) BackoffV(path string, fallback ...*Backoff) *Backoff {
+	if val, err := this.Backoff(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return nil
+	} else {
+		return val
+	}
+}
+
+// Delay returns the delay before the given retry attempt, starting with 0
+func (this *Backoff) Delay(attempt int) time.Duration {
+	if attempt < 0 {
+		attempt = 0
+	}
+	var delay float64
+	if len(this.Delays) > 0 {
+		if attempt >= len(this.Delays) {
+			attempt = len(this.Delays) - 1
+		}
+		delay = float64(this.Delays[attempt])
+	} else {
+		delay = float64(this.Initial) * math.Pow(this.Factor, float64(attempt))
+		if this.Max > 0 && delay > float64(this.Max) {
+			delay = float64(this.Max)
+		}
+	}
+	if this.Jitter > 0 {
+		random := this.random
+		if random == nil {
+			random = empty.random
+		}
+		delay *= 1 + this.Jitter*(2*random()-1)
+	}
+	if delay > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(delay)
+}
@@ -0,0 +1,57 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * Backoff
+ * -------
+ */
+
+func TestBackoff(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"list":        []interface{}{"1s", 5, "30s"},
+		"exponential": map[string]interface{}{"initial": "100ms", "max": "1s", "factor": 3},
+		"jitter":      map[string]interface{}{"initial": "1s", "factor": 1, "jitter": 0.5},
+		"nojitter":    map[string]interface{}{"initial": "1s"},
+		"invalid":     map[string]interface{}{"initial": "1s", "jitter": 2},
+		"shrinking":   map[string]interface{}{"initial": "1s", "factor": 0.5},
+		"noinitial":   map[string]interface{}{"max": "1s"},
+		"badlist":     []interface{}{"1s", "soon"},
+		"empty":       []interface{}{},
+		"scalar":      "1s",
+	}, WithRand(rand.New(rand.NewSource(1))))
+
+	list, err := m.Backoff("list")
+	assert.Nil(t, err, "No error on list")
+	assert.Equal(t, []time.Duration{time.Second, 5 * time.Second, 30 * time.Second, 30 * time.Second}, []time.Duration{list.Delay(0), list.Delay(1), list.Delay(2), list.Delay(10)}, "Delays of list")
+
+	exp := m.BackoffV("exponential")
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 900 * time.Millisecond, time.Second}, []time.Duration{exp.Delay(0), exp.Delay(1), exp.Delay(2), exp.Delay(3)}, "Exponential delays up to max")
+	assert.Equal(t, time.Second, exp.Delay(1000), "Huge attempt capped")
+
+	assert.Equal(t, 2.0, m.BackoffV("nojitter").Factor, "Default factor")
+	assert.Equal(t, 4*time.Second, m.BackoffV("nojitter").Delay(2), "Default factor used")
+
+	jitter := m.BackoffV("jitter")
+	for i := 0; i < 20; i++ {
+		d := jitter.Delay(i)
+		assert.True(t, d >= 500*time.Millisecond && d <= 1500*time.Millisecond, "Delay within jitter")
+	}
+
+	for _, path := range []string{"invalid", "shrinking"} {
+		_, err = m.Backoff(path)
+		assert.IsType(t, &RangeError{}, err, "Range error of "+path)
+	}
+	for _, path := range []string{"noinitial", "badlist", "empty", "scalar"} {
+		_, err = m.Backoff(path)
+		assert.NotNil(t, err, "Error of "+path)
+	}
+	fallback := &Backoff{Delays: []time.Duration{time.Second}}
+	assert.Equal(t, fallback, m.BackoffV("missing", fallback), "Fallback used")
+}
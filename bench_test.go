@@ -0,0 +1,76 @@
+package mappath
+
+import (
+	"testing"
+)
+
+/*
+ * -------
+ * Benchmarks
+ * -------
+ */
+
+var benchStructure = map[string]interface{}{
+	"name":    "app",
+	"port":    8080,
+	"servers": []interface{}{map[string]interface{}{"host": "a"}, map[string]interface{}{"host": "b"}},
+	"db": map[string]interface{}{
+		"pool": map[string]interface{}{"size": 10},
+		"a/b":  "quoted",
+	},
+}
+
+var benchPaths = []struct {
+	name string
+	path string
+}{
+	{"SingleSegment", "name"},
+	{"Nested", "db/pool/size"},
+	{"ArrayElement", "servers/1/host"},
+	{"Quoted", `db/"a/b"`},
+	{"Missing", "db/pool/missing"},
+}
+
+func BenchmarkGet(b *testing.B) {
+	m := NewMapPath(benchStructure)
+	for _, bench := range benchPaths {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				m.Get(bench.path)
+			}
+		})
+	}
+}
+
+func BenchmarkGetIndexed(b *testing.B) {
+	m := NewMapPath(benchStructure, WithPathIndex())
+	for _, bench := range benchPaths {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				m.Get(bench.path)
+			}
+		})
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	m := NewMapPath(benchStructure)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.StringV("name")
+	}
+}
+
+func BenchmarkGetMany(b *testing.B) {
+	m := NewMapPath(benchStructure)
+	paths := make([]string, len(benchPaths))
+	for i, bench := range benchPaths {
+		paths[i] = bench.path
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.GetMany(paths)
+	}
+}
@@ -0,0 +1,106 @@
+package mappath
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+)
+
+// BigInt returns the arbitrary-precision integer value of path. Strings and json.Number values are
+// parsed as decimal integers, or as decimals without fractional part (eg "1.5e3") with exponents up to
+// MaxDecimalExponent. Numeric values are converted, floats must not have a fractional part. If the value
+// cannot be parsed or converted then an InvalidTypeError is returned.
+func (this *MapPath) BigInt(path string, fallback ...*big.Int) (*big.Int, error) {
+	val, err := this.Get(path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return nil, err
+	} else if val == nil {
+		return nil, NullValueError(path)
+	}
+
+	ref := reflect.ValueOf(val)
+	switch kind := ref.Kind(); {
+	case kind == reflect.Int, kind == reflect.Int8, kind == reflect.Int16, kind == reflect.Int32, kind == reflect.Int64:
+		return big.NewInt(ref.Int()), nil
+	case isOfKind(kind, kindsInt):
+		return new(big.Int).SetUint64(ref.Uint()), nil
+	case isOfKind(kind, kindsFloat):
+		if f := ref.Float(); !math.IsNaN(f) && !math.IsInf(f, 0) && f == math.Trunc(f) {
+			i, _ := big.NewFloat(f).Int(nil)
+			return i, nil
+		}
+	case kind == reflect.String:
+		if i, ok := new(big.Int).SetString(ref.String(), 10); ok {
+			return i, nil
+		} else if d, err := ParseDecimal(ref.String()); err == nil {
+			if r := d.Rat(); r.IsInt() {
+				return new(big.Int).Set(r.Num()), nil
+			}
+		}
+	}
+	return nil, &InvalidTypeError{val, "big.Int"}
+}
+
+// BigIntV returns *big.Int value of path. If value cannot be parsed or converted then fallback or nil is returned. Handy in single value context.
+func (this *MapPath) BigIntV(path string, fallback ...*big.Int) *big.Int {
+	if val, err := this.BigInt(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return nil
+	} else {
+		return val
+	}
+}
+
+// BigFloat returns the arbitrary-precision float value of path. Strings and json.Number values are
+// parsed with a precision sufficient for all their digits, numeric values are converted. If the value
+// cannot be parsed or converted then an InvalidTypeError is returned.
+func (this *MapPath) BigFloat(path string, fallback ...*big.Float) (*big.Float, error) {
+	val, err := this.Get(path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return nil, err
+	} else if val == nil {
+		return nil, NullValueError(path)
+	}
+
+	ref := reflect.ValueOf(val)
+	switch kind := ref.Kind(); {
+	case kind == reflect.Int, kind == reflect.Int8, kind == reflect.Int16, kind == reflect.Int32, kind == reflect.Int64:
+		return new(big.Float).SetInt64(ref.Int()), nil
+	case isOfKind(kind, kindsInt):
+		return new(big.Float).SetUint64(ref.Uint()), nil
+	case isOfKind(kind, kindsFloat):
+		if f := ref.Float(); !math.IsNaN(f) {
+			return big.NewFloat(f), nil
+		}
+	case kind == reflect.String:
+		s := ref.String()
+		prec := uint(len(s)) * 4
+		if prec < 64 {
+			prec = 64
+		}
+		if f, _, err := big.ParseFloat(s, 10, prec, big.ToNearestEven); err == nil {
+			return f, nil
+		}
+	}
+	return nil, &InvalidTypeError{val, "big.Float"}
+}
+
+// BigFloatV returns *big.Float value of path. If value cannot be parsed or converted then fallback or nil is returned. Handy in single value context.
+func (this *MapPath) BigFloatV(path string, fallback ...*big.Float) *big.Float {
+	if val, err := this.BigFloat(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return nil
+	} else {
+		return val
+	}
+}
@@ -0,0 +1,78 @@
+package mappath
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+/*
+ * -------
+ * Get: BigInt / BigFloat
+ * -------
+ */
+
+var bigTest = map[string]interface{}{
+	"int":      42,
+	"uint":     uint64(18446744073709551615),
+	"float":    1e20,
+	"fraction": 1.5,
+	"string":   "123456789012345678901234567890",
+	"number":   json.Number("-98765432109876543210"),
+	"decimal":  "0.1234567890123456789012345678901234567890",
+	"exp":      "1e30",
+	"hugeexp":  "1e1000000000",
+	"ratio":    "4/2",
+	"text":     "foo",
+	"null":     nil,
+}
+
+func bigIntFromString(s string) *big.Int {
+	i, _ := new(big.Int).SetString(s, 10)
+	return i
+}
+
+func TestGetBigInt(t *testing.T) {
+	m := NewMapPath(bigTest)
+	tests := map[string]*big.Int{
+		"int":    big.NewInt(42),
+		"uint":   bigIntFromString("18446744073709551615"),
+		"float":  bigIntFromString("100000000000000000000"),
+		"string": bigIntFromString("123456789012345678901234567890"),
+		"number": bigIntFromString("-98765432109876543210"),
+		"exp":    bigIntFromString("1000000000000000000000000000000"),
+	}
+	for path, expected := range tests {
+		r, err := m.BigInt(path)
+		assert.Nil(t, err, "No error for "+path)
+		assert.Equal(t, 0, expected.Cmp(r), "Expected value for "+path)
+	}
+	for _, path := range []string{"fraction", "decimal", "text", "hugeexp", "ratio"} {
+		_, err := m.BigInt(path)
+		_, ok := err.(*InvalidTypeError)
+		assert.True(t, ok, "Invalid type error for "+path)
+		assert.Nil(t, m.BigIntV(path), "Nil value for "+path)
+	}
+	assert.Equal(t, NullValueError("null"), func() error { _, e := m.BigInt("null"); return e }(), "Null value error")
+	assert.Equal(t, big.NewInt(1), m.BigIntV("missing", big.NewInt(1)), "Fallback returned")
+}
+
+func TestGetBigFloat(t *testing.T) {
+	m := NewMapPath(bigTest)
+	r, err := m.BigFloat("decimal")
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, "0.1234567890123456789012345678901234567890", r.Text('f', 40), "Full precision kept")
+
+	r, err = m.BigFloat("number")
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, "-98765432109876543210", r.Text('f', 0), "json.Number parsed")
+
+	assert.Equal(t, "1.5", m.BigFloatV("fraction").Text('f', 1), "Float converted")
+	assert.Equal(t, "42", m.BigFloatV("int").Text('f', 0), "Int converted")
+
+	_, err = m.BigFloat("text")
+	_, ok := err.(*InvalidTypeError)
+	assert.True(t, ok, "Invalid type error for unparsable string")
+	assert.Nil(t, m.BigFloatV("text"), "Nil value returned")
+}
@@ -0,0 +1,445 @@
+package mappath
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fieldTag contains the parsed `mappath:"path,option..."` and `default:"value"` struct tags of a field
+type fieldTag struct {
+	path      string
+	explicit  bool
+	skip      bool
+	required  bool
+	omitempty bool
+	squash    bool
+	def       *string
+	parser    string
+}
+
+func parseFieldTag(field reflect.StructField) fieldTag {
+	tag := fieldTag{path: field.Name}
+	if raw, ok := field.Tag.Lookup("mappath"); ok {
+		parts := strings.Split(raw, ",")
+		if parts[0] == "-" {
+			tag.skip = true
+		} else if parts[0] != "" {
+			tag.path = parts[0]
+			tag.explicit = true
+		}
+		for _, opt := range parts[1:] {
+			switch strings.TrimSpace(opt) {
+			case "required":
+				tag.required = true
+			case "omitempty":
+				tag.omitempty = true
+			case "squash":
+				tag.squash = true
+			}
+		}
+	}
+	if def, ok := field.Tag.Lookup("default"); ok {
+		tag.def = &def
+	}
+	tag.parser = field.Tag.Get("parser")
+	return tag
+}
+
+// bindDefault sets target to the default value of the tag, parsed with the parser of the tag, if any
+func (this fieldTag) bindDefault(target reflect.Value) error {
+	if this.parser != "" {
+		defaults := NewMapPath(map[string]interface{}{"default": *this.def})
+		return defaults.bindParsed(target, "default", this.parser)
+	}
+	return bindDefault(target, *this.def)
+}
+
+// Bind populates the struct target points to with the values of the MapPath. The path of a
+// field is its name, unless set with the `mappath:"server/port"` tag, which can contain any path
+// expression. Untagged field names are matched case insensitive. Supported tag options are:
+//
+//	required    missing paths return a NotFoundError
+//	squash      embedded structs are bound from the same level
+//	omitempty   zero values are not stored by FromStruct
+//	-           the field is ignored
+//
+// Missing paths are filled from a `default:"value"` tag, if provided. Values of fields with a
+// `parser:"name"` tag, and of all types with a registered parser, are converted by the parser (see
+// RegisterScalarParser). Integers must fit the field, otherwise a RangeError is returned. Nested structs,
+// pointers, slices and string-keyed maps are bound recursively.
+func (this *MapPath) Bind(target interface{}) error {
+	ref := reflect.ValueOf(target)
+	if target == nil || ref.Kind() != reflect.Ptr || ref.IsNil() || ref.Elem().Kind() != reflect.Struct {
+		return &InvalidTypeError{target, "pointer to struct"}
+	}
+	return this.bindStruct(ref.Elem(), "")
+}
+
+func (this *MapPath) bindStruct(target reflect.Value, prefix string) error {
+	typ := target.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := parseFieldTag(field)
+		if tag.skip {
+			continue
+		}
+		value := target.Field(i)
+		if tag.squash {
+			if value.Kind() == reflect.Ptr {
+				if value.IsNil() {
+					value.Set(reflect.New(value.Type().Elem()))
+				}
+				value = value.Elem()
+			}
+			if value.Kind() != reflect.Struct {
+				return &InvalidTypeError{value.Interface(), "struct to squash"}
+			}
+			if err := this.bindStruct(value, prefix); err != nil {
+				return err
+			}
+			continue
+		} else if field.PkgPath != "" {
+			continue
+		}
+
+		path := this.fieldPath(prefix, tag)
+		if !this.Has(path) {
+			if tag.def != nil {
+				if err := tag.bindDefault(value); err != nil {
+					return fmt.Errorf("Invalid default of field %s: %s", field.Name, err)
+				}
+			} else if tag.required {
+				return NotFoundError(path)
+			}
+			continue
+		}
+		if tag.parser != "" {
+			if err := this.bindParsed(value, path, tag.parser); err != nil {
+				return err
+			}
+		} else if err := this.bindValue(value, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldPath returns the path of field within prefix. Untagged field names are matched case insensitive.
+func (this *MapPath) fieldPath(prefix string, tag fieldTag) string {
+	path := prefix + tag.path
+	if tag.explicit || this.Has(path) {
+		return path
+	}
+	parent := this.root
+	if prefix != "" {
+		if m, err := this.Map(strings.TrimSuffix(prefix, "/")); err == nil {
+			parent = m
+		}
+	}
+	for key := range parent {
+		if strings.EqualFold(key, tag.path) {
+			return prefix + key
+		}
+	}
+	return path
+}
+
+func (this *MapPath) bindValue(target reflect.Value, path string) error {
+	if parser := scalarParserOf(target.Type()); parser != "" {
+		return this.bindParsed(target, path, parser)
+	}
+	switch kind := target.Kind(); {
+	case kind == reflect.Ptr:
+		if val, err := this.Get(path); err == nil && val == nil {
+			// null leaves the pointer nil
+			target.Set(reflect.Zero(target.Type()))
+			return nil
+		}
+		elem := reflect.New(target.Type().Elem())
+		if err := this.bindValue(elem.Elem(), path); err != nil {
+			return err
+		}
+		target.Set(elem)
+
+	case kind == reflect.Struct:
+		if m, err := this.Map(path); err != nil {
+			return err
+		} else if m != nil {
+			return this.bindStruct(target, path+"/")
+		}
+
+	case kind == reflect.Bool:
+		b, err := this.Bool(path)
+		if err != nil {
+			return err
+		}
+		target.SetBool(b)
+
+	case kind == reflect.Int, kind == reflect.Int8, kind == reflect.Int16, kind == reflect.Int32, kind == reflect.Int64:
+		shift := 64 - uint(target.Type().Bits())
+		i, err := this.intBetween(path, math.MinInt64>>shift, math.MaxInt64>>shift)
+		if err != nil {
+			return err
+		}
+		target.SetInt(i)
+
+	case isOfKind(kind, kindsInt):
+		u, err := this.uintBetween(path, 0, math.MaxUint64>>(64-uint(target.Type().Bits())))
+		if err != nil {
+			return err
+		}
+		target.SetUint(u)
+
+	case isOfKind(kind, kindsString), isOfKind(kind, kindsFloat):
+		val, err := this.GetAs(path, target.Type())
+		if err != nil {
+			return err
+		}
+		target.Set(reflect.ValueOf(val).Convert(target.Type()))
+
+	case kind == reflect.Slice:
+		val, _ := this.Get(path)
+		if val == nil {
+			return nil
+		} else if reflect.TypeOf(val).Kind() != reflect.Slice {
+			return &InvalidTypeError{val, "array"}
+		}
+		size := reflect.ValueOf(val).Len()
+		slice := reflect.MakeSlice(target.Type(), size, size)
+		for i := 0; i < size; i++ {
+			if err := this.bindValue(slice.Index(i), fmt.Sprintf("%s/%d", path, i)); err != nil {
+				return err
+			}
+		}
+		target.Set(slice)
+
+	case kind == reflect.Map:
+		if target.Type().Key().Kind() != reflect.String {
+			return UnsupportedTypeError(target.Type().String())
+		}
+		m, err := this.Map(path)
+		if err != nil {
+			return err
+		}
+		result := reflect.MakeMapWithSize(target.Type(), len(m))
+		for key := range m {
+			elem := reflect.New(target.Type().Elem()).Elem()
+			if err := this.bindValue(elem, path+"/"+escapeKey(key)); err != nil {
+				return err
+			}
+			result.SetMapIndex(reflect.ValueOf(key).Convert(target.Type().Key()), elem)
+		}
+		target.Set(result)
+
+	case kind == reflect.Interface:
+		val, _ := this.Get(path)
+		if val != nil {
+			target.Set(reflect.ValueOf(val))
+		}
+
+	default:
+		return UnsupportedTypeError(target.Type().String())
+	}
+	return nil
+}
+
+// bindDefault sets target to the parsed default value of a struct tag
+func bindDefault(target reflect.Value, def string) error {
+	if target.Kind() == reflect.Ptr {
+		elem := reflect.New(target.Type().Elem())
+		if err := bindDefault(elem.Elem(), def); err != nil {
+			return err
+		}
+		target.Set(elem)
+		return nil
+	}
+	if isOfKind(target.Kind(), kindsInt) {
+		return NewMapPath(map[string]interface{}{"default": def}).bindValue(target, "default")
+	}
+	var val interface{}
+	var err error
+	if target.Kind() == reflect.Bool {
+		val, err = strconv.ParseBool(def)
+	} else {
+		val, err = NewMapPath(map[string]interface{}{}).GetAs("default", target.Type(), def)
+	}
+	if err != nil {
+		return err
+	}
+	target.Set(reflect.ValueOf(val).Convert(target.Type()))
+	return nil
+}
+
+// FromStruct is a factory method to create a MapPath from a struct (or pointer to struct), which is
+// the inverse of Bind. Values are stored at the paths given by `mappath` tags, which can create
+// nested structures. Fields with the omitempty option are not stored if they have a zero value and
+// squashed embedded structs are stored on the same level. Integers are stored as int, if they fit,
+// floats as float64, so that the getters read them like decoded JSON.
+func FromStruct(source interface{}) (*MapPath, error) {
+	ref := reflect.Indirect(reflect.ValueOf(source))
+	if source == nil || ref.Kind() != reflect.Struct {
+		return nil, &InvalidTypeError{source, "struct"}
+	}
+	result := NewMapPath(map[string]interface{}{})
+	if err := result.marshalStruct(ref, ""); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (this *MapPath) marshalStruct(source reflect.Value, prefix string) error {
+	typ := source.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := parseFieldTag(field)
+		value := source.Field(i)
+		if tag.skip {
+			continue
+		} else if tag.squash {
+			if value = reflect.Indirect(value); value.Kind() == reflect.Struct {
+				if err := this.marshalStruct(value, prefix); err != nil {
+					return err
+				}
+			}
+			continue
+		} else if field.PkgPath != "" || (tag.omitempty && value.IsZero()) {
+			continue
+		}
+		val, err := marshalValue(value)
+		if err != nil {
+			return err
+		} else if err := this.Set(prefix+tag.path, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func marshalValue(value reflect.Value) (interface{}, error) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return marshalValue(value.Elem())
+	case reflect.Struct:
+		if _, ok := value.Interface().(time.Time); ok {
+			return value.Interface(), nil
+		}
+		sub := NewMapPath(map[string]interface{}{})
+		if err := sub.marshalStruct(value, ""); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}(sub.root), nil
+	case reflect.Slice:
+		if value.IsNil() {
+			return nil, nil
+		}
+		result := make([]interface{}, value.Len())
+		for i := range result {
+			val, err := marshalValue(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			result[i] = val
+		}
+		return result, nil
+	case reflect.Map:
+		if value.IsNil() {
+			return nil, nil
+		}
+		result := make(map[string]interface{}, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			val, err := marshalValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			result[fmt.Sprintf("%v", iter.Key().Interface())] = val
+		}
+		return result, nil
+	}
+	if scalarParserOf(value.Type()) != "" {
+		// eg time.Duration, which is read by its parser
+		return value.Interface(), nil
+	}
+	// the getters work with int and float64, like FromRows and FromBson convert
+	switch kind := value.Kind(); {
+	case kind == reflect.Int, kind == reflect.Int8, kind == reflect.Int16, kind == reflect.Int32, kind == reflect.Int64:
+		if i := value.Int(); int64(int(i)) == i {
+			return int(i), nil
+		}
+		return value.Int(), nil
+	case isOfKind(kind, kindsInt):
+		if u := value.Uint(); u <= math.MaxInt64 && int64(int(u)) == int64(u) {
+			return int(u), nil
+		}
+		return value.Uint(), nil
+	case kind == reflect.Float32:
+		// shortest representation, so that eg float32(0.1) becomes 0.1 and not 0.10000000149011612
+		f, _ := strconv.ParseFloat(strconv.FormatFloat(value.Float(), 'g', -1, 32), 64)
+		return f, nil
+	case kind == reflect.Float64:
+		return value.Float(), nil
+	case kind == reflect.String:
+		return value.String(), nil
+	case kind == reflect.Bool:
+		return value.Bool(), nil
+	}
+	return value.Interface(), nil
+}
+
+// ApplyDefaults reads the `default:"value"` tags of the struct (or pointer to struct) target and
+// sets the parsed defaults (with the parser of `parser:"name"` tags, like Bind) into the MapPath for all
+// paths which are missing. Paths are determined
+// the same way as in Bind, including nested and squashed structs. The target is not modified.
+func (this *MapPath) ApplyDefaults(target interface{}) error {
+	typ := reflect.TypeOf(target)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return &InvalidTypeError{target, "struct"}
+	}
+	return this.applyDefaults(typ, "")
+}
+
+func (this *MapPath) applyDefaults(typ reflect.Type, prefix string) error {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := parseFieldTag(field)
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if tag.skip || (field.PkgPath != "" && !tag.squash) {
+			continue
+		} else if tag.squash {
+			if fieldType.Kind() == reflect.Struct {
+				if err := this.applyDefaults(fieldType, prefix); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		path := this.fieldPath(prefix, tag)
+		if tag.def != nil && !this.Has(path) {
+			value := reflect.New(fieldType).Elem()
+			if err := tag.bindDefault(value); err != nil {
+				return fmt.Errorf("Invalid default of field %s: %s", field.Name, err)
+			} else if err := this.Set(path, value.Interface()); err != nil {
+				return err
+			}
+		} else if fieldType.Kind() == reflect.Struct && fieldType != reflect.TypeOf(time.Time{}) {
+			if err := this.applyDefaults(fieldType, path+"/"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * Bind
+ * -------
+ */
+
+type bindTestBase struct {
+	Name string `mappath:"name,required"`
+}
+
+type bindTestServer struct {
+	Host string `mappath:"host" default:"localhost"`
+	Port int    `mappath:"port"`
+}
+
+type bindTestConfig struct {
+	bindTestBase `mappath:",squash"`
+	Port         uint16                    `mappath:"listen/port,required"`
+	Debug        bool                      `default:"true"`
+	Workers      *int                      `mappath:"pool/size" default:"4"`
+	Ratio        float64                   `mappath:"ratio,omitempty"`
+	Server       bindTestServer            `mappath:"server"`
+	Backups      []bindTestServer          `mappath:"backups,omitempty"`
+	Tags         []string                  `mappath:"tags,omitempty"`
+	Limits       map[string]int            `mappath:"limits,omitempty"`
+	Extra        interface{}               `mappath:"extra,omitempty"`
+	Ignored      string                    `mappath:"-"`
+	Nested       map[string]bindTestServer `mappath:"nested,omitempty"`
+	hidden       string
+}
+
+var bindTest = map[string]interface{}{
+	"name": "app",
+	"listen": map[string]interface{}{
+		"port": "8080",
+	},
+	"server": map[string]interface{}{
+		"port": 80,
+	},
+	"DEBUG": false,
+	"backups": []interface{}{
+		map[string]interface{}{"host": "b1", "port": 1},
+		map[string]interface{}{"port": 2},
+	},
+	"tags":    []interface{}{"a", 1},
+	"limits":  map[string]interface{}{"cpu": "2", "mem": 512.0},
+	"extra":   []int{1, 2},
+	"Ignored": "foo",
+	"nested": map[string]interface{}{
+		"x": map[string]interface{}{"host": "x1"},
+	},
+}
+
+func TestBind(t *testing.T) {
+	var cfg bindTestConfig
+	err := NewMapPath(bindTest).Bind(&cfg)
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, "app", cfg.Name, "Squashed embedded struct bound")
+	assert.Equal(t, uint16(8080), cfg.Port, "Embedded path expression bound")
+	assert.Equal(t, false, cfg.Debug, "Untagged field matched case insensitive")
+	assert.Equal(t, 4, *cfg.Workers, "Pointer default applied")
+	assert.Equal(t, 0.0, cfg.Ratio, "Missing optional field untouched")
+	assert.Equal(t, bindTestServer{"localhost", 80}, cfg.Server, "Nested struct bound with default")
+	assert.Equal(t, []bindTestServer{{"b1", 1}, {"localhost", 2}}, cfg.Backups, "Slice of structs bound")
+	assert.Equal(t, []string{"a", "1"}, cfg.Tags, "Slice of scalars bound")
+	assert.Equal(t, map[string]int{"cpu": 2, "mem": 512}, cfg.Limits, "Map bound")
+	assert.Equal(t, []int{1, 2}, cfg.Extra, "Interface bound")
+	assert.Equal(t, "", cfg.Ignored, "Ignored field untouched")
+	assert.Equal(t, map[string]bindTestServer{"x": {"x1", 0}}, cfg.Nested, "Map of structs bound")
+}
+
+func TestBindErrors(t *testing.T) {
+	var cfg bindTestConfig
+	err := NewMapPath(map[string]interface{}{"name": "app"}).Bind(&cfg)
+	assert.Equal(t, NotFoundError("listen/port"), err, "Required path missing")
+
+	err = NewMapPath(map[string]interface{}{"listen": map[string]interface{}{"port": 1}}).Bind(&cfg)
+	assert.Equal(t, NotFoundError("name"), err, "Required path of squashed struct missing")
+
+	err = NewMapPath(map[string]interface{}{"name": "app", "listen": map[string]interface{}{"port": 1}, "server": "foo"}).Bind(&cfg)
+	assert.NotNil(t, err, "Invalid type of nested struct")
+
+	err = NewMapPath(bindTest).Bind(cfg)
+	_, ok := err.(*InvalidTypeError)
+	assert.True(t, ok, "Target must be pointer to struct")
+
+	var invalid struct {
+		Port int `default:"abc"`
+	}
+	assert.NotNil(t, NewMapPath(bindTest).Bind(&invalid), "Invalid default returns error")
+
+	var ints struct {
+		Small int8
+		Port  uint16
+		Big   int64
+	}
+	m := NewMapPath(map[string]interface{}{"small": 300, "port": 8080, "big": "9000000000"})
+	assert.Equal(t, &RangeError{"small", 300, int64(math.MinInt8), int64(math.MaxInt8)}, m.Bind(&ints), "Overflow of int8 refused")
+	m.Set("small", -128)
+	assert.Nil(t, m.Bind(&ints), "No error within range")
+	assert.Equal(t, int8(-128), ints.Small, "Int8 bound")
+	assert.Equal(t, uint16(8080), ints.Port, "Uint16 bound")
+	assert.Equal(t, int64(9000000000), ints.Big, "Int64 bound")
+	m.Set("port", -1)
+	assert.Equal(t, &RangeError{"port", -1, uint64(0), uint64(math.MaxUint16)}, m.Bind(&ints), "Negative unsigned refused")
+	m.Set("port", 80.5)
+	assert.IsType(t, &InvalidTypeError{}, m.Bind(&ints), "Fraction refused")
+
+	var overflow struct {
+		Small int8 `default:"300"`
+	}
+	assert.NotNil(t, NewMapPath(bindTest).Bind(&overflow), "Overflow of default refused")
+}
+
+/*
+ * -------
+ * FromStruct
+ * -------
+ */
+
+func TestFromStruct(t *testing.T) {
+	workers := 3
+	m, err := FromStruct(&bindTestConfig{
+		bindTestBase: bindTestBase{"app"},
+		Port:         8080,
+		Workers:      &workers,
+		Server:       bindTestServer{"example.com", 80},
+		Tags:         []string{"a"},
+		Ignored:      "foo",
+	})
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, map[string]interface{}{
+		"name": "app",
+		"listen": map[string]interface{}{
+			"port": 8080,
+		},
+		"server": map[string]interface{}{
+			"port": 80,
+			"host": "example.com",
+		},
+		"Debug": false,
+		"pool": map[string]interface{}{
+			"size": 3,
+		},
+		"tags": []interface{}{"a"},
+	}, m.Root(), "Struct marshalled with omitempty")
+
+	var back bindTestConfig
+	assert.Nil(t, m.Bind(&back), "Marshalled struct can be bound")
+	assert.Equal(t, uint16(8080), back.Port, "Roundtrip keeps values")
+
+	_, err = FromStruct("foo")
+	assert.NotNil(t, err, "Error on non-struct")
+}
+
+func TestBindNullPointer(t *testing.T) {
+	port, name := 1, "x"
+	cfg := struct {
+		Port *int
+		Name *string
+		Host *string
+	}{&port, &name, nil}
+	m := NewMapPath(map[string]interface{}{"port": nil, "name": nil, "host": "example.com"})
+	assert.Nil(t, m.Bind(&cfg), "No error on null pointers")
+	assert.Nil(t, cfg.Port, "Null int pointer is nil")
+	assert.Nil(t, cfg.Name, "Null string pointer is nil")
+	if assert.NotNil(t, cfg.Host, "Value bound") {
+		assert.Equal(t, "example.com", *cfg.Host, "Value of pointer bound")
+	}
+}
+
+func TestFromStructNumbers(t *testing.T) {
+	type level string
+	m, err := FromStruct(struct {
+		Port    int64
+		Small   int8
+		Count   uint
+		Huge    uint64
+		Ratio   float32
+		Level   level
+		Timeout time.Duration
+	}{8080, -3, 7, math.MaxUint64, 0.1, "debug", time.Minute})
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, 8080, m.IntV("Port"), "Int64 read with Int")
+	assert.Equal(t, -3, m.IntV("Small"), "Int8 read with Int")
+	assert.Equal(t, 7, m.IntV("Count"), "Uint read with Int")
+	assert.Equal(t, uint64(math.MaxUint64), m.Uint64V("Huge"), "Large uint64 kept")
+	f, err := m.Float("Ratio")
+	assert.Nil(t, err, "Float32 read with Float")
+	assert.Equal(t, 0.1, f, "Float32 converted exactly")
+	assert.Equal(t, "debug", m.StringV("Level"), "Named string read with String")
+	assert.Equal(t, time.Minute, m.DurationV("Timeout"), "Duration kept")
+}
+
+/*
+ * -------
+ * ApplyDefaults
+ * -------
+ */
+
+func TestApplyDefaults(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"server": map[string]interface{}{
+			"host": "example.com",
+		},
+		"Debug": false,
+	})
+	err := m.ApplyDefaults((*bindTestConfig)(nil))
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, map[string]interface{}{
+		"server": map[string]interface{}{
+			"host": "example.com",
+		},
+		"Debug": false,
+		"pool": map[string]interface{}{
+			"size": 4,
+		},
+	}, m.Root(), "Defaults applied for missing paths")
+
+	err = NewMapPath(map[string]interface{}{}).ApplyDefaults(bindTestServer{})
+	assert.Nil(t, err, "No error for struct value")
+
+	err = m.ApplyDefaults("foo")
+	assert.NotNil(t, err, "Error for non-struct")
+}
@@ -0,0 +1,44 @@
+package mappath
+
+import (
+	"sort"
+)
+
+// MapPath returns a MapPath on the branch with the given options. The branch is not copied.
+func (this Branch) MapPath(opts ...Option) *MapPath {
+	return NewMapPath(this, opts...)
+}
+
+// Path returns the value of path within the branch and whether it exists, see Get
+func (this Branch) Path(path string) (interface{}, bool) {
+	val, found, err := this.MapPath().lookup(path)
+	return val, found && err == nil
+}
+
+// Set stores value at path within the branch, see MapPath.Set. A nil branch cannot be modified and
+// returns ErrEmptyReadOnly.
+func (this Branch) Set(path string, value interface{}) error {
+	if this == nil {
+		return ErrEmptyReadOnly
+	}
+	return this.MapPath().Set(path, value)
+}
+
+// Keys returns the sorted keys of the branch
+func (this Branch) Keys() []string {
+	keys := make([]string, 0, len(this))
+	for key := range this {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Branch returns the root of the MapPath as Branch. The root is not copied. Returns nil on Empty (or nil),
+// which must not be modified.
+func (this *MapPath) Branch() Branch {
+	if this == nil || this == empty {
+		return nil
+	}
+	return this.root
+}
@@ -0,0 +1,40 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Branch
+ * -------
+ */
+
+func TestBranch(t *testing.T) {
+	b := Branch{
+		"name": "app",
+		"db":   map[string]interface{}{"hosts": []interface{}{"a", "b"}},
+	}
+	val, found := b.Path("db/hosts/1")
+	assert.True(t, found, "Nested value found")
+	assert.Equal(t, "b", val, "Nested value")
+	_, found = b.Path("db/missing")
+	assert.False(t, found, "Missing value")
+	_, found = b.Path(`db/"unterminated`)
+	assert.False(t, found, "Invalid path")
+
+	assert.Nil(t, b.Set("db/port", 5432), "Value set")
+	assert.Equal(t, 5432, b.MapPath().IntV("db/port"), "Set on the branch")
+	assert.Equal(t, []string{"db", "name"}, b.Keys(), "Sorted keys")
+
+	m := b.MapPath(WithSortedKeys())
+	assert.True(t, m.opts().sortedKeys, "Options applied")
+	assert.Equal(t, b, m.Branch(), "Branch of MapPath")
+	assert.Nil(t, (*MapPath)(nil).Branch(), "Branch of nil")
+
+	assert.Nil(t, Empty().Branch(), "Branch of empty")
+	assert.Equal(t, ErrEmptyReadOnly, Empty().Branch().Set("x", 1), "Set on branch of empty refused")
+	assert.Nil(t, Empty().Root(), "Root of empty")
+	assert.False(t, Empty().Has("x"), "Empty not modified")
+}
@@ -0,0 +1,193 @@
+// Package bsonpath converts between MapPath structures and MongoDB documents, so that the mappath
+// package itself does not depend on the MongoDB driver.
+package bsonpath
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/ukautz/mappath"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FromBson is a factory method to create a MapPath from a MongoDB document, which can be
+// raw BSON ([]byte or bson.Raw), bson.M or bson.D. Nested documents become maps, arrays become
+// []interface{}, DateTime values become time.Time and int32/int64 values become int. ObjectIDs
+// are kept as primitive.ObjectID and can be read with ObjectID. Mind that the order of the keys
+// of bson.D documents is lost, see ToBson for the order of converted documents.
+func FromBson(doc interface{}, opts ...mappath.Option) (*mappath.MapPath, error) {
+	switch d := doc.(type) {
+	case []byte:
+		return FromBson(bson.Raw(d), opts...)
+	case bson.Raw:
+		var data bson.D
+		if err := bson.Unmarshal(d, &data); err != nil {
+			return nil, err
+		}
+		doc = data
+	}
+
+	root, ok := fromBsonValue(doc).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Cannot use BSON of type %+v. Must be a document", reflect.TypeOf(doc))
+	}
+	return mappath.NewMapPath(root, opts...), nil
+}
+
+func fromBsonValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case bson.D:
+		m := make(map[string]interface{}, len(v))
+		for _, e := range v {
+			m[e.Key] = fromBsonValue(e.Value)
+		}
+		return m
+	case bson.M:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = fromBsonValue(e)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = fromBsonValue(e)
+		}
+		return m
+	case bson.A:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = fromBsonValue(e)
+		}
+		return a
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = fromBsonValue(e)
+		}
+		return a
+	case primitive.DateTime:
+		return v.Time().UTC()
+	case int32:
+		return int(v)
+	case int64:
+		if int64(int(v)) == v {
+			return int(v)
+		}
+	}
+	return val
+}
+
+// ToBson converts the MapPath into a bson.D document, which can be passed to the MongoDB driver.
+// Keys are ordered with "_id" first, followed by all other keys in lexical order, so the output
+// is deterministic. Nested maps become bson.D, arrays become bson.A and time.Time values become
+// DateTime. The structure is read with Root, so that no reads are reported to audit hooks.
+func ToBson(m *mappath.MapPath) (bson.D, error) {
+	root := m.Root()
+	if root == nil {
+		root = map[string]interface{}{}
+	}
+	doc, err := toBsonValue(root)
+	if err != nil {
+		return nil, err
+	}
+	return doc.(bson.D), nil
+}
+
+func toBsonValue(val interface{}) (interface{}, error) {
+	if m, ok := stringMap(val); ok {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[j] == "_id" {
+				return false
+			}
+			return keys[i] == "_id" || keys[i] < keys[j]
+		})
+		doc := make(bson.D, len(keys))
+		for i, k := range keys {
+			v, err := toBsonValue(m[k])
+			if err != nil {
+				return nil, err
+			}
+			doc[i] = bson.E{Key: k, Value: v}
+		}
+		return doc, nil
+	}
+
+	switch v := val.(type) {
+	case time.Time:
+		return primitive.NewDateTimeFromTime(v), nil
+	case []byte:
+		return v, nil
+	}
+
+	if val != nil && reflect.TypeOf(val).Kind() == reflect.Slice {
+		ref := reflect.ValueOf(val)
+		a := make(bson.A, ref.Len())
+		for i := range a {
+			v, err := toBsonValue(ref.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			a[i] = v
+		}
+		return a, nil
+	}
+	return val, nil
+}
+
+// stringMap returns maps of any key type as map[string]interface{}
+func stringMap(val interface{}) (map[string]interface{}, bool) {
+	if m, ok := val.(map[string]interface{}); ok {
+		return m, true
+	}
+	ref := reflect.ValueOf(val)
+	if ref.Kind() != reflect.Map {
+		return nil, false
+	}
+	m := make(map[string]interface{}, ref.Len())
+	iter := ref.MapRange()
+	for iter.Next() {
+		m[fmt.Sprintf("%v", iter.Key().Interface())] = iter.Value().Interface()
+	}
+	return m, true
+}
+
+// ObjectID returns the MongoDB ObjectID of path. The value can either be a primitive.ObjectID or
+// its hex string representation. Fallback is returned, if the path does not exist.
+func ObjectID(m *mappath.MapPath, path string, fallback ...primitive.ObjectID) (primitive.ObjectID, error) {
+	val, err := m.Get(path)
+	if err != nil {
+		if _, ok := err.(mappath.NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return primitive.NilObjectID, err
+	} else if val == nil {
+		return primitive.NilObjectID, mappath.NullValueError(path)
+	}
+	switch v := val.(type) {
+	case primitive.ObjectID:
+		return v, nil
+	case string:
+		return primitive.ObjectIDFromHex(v)
+	}
+	return primitive.NilObjectID, fmt.Errorf("Could not cast %v of path \"%s\" into ObjectID", reflect.TypeOf(val), path)
+}
+
+// ObjectIDV returns the MongoDB ObjectID of path. If value cannot be parsed or converted then fallback or NilObjectID is returned. Handy in single value context.
+func ObjectIDV(m *mappath.MapPath, path string, fallback ...primitive.ObjectID) primitive.ObjectID {
+	if val, err := ObjectID(m, path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return primitive.NilObjectID
+	} else {
+		return val
+	}
+}
@@ -0,0 +1,109 @@
+package bsonpath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/ukautz/mappath"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * BSON
+ * -------
+ */
+
+var bsonTestID, _ = primitive.ObjectIDFromHex("5f1d7a2b9c8e4a0012345678")
+var bsonTestTime = time.Date(2020, 7, 26, 12, 0, 0, 0, time.UTC)
+
+var bsonTest = bson.D{
+	{Key: "name", Value: "alice"},
+	{Key: "_id", Value: bsonTestID},
+	{Key: "created", Value: primitive.NewDateTimeFromTime(bsonTestTime)},
+	{Key: "logins", Value: int32(3)},
+	{Key: "profile", Value: bson.M{
+		"tags":    bson.A{"a", "b"},
+		"address": bson.D{{Key: "city", Value: "Berlin"}},
+	}},
+}
+
+func TestFromBsonDocument(t *testing.T) {
+	m, err := FromBson(bsonTest)
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, "alice", m.StringV("name"), "String read")
+	assert.Equal(t, 3, m.IntV("logins"), "Int32 converted")
+	assert.Equal(t, bsonTestTime, m.Root()["created"], "DateTime converted into time.Time")
+	assert.Equal(t, []string{"a", "b"}, m.StringsV("profile/tags"), "Array from bson.M read")
+	assert.Equal(t, "Berlin", m.StringV("profile/address/city"), "Nested bson.D read")
+	assert.Equal(t, bsonTestID, ObjectIDV(m, "_id"), "ObjectID read")
+}
+
+func TestFromBsonOptions(t *testing.T) {
+	m, err := FromBson(bsonTest, mappath.WithSortedKeys())
+	assert.Nil(t, err, "No error returned")
+	paths := []string{}
+	m.Walk(func(path string, val interface{}) error {
+		paths = append(paths, path)
+		return nil
+	})
+	assert.Equal(t, "_id", paths[0], "Options applied")
+}
+
+func TestFromBsonRaw(t *testing.T) {
+	raw, err := bson.Marshal(bsonTest)
+	assert.Nil(t, err, "Test document marshalled")
+	m, err := FromBson(raw)
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, "Berlin", m.StringV("profile/address/city"), "Nested document read")
+	assert.Equal(t, bsonTestID, ObjectIDV(m, "_id"), "ObjectID read")
+
+	m, err = FromBson([]byte("invalid"))
+	assert.NotNil(t, err, "Error on invalid BSON")
+	assert.Nil(t, m, "No result returned")
+	m, err = FromBson("invalid")
+	assert.NotNil(t, err, "Error on unsupported type")
+	assert.Nil(t, m, "No result returned")
+}
+
+func TestToBson(t *testing.T) {
+	m, _ := FromBson(bsonTest)
+	doc, err := ToBson(m)
+	assert.Nil(t, err, "No error returned")
+	keys := []string{}
+	for _, e := range doc {
+		keys = append(keys, e.Key)
+	}
+	assert.Equal(t, []string{"_id", "created", "logins", "name", "profile"}, keys, "Keys ordered with _id first")
+	assert.Equal(t, primitive.NewDateTimeFromTime(bsonTestTime), doc[1].Value, "Time converted into DateTime")
+	assert.Equal(t, bson.D{{Key: "address", Value: bson.D{{Key: "city", Value: "Berlin"}}}, {Key: "tags", Value: bson.A{"a", "b"}}}, doc[4].Value, "Nested maps converted")
+
+	raw, err := bson.Marshal(doc)
+	assert.Nil(t, err, "Result can be marshalled")
+	back, _ := FromBson(raw)
+	assert.Equal(t, m.Root(), back.Root(), "Roundtrip keeps data")
+
+	doc, err = ToBson(nil)
+	assert.Nil(t, err, "No error for nil")
+	assert.Equal(t, bson.D{}, doc, "Empty document for nil")
+}
+
+func TestObjectID(t *testing.T) {
+	m := mappath.NewMapPath(map[string]interface{}{
+		"hex":     "5f1d7a2b9c8e4a0012345678",
+		"invalid": "xyz",
+		"number":  123,
+	})
+	r, err := ObjectID(m, "hex")
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, bsonTestID, r, "ObjectID parsed from hex")
+	for _, path := range []string{"invalid", "number", "missing"} {
+		_, err = ObjectID(m, path)
+		assert.NotNil(t, err, "Error returned for "+path)
+		assert.Equal(t, primitive.NilObjectID, ObjectIDV(m, path), "Nil value returned for "+path)
+	}
+	r, err = ObjectID(m, "missing", bsonTestID)
+	assert.Nil(t, err, "No error when fallback used")
+	assert.Equal(t, bsonTestID, r, "Fallback returned")
+}
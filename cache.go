@@ -0,0 +1,108 @@
+package mappath
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CachedSource is a concurrency safe read-through cache of a (remote) Source. Loaded structures are
+// served for the TTL. Afterwards they are served for the stale period while being refreshed in the
+// background. Once stale they are reloaded before being served. Concurrent loads of the source are
+// deduplicated, so that the source is loaded at most once at a time.
+//
+// Structures returned by Load are shared by all callers and must not be modified.
+type CachedSource struct {
+	source Source
+	ttl    time.Duration
+	stale  time.Duration
+	clock  Clock
+
+	mu         sync.Mutex
+	current    *MapPath
+	loaded     time.Time
+	loading    *cacheLoad
+	generation uint64
+}
+
+// cacheLoad is an in-flight load of the source, shared by all waiting callers
+type cacheLoad struct {
+	done       chan struct{}
+	m          *MapPath
+	err        error
+	generation uint64
+}
+
+// NewCachedSource returns a cache of source, which serves loaded structures for ttl and stale ones for
+// another stale period while refreshing them in the background
+func NewCachedSource(source Source, ttl, stale time.Duration) *CachedSource {
+	return &CachedSource{source: source, ttl: ttl, stale: stale}
+}
+
+// Load returns the cached structure. Missing or stale structures are loaded from the source, waiting
+// until the load finishes or the context is done. Failed loads are not cached, but an expired structure
+// (within the stale period) is kept if its background refresh fails.
+func (this *CachedSource) Load(ctx context.Context) (*MapPath, error) {
+	this.mu.Lock()
+	if this.current != nil {
+		age := this.now().Sub(this.loaded)
+		if age < this.ttl {
+			defer this.mu.Unlock()
+			return this.current, nil
+		} else if age < this.ttl+this.stale {
+			defer this.mu.Unlock()
+			this.refresh()
+			return this.current, nil
+		}
+	}
+	load := this.refresh()
+	this.mu.Unlock()
+
+	select {
+	case <-load.done:
+		return load.m, load.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Invalidate drops the cached structure, so that the next Load reloads it from the source. The result of
+// a load in flight, which started before, is not cached.
+func (this *CachedSource) Invalidate() {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	this.current = nil
+	this.loading = nil
+	this.generation++
+}
+
+// refresh starts loading the source in the background, unless a load is in flight already, and returns
+// the load. Must be called with the lock held.
+func (this *CachedSource) refresh() *cacheLoad {
+	if this.loading != nil {
+		return this.loading
+	}
+	load := &cacheLoad{done: make(chan struct{}), generation: this.generation}
+	this.loading = load
+	go func() {
+		load.m, load.err = this.source.Load(context.Background())
+		this.mu.Lock()
+		defer this.mu.Unlock()
+		// loads started before an invalidation are outdated
+		if load.generation == this.generation {
+			if load.err == nil {
+				this.current, this.loaded = load.m, this.now()
+			}
+			this.loading = nil
+		}
+		close(load.done)
+	}()
+	return load
+}
+
+func (this *CachedSource) now() time.Time {
+	if this.clock != nil {
+		return this.clock.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,132 @@
+package mappath
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * CachedSource
+ * -------
+ */
+
+type cacheTestSource struct {
+	loads int32
+	fail  int32
+	block chan struct{}
+}
+
+func (this *cacheTestSource) Load(ctx context.Context) (*MapPath, error) {
+	n := atomic.AddInt32(&this.loads, 1)
+	if this.block != nil {
+		<-this.block
+	}
+	if atomic.LoadInt32(&this.fail) > 0 {
+		return nil, fmt.Errorf("Unavailable")
+	}
+	return NewMapPath(map[string]interface{}{"version": int(n)}), nil
+}
+
+func TestCachedSource(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	src := &cacheTestSource{}
+	cache := NewCachedSource(src, time.Minute, time.Minute)
+	cache.clock = ClockFunc(func() time.Time { return now })
+	ctx := context.Background()
+
+	m, err := cache.Load(ctx)
+	assert.Nil(t, err, "No error on initial load")
+	assert.Equal(t, 1, m.IntV("version"), "Initially loaded")
+	m, _ = cache.Load(ctx)
+	assert.Equal(t, 1, m.IntV("version"), "Served from cache")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&src.loads), "Loaded once")
+
+	now = now.Add(90 * time.Second)
+	m, _ = cache.Load(ctx)
+	assert.Equal(t, 1, m.IntV("version"), "Stale structure served")
+	assert.Eventually(t, func() bool {
+		m, _ := cache.Load(ctx)
+		return m.IntV("version") == 2
+	}, time.Second, time.Millisecond, "Refreshed in background")
+
+	atomic.StoreInt32(&src.fail, 1)
+	now = now.Add(90 * time.Second)
+	assert.Eventually(t, func() bool {
+		m, _ := cache.Load(ctx)
+		return atomic.LoadInt32(&src.loads) == 3 && m.IntV("version") == 2
+	}, time.Second, time.Millisecond, "Stale structure kept on failed refresh")
+
+	now = now.Add(5 * time.Minute)
+	_, err = cache.Load(ctx)
+	assert.EqualError(t, err, "Unavailable", "Expired structure not served")
+
+	atomic.StoreInt32(&src.fail, 0)
+	cache.Invalidate()
+	m, err = cache.Load(ctx)
+	assert.Nil(t, err, "No error after recovery")
+	assert.True(t, m.IntV("version") > 2, "Reloaded after invalidation")
+}
+
+func TestCachedSourceConcurrent(t *testing.T) {
+	src := &cacheTestSource{block: make(chan struct{})}
+	cache := NewCachedSource(src, time.Minute, 0)
+
+	var wg sync.WaitGroup
+	results := make(chan int, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m, _ := cache.Load(context.Background())
+			results <- m.IntV("version")
+		}()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err := cache.Load(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err, "Waiting respects context")
+
+	close(src.block)
+	wg.Wait()
+	close(results)
+	for version := range results {
+		assert.Equal(t, 1, version, "Shared result of single load")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&src.loads), "Concurrent loads deduplicated")
+}
+
+func TestCachedSourceInvalidateInFlight(t *testing.T) {
+	var loads int32
+	first := make(chan struct{})
+	cache := NewCachedSource(SourceFunc(func(ctx context.Context) (*MapPath, error) {
+		n := atomic.AddInt32(&loads, 1)
+		if n == 1 {
+			<-first
+		}
+		return NewMapPath(map[string]interface{}{"version": int(n)}), nil
+	}), time.Minute, 0)
+
+	outdated := make(chan *MapPath)
+	go func() {
+		m, _ := cache.Load(context.Background())
+		outdated <- m
+	}()
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&loads) == 1 }, time.Second, time.Millisecond, "Load in flight")
+	cache.Invalidate()
+
+	m, err := cache.Load(context.Background())
+	assert.Nil(t, err, "No error on reload")
+	assert.Equal(t, 2, m.IntV("version"), "Reloaded after invalidation")
+
+	close(first)
+	assert.Equal(t, 1, (<-outdated).IntV("version"), "Waiting caller served by its load")
+	m, _ = cache.Load(context.Background())
+	assert.Equal(t, 2, m.IntV("version"), "Outdated load not cached")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&loads), "No further load")
+}
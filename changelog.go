@@ -0,0 +1,189 @@
+package mappath
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Operations of a Change
+const (
+	// ChangeSet is the modification of a single path, eg by Set
+	ChangeSet = "set"
+	// ChangeDelete is the removal of a single path, eg by RenameKey
+	ChangeDelete = "delete"
+	// ChangeReplace is the replacement of the whole (sub) structure by bulk modifications, eg Resolve
+	ChangeReplace = "replace"
+)
+
+// Change is a modification of the structure recorded in the changelog, see WithChangelog
+type Change struct {
+	// Op is the operation: ChangeSet, ChangeDelete or ChangeReplace
+	Op string
+	// Path is the modified path from the root, for ChangeReplace the path of the replaced sub structure
+	// or empty for the root
+	Path string
+	// Old is the value before the modification, nil if the path did not exist
+	Old interface{}
+	// New is the value after the modification, nil for ChangeDelete
+	New interface{}
+	// Existed is whether the path existed before the modification
+	Existed bool
+	// Time of the modification, see WithClock
+	Time time.Time
+	// Actor is the label set with WithActor
+	Actor string
+
+	// created is the topmost path created by the modification, which is removed to revert it
+	created string
+}
+
+// changelog records the modifications of a structure
+type changelog struct {
+	mu      sync.Mutex
+	max     int
+	changes []Change
+}
+
+// WithChangelog makes the MapPath record all modifications (Set, RenameKey, Resolve, ..) done through it,
+// MapPaths created by With or its sub structures. The log keeps the last max changes, or all if max is
+// 0. Replacements (ChangeReplace) contain deep copies of the whole structure before and after. The
+// recorded changes can be retrieved with Changes.
+func WithChangelog(max int) Option {
+	return func(o *options) {
+		o.changelog = &changelog{max: max}
+	}
+}
+
+// WithActor sets the label of the actor recorded with each change, see WithChangelog. Use With to
+// modify with a specific actor:
+//
+//	err := m.With(WithActor("alice")).Set("feature/enabled", true)
+func WithActor(actor string) Option {
+	return func(o *options) {
+		o.actor = actor
+	}
+}
+
+// Changes returns the recorded changes made after since, oldest first. Returns nil if the changelog is
+// not enabled, see WithChangelog.
+func (this *MapPath) Changes(since time.Time) []Change {
+	if this == nil || this.opts().changelog == nil {
+		return nil
+	}
+	log := this.opts().changelog
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	result := []Change{}
+	for _, change := range log.changes {
+		if change.Time.After(since) {
+			result = append(result, change)
+		}
+	}
+	return result
+}
+
+// record adds the changes of a single modification to the changelog and the history, if enabled
+func (this *MapPath) record(changes ...Change) {
+	if len(changes) == 0 || this.opts().changelog == nil && this.opts().history == nil {
+		return
+	}
+	for i := range changes {
+		change := &changes[i]
+		change.Time = this.now()
+		change.Actor = this.opts().actor
+		if change.Path = this.prefix + change.Path; change.Op == ChangeReplace {
+			change.Path = strings.TrimSuffix(change.Path, "/")
+		}
+		if change.created != "" {
+			change.created = this.prefix + change.created
+		}
+	}
+	if this.opts().history != nil {
+		this.opts().history.push(changes)
+	}
+	this.opts().changelog.add(changes)
+}
+
+// add appends the changes to the log, keeping the last max
+func (this *changelog) add(changes []Change) {
+	if this == nil {
+		return
+	}
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	this.changes = append(this.changes, changes...)
+	if this.max > 0 && len(this.changes) > this.max {
+		this.changes = append([]Change{}, this.changes[len(this.changes)-this.max:]...)
+	}
+}
+
+// subPrefix returns the prefix of the sub structure at path, for recording changes and auditing reads with
+// paths from the root
+func (this *MapPath) subPrefix(path string) string {
+	if this.opts().changelog == nil && this.opts().history == nil && this.opts().audit == nil {
+		return ""
+	} else if keys, err := splitPath(strings.Trim(path, "/")); err == nil {
+		return this.prefix + formatKeys(keys) + "/"
+	}
+	return this.prefix + path + "/"
+}
+
+// snapshot returns a deep copy of the structure, if the changelog is enabled, for recordReplace
+func (this *MapPath) snapshot() interface{} {
+	if this.opts().changelog == nil && this.opts().history == nil {
+		return nil
+	}
+	return deepCopy(map[string]interface{}(this.root))
+}
+
+// recordReplace records the replacement of the structure, which was before as in the snapshot
+func (this *MapPath) recordReplace(before interface{}) {
+	if this.opts().changelog == nil && this.opts().history == nil {
+		return
+	}
+	after := deepCopy(map[string]interface{}(this.root))
+	if !reflect.DeepEqual(before, after) {
+		this.record(Change{Op: ChangeReplace, Old: before, New: after, Existed: true})
+	}
+}
+
+// deepCopy returns a copy of val with all nested maps and arrays copied
+func deepCopy(val interface{}) interface{} {
+	if val == nil {
+		return nil
+	}
+	ref := reflect.ValueOf(val)
+	switch ref.Kind() {
+	case reflect.Map:
+		result := reflect.MakeMapWithSize(ref.Type(), ref.Len())
+		iter := ref.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(iter.Key(), deepCopyValue(iter.Value(), ref.Type().Elem()))
+		}
+		return result.Interface()
+	case reflect.Slice:
+		if ref.IsNil() {
+			return val
+		}
+		result := reflect.MakeSlice(ref.Type(), ref.Len(), ref.Len())
+		for i := 0; i < ref.Len(); i++ {
+			result.Index(i).Set(deepCopyValue(ref.Index(i), ref.Type().Elem()))
+		}
+		return result.Interface()
+	}
+	return val
+}
+
+// deepCopyValue returns a deep copy of the element, assignable to typ
+func deepCopyValue(val reflect.Value, typ reflect.Type) reflect.Value {
+	if val.Kind() == reflect.Interface && val.IsNil() {
+		return reflect.Zero(typ)
+	}
+	copied := deepCopy(val.Interface())
+	if copied == nil {
+		return reflect.Zero(typ)
+	}
+	return reflect.ValueOf(copied).Convert(typ)
+}
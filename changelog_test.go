@@ -0,0 +1,88 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * Changelog
+ * -------
+ */
+
+func testChangelogClock() Option {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	return WithClock(ClockFunc(func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}))
+}
+
+func TestChangelog(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"name":    "app",
+		"servers": []interface{}{map[string]interface{}{"host": "a"}},
+		"db":      map[string]interface{}{"host": "localhost"},
+	}, WithChangelog(0), testChangelogClock())
+
+	assert.Nil(t, m.Set("name", "demo"), "Value set")
+	assert.Nil(t, m.With(WithActor("alice")).Set("new/nested/key", 1), "Value set by actor")
+	assert.Nil(t, m.ChildV("db").Set("port", 5432), "Value of sub structure set")
+	assert.Nil(t, m.ChildsV("servers")[0].Set("host", "b"), "Value of array element set")
+	assert.Nil(t, m.RenameKey("db", "host", "hostname"), "Key renamed")
+
+	changes := m.Changes(time.Time{})
+	assert.Len(t, changes, 6, "All changes recorded")
+	expected := []Change{
+		{Op: ChangeSet, Path: "name", Old: "app", New: "demo", Existed: true},
+		{Op: ChangeSet, Path: "new/nested/key", New: 1, Actor: "alice", created: "new"},
+		{Op: ChangeSet, Path: "db/port", New: 5432, created: "db/port"},
+		{Op: ChangeSet, Path: "servers/0/host", Old: "a", New: "b", Existed: true},
+		{Op: ChangeDelete, Path: "db/host", Old: "localhost", Existed: true},
+		{Op: ChangeSet, Path: "db/hostname", New: "localhost", created: "db/hostname"},
+	}
+	for i, change := range changes {
+		assert.Equal(t, time.Date(2020, 1, 1, 0, 0, i+1, 0, time.UTC), change.Time, "Time of change")
+		change.Time = time.Time{}
+		assert.Equal(t, expected[i], change, "Change recorded")
+	}
+	assert.Len(t, m.Changes(changes[3].Time), 2, "Changes since")
+
+	assert.NotNil(t, m.Set("servers/5/host", "x"), "Failed modification")
+	assert.Len(t, m.Changes(time.Time{}), 6, "Failed modification not recorded")
+	assert.Nil(t, NewMapPath(map[string]interface{}{}).Changes(time.Time{}), "No changelog by default")
+}
+
+func TestChangelogReplace(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"debug":    false,
+		"profiles": map[string]interface{}{"dev": map[string]interface{}{"debug": true}},
+	}, WithChangelog(1), testChangelogClock())
+	assert.Nil(t, m.Set("name", "app"), "Value set")
+	assert.Nil(t, m.Profile("dev"), "Profile applied")
+	changes := m.Changes(time.Time{})
+	assert.Len(t, changes, 1, "Changelog bounded")
+	assert.Equal(t, ChangeReplace, changes[0].Op, "Replacement recorded")
+	assert.Equal(t, "", changes[0].Path, "Replacement of root")
+	assert.Equal(t, false, changes[0].Old.(map[string]interface{})["debug"], "Copy of structure before")
+	assert.Equal(t, map[string]interface{}{"debug": true, "name": "app"}, changes[0].New, "Copy of structure after")
+}
+
+func TestDeepCopy(t *testing.T) {
+	orig := map[string]interface{}{
+		"list":  []interface{}{map[string]interface{}{"a": 1}, nil},
+		"ints":  []int{1, 2},
+		"typed": map[string]string{"x": "y"},
+		"null":  nil,
+	}
+	copied := deepCopy(orig).(map[string]interface{})
+	assert.Equal(t, orig, copied, "Copy equals original")
+	copied["list"].([]interface{})[0].(map[string]interface{})["a"] = 2
+	copied["ints"].([]int)[0] = 3
+	copied["typed"].(map[string]string)["x"] = "z"
+	assert.Equal(t, 1, orig["list"].([]interface{})[0].(map[string]interface{})["a"], "Nested map copied")
+	assert.Equal(t, 1, orig["ints"].([]int)[0], "Typed slice copied")
+	assert.Equal(t, "y", orig["typed"].(map[string]string)["x"], "Typed map copied")
+}
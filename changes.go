@@ -0,0 +1,55 @@
+package mappath
+
+import (
+	"sync"
+)
+
+// changeListeners are notified about modifications of the structure. They are shared with all sub
+// structures and MapPaths created by With.
+type changeListeners struct {
+	mu        sync.Mutex
+	listeners []*func()
+}
+
+// onChange registers fn to be called after every modification through the MapPath or its sub structures
+// and returns a function removing it again
+func (this *MapPath) onChange(fn func()) (remove func()) {
+	if this == nil || this == empty {
+		return func() {}
+	}
+	if this.changes == nil {
+		this.changes = &changeListeners{}
+	}
+	changes := this.changes
+	changes.mu.Lock()
+	defer changes.mu.Unlock()
+	listener := &fn
+	changes.listeners = append(changes.listeners, listener)
+	return func() {
+		changes.mu.Lock()
+		defer changes.mu.Unlock()
+		for i, other := range changes.listeners {
+			if other == listener {
+				changes.listeners = append(changes.listeners[:i], changes.listeners[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// changed must be called after every modification of the structure through the MapPath
+func (this *MapPath) changed() {
+	if this.index != nil {
+		this.index.reset()
+	}
+	this.resetChilds()
+	if this.changes == nil {
+		return
+	}
+	this.changes.mu.Lock()
+	listeners := append([]*func(){}, this.changes.listeners...)
+	this.changes.mu.Unlock()
+	for _, listener := range listeners {
+		(*listener)()
+	}
+}
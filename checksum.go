@@ -0,0 +1,70 @@
+package mappath
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ChecksumError is returned by loaders if the SHA-256 digest of a document does not match the expected
+// one, see WithSHA256
+type ChecksumError struct {
+	Expected string
+	Actual   string
+}
+
+func (this *ChecksumError) Error() string {
+	return fmt.Sprintf("The document has the SHA-256 digest %s instead of %s", this.Actual, this.Expected)
+}
+
+// WithSHA256 makes the loaders (eg FromJson, FromJsonFile, FromYaml, FromFile, FromURL) verify that the
+// SHA-256 digest of the document, before parsing, equals the hex encoded digest. Documents with another
+// digest are refused with a ChecksumError. Mind that the digest applies to every document loaded with the
+// options, eg both files of FromFileWithOverride or each file of FromDirTree.
+func WithSHA256(digest string) Option {
+	return func(o *options) {
+		o.sha256 = strings.ToLower(strings.TrimSpace(digest))
+	}
+}
+
+// FromJsonFileVerified is a factory method to create a MapPath from a JSON file, which must have the hex
+// encoded SHA-256 digest, see WithSHA256
+func FromJsonFileVerified(file, digest string, opts ...Option) (*MapPath, error) {
+	return FromJsonFile(file, append(opts, WithSHA256(digest))...)
+}
+
+// readDocument reads the whole document, see readLimited, and verifies it, see verifyDocument
+func readDocument(r io.Reader, o *options) ([]byte, error) {
+	in, err := readLimited(r, o)
+	if err != nil {
+		return nil, err
+	} else if err = verifyDocument(in, o); err != nil {
+		return nil, err
+	}
+	return in, nil
+}
+
+// verifyDocument checks the digest and signature of the raw document, if set
+func verifyDocument(in []byte, o *options) error {
+	if o.sha256 != "" {
+		sum := sha256.Sum256(in)
+		if actual := hex.EncodeToString(sum[:]); actual != o.sha256 {
+			return &ChecksumError{o.sha256, actual}
+		}
+	}
+	if o.verifier != nil {
+		return o.verifier(in)
+	}
+	return nil
+}
+
+// verified returns the options for parsing a document which readDocument verified already, so that it
+// is not verified twice
+func verified(opts []Option) []Option {
+	return append(opts[:len(opts):len(opts)], func(o *options) {
+		o.sha256 = ""
+		o.verifier = nil
+	})
+}
@@ -0,0 +1,87 @@
+package mappath
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+/*
+ * -------
+ * Checksum
+ * -------
+ */
+
+// sha256 of {"foo":"bar"}
+const checksumTestDigest = "7a38bf81f383f69433ad6e900d35b3e2385593f76a7b7ab5d4355b8ba41ee24b"
+
+func TestFromJsonFileVerified(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "mappath")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "config.json")
+	ioutil.WriteFile(file, []byte(`{"foo":"bar"}`), 0644)
+
+	m, err := FromJsonFileVerified(file, checksumTestDigest)
+	assert.Nil(t, err, "No error on matching digest")
+	assert.Equal(t, "bar", m.StringV("foo"), "File loaded")
+	_, err = FromJsonFileVerified(file, " "+strings.ToUpper(checksumTestDigest))
+	assert.Nil(t, err, "Digest case insensitive")
+
+	_, err = FromJsonFileVerified(file, "0000")
+	assert.Equal(t, &ChecksumError{Expected: "0000", Actual: checksumTestDigest}, err, "Mismatch refused")
+	assert.EqualError(t, err, "The document has the SHA-256 digest "+checksumTestDigest+" instead of 0000", "Error message")
+
+	_, err = FromFile(file, WithSHA256("0000"))
+	var checksum *ChecksumError
+	assert.True(t, errors.As(err, &checksum), "Digest verified by FromFile")
+}
+
+func TestWithSHA256URL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"foo":"bar"}`))
+	}))
+	defer srv.Close()
+	_, err := FromURL(srv.URL, WithSHA256(checksumTestDigest))
+	assert.Nil(t, err, "No error on matching digest")
+	_, err = FromURL(srv.URL, WithSHA256("0000"))
+	assert.IsType(t, &ChecksumError{}, err, "Digest verified by FromURL")
+}
+
+func TestWithSHA256Loaders(t *testing.T) {
+	loaders := map[string]func([]byte, ...Option) (*MapPath, error){
+		"json":  FromJson,
+		"jsonc": FromJsonc,
+		"yaml":  FromYaml,
+		"xml":   FromXml,
+	}
+	docs := map[string]string{
+		"json":  `{"foo":"bar"}`,
+		"jsonc": `{"foo":"bar"}`,
+		"yaml":  `{"foo":"bar"}`,
+		"xml":   `<foo>bar</foo>`,
+	}
+	for name, load := range loaders {
+		_, err := load([]byte(docs[name]), WithSHA256("0000"))
+		assert.IsType(t, &ChecksumError{}, err, "Digest verified by "+name)
+	}
+	m, err := FromJsonc([]byte(`{"foo":"bar"}`), WithSHA256(checksumTestDigest))
+	assert.Nil(t, err, "No error on matching digest")
+	assert.Equal(t, "bar", m.StringV("foo"), "Document loaded")
+	_, err = FromJsonc([]byte(`{"foo":"bar",}`), WithSHA256(checksumTestDigest))
+	assert.IsType(t, &ChecksumError{}, err, "Digest of the original document verified")
+
+	dir, _ := ioutil.TempDir("", "mappath")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "config.jsonc")
+	ioutil.WriteFile(file, []byte(`{"foo":"bar"}`), 0644)
+	_, err = FromJsoncFile(file, WithSHA256("0000"))
+	assert.IsType(t, &ChecksumError{}, err, "Digest verified by FromJsoncFile")
+	_, err = FromJsoncFile(file, WithMaxBytes(5))
+	assert.IsType(t, &LimitError{}, err, "Size limited by FromJsoncFile")
+}
@@ -0,0 +1,40 @@
+package mappath
+
+import (
+	"reflect"
+	"sync"
+)
+
+// childCache holds the sub structures handed out by Childs, keyed by the address of their map, so
+// that repeatedly iterating the same array does not allocate a new MapPath per element every time
+type childCache struct {
+	mu   sync.Mutex
+	subs map[uintptr]*MapPath
+}
+
+// cachedChild returns the sub structure of root, which is created on first use
+func (this *MapPath) cachedChild(root map[string]interface{}) *MapPath {
+	this.cacheMu.Lock()
+	if this.childs == nil {
+		this.childs = &childCache{subs: map[uintptr]*MapPath{}}
+	}
+	cache := this.childs
+	this.cacheMu.Unlock()
+
+	key := reflect.ValueOf(root).Pointer()
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	sub, ok := cache.subs[key]
+	if !ok {
+		sub = this.child(root)
+		cache.subs[key] = sub
+	}
+	return sub
+}
+
+// resetChilds drops all cached sub structures
+func (this *MapPath) resetChilds() {
+	this.cacheMu.Lock()
+	defer this.cacheMu.Unlock()
+	this.childs = nil
+}
@@ -0,0 +1,44 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Childs cache
+ * -------
+ */
+
+func TestChildsCached(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"servers": []interface{}{
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{"name": "b"},
+		},
+	})
+	first := m.ChildsV("servers")
+	second := m.ChildsV("servers")
+	assert.Len(t, second, 2, "All childs returned")
+	assert.Same(t, first[0], second[0], "Sub structure reused")
+	assert.Same(t, first[1], second[1], "Sub structure reused")
+
+	assert.Nil(t, m.Set("servers/1", map[string]interface{}{"name": "c"}), "Element replaced")
+	third := m.ChildsV("servers")
+	assert.NotSame(t, first[1], third[1], "Cache dropped after Set")
+	assert.Equal(t, "c", third[1].StringV("name"), "New element returned")
+}
+
+func BenchmarkChilds(b *testing.B) {
+	servers := make([]interface{}, 50000)
+	for i := range servers {
+		servers[i] = map[string]interface{}{"id": i}
+	}
+	m := NewMapPath(map[string]interface{}{"servers": servers})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.ChildsV("servers")
+	}
+}
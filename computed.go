@@ -0,0 +1,99 @@
+package mappath
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type computedValue struct {
+	deps        []string
+	fn          func(*MapPath) (interface{}, error)
+	fingerprint string
+	value       interface{}
+	valid       bool
+}
+
+type computedValues struct {
+	mu     sync.Mutex
+	values map[string]*computedValue
+}
+
+// Computed registers a derived value, which can be read from path like any other value (eg with
+// String or Get). The value is calculated by fn on first access and recalculated whenever one of the
+// values of the dependency paths deps changed since. Errors returned by fn are returned by the getters.
+// Computed values take precedence over values of the structure and are only visible to this MapPath
+// (and MapPaths derived with With), not to sub structures. Computed values, which depend on themselves
+// (directly, or through other computed values), return an error naming the cycle.
+func (this *MapPath) Computed(path string, deps []string, fn func(*MapPath) (interface{}, error)) {
+	if this == nil || this == empty {
+		return
+	}
+	if this.computed == nil {
+		this.computed = &computedValues{values: make(map[string]*computedValue)}
+	}
+	this.computed.mu.Lock()
+	defer this.computed.mu.Unlock()
+	this.computed.values[path] = &computedValue{deps: deps, fn: fn}
+}
+
+// get returns the up to date computed value of path, if any is registered
+func (this *computedValues) get(m *MapPath, path string) (interface{}, bool, error) {
+	this.mu.Lock()
+	cv, ok := this.values[path]
+	this.mu.Unlock()
+	if !ok {
+		return nil, false, nil
+	}
+	for i, p := range m.computing {
+		if p == path {
+			cycle := append(m.computing[i:len(m.computing):len(m.computing)], path)
+			return nil, true, fmt.Errorf("Computed value \"%s\" depends on itself: %s", path, strings.Join(cycle, " -> "))
+		}
+	}
+	m = m.computingOf(path)
+
+	deps := make([]interface{}, len(cv.deps))
+	for i, dep := range cv.deps {
+		if val, found, err := m.lookup(dep); err != nil {
+			return nil, true, err
+		} else if found {
+			deps[i] = val
+		} else {
+			deps[i] = NotFoundError(dep)
+		}
+	}
+	fingerprint := fmt.Sprintf("%#v", deps)
+
+	this.mu.Lock()
+	if cv.valid && cv.fingerprint == fingerprint {
+		defer this.mu.Unlock()
+		return cv.value, true, nil
+	}
+	this.mu.Unlock()
+
+	val, err := cv.fn(m)
+	if err != nil {
+		return nil, true, err
+	}
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	cv.fingerprint, cv.value, cv.valid = fingerprint, val, true
+	return val, true, nil
+}
+
+// computingOf returns a copy of the MapPath, which tracks that the computed value of path is in progress
+func (this *MapPath) computingOf(path string) *MapPath {
+	return &MapPath{
+		root:      this.root,
+		config:    this.config,
+		computed:  this.computed,
+		computing: append(this.computing[:len(this.computing):len(this.computing)], path),
+		mounts:    this.mounts,
+		fallback:  this.fallback,
+		index:     this.index,
+		provider:  this.provider,
+		changes:   this.changes,
+		prefix:    this.prefix,
+	}
+}
@@ -0,0 +1,70 @@
+package mappath
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Computed
+ * -------
+ */
+
+func TestComputed(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"db": map[string]interface{}{"host": "localhost", "port": 5432, "user": "app"},
+	})
+	calls := 0
+	m.Computed("db/dsn", []string{"db/host", "db/port", "db/user"}, func(m *MapPath) (interface{}, error) {
+		calls++
+		return fmt.Sprintf("%s@%s:%d", m.StringV("db/user"), m.StringV("db/host"), m.IntV("db/port")), nil
+	})
+
+	assert.Equal(t, "app@localhost:5432", m.StringV("db/dsn"), "Computed value returned")
+	assert.True(t, m.Has("db/dsn"), "Computed path exists")
+	assert.Equal(t, "app@localhost:5432", m.StringV("db/dsn"), "Computed value returned again")
+	assert.Equal(t, 1, calls, "Value cached while dependencies unchanged")
+
+	assert.Nil(t, m.Set("db/port", 6543), "Dependency changed")
+	assert.Equal(t, "app@localhost:6543", m.StringV("db/dsn"), "Value recomputed")
+	assert.Equal(t, 2, calls, "Recomputed once")
+
+	assert.Equal(t, "app@localhost:6543", m.With(WithSortedKeys()).StringV("db/dsn"), "Visible to derived MapPath")
+	assert.False(t, m.ChildV("db").Has("dsn"), "Not visible to sub structure")
+}
+
+func TestComputedCycle(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{})
+	m.Computed("self", []string{"self"}, func(m *MapPath) (interface{}, error) {
+		return 1, nil
+	})
+	_, err := m.Get("self")
+	if assert.NotNil(t, err, "Error for self dependent value") {
+		assert.Equal(t, `Computed value "self" depends on itself: self -> self`, err.Error(), "Cycle named")
+	}
+
+	m.Computed("a", nil, func(m *MapPath) (interface{}, error) {
+		return m.Get("b")
+	})
+	m.Computed("b", []string{"a"}, func(m *MapPath) (interface{}, error) {
+		return 2, nil
+	})
+	_, err = m.Get("a")
+	if assert.NotNil(t, err, "Error for cyclic values") {
+		assert.Equal(t, `Computed value "a" depends on itself: a -> b -> a`, err.Error(), "Cycle named")
+	}
+}
+
+func TestComputedError(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{})
+	fail := errors.New("fail")
+	m.Computed("broken", nil, func(m *MapPath) (interface{}, error) {
+		return nil, fail
+	})
+	_, err := m.Get("broken")
+	assert.Equal(t, fail, err, "Error of computation returned")
+	assert.Equal(t, "fallback", m.StringV("broken", "fallback"), "Fallback returned on error")
+}
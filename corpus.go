@@ -0,0 +1,111 @@
+package mappath
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// ExportCorpus writes the structure and paths derived from it into dir, to seed fuzz tests of code
+// using the structure, its paths and getters:
+//
+//	tree.json   canonical JSON of the structure: compact, with sorted keys
+//	valid/      paths of all values of the structure, plain and with all segments quoted
+//	invalid/    malformed paths (eg trailing escape characters) and paths which do not exist
+//
+// The paths are written as entries of the Go fuzzing corpus format, with a single string value each,
+// so that the directories can be used as testdata/fuzz/<FuzzTarget> of fuzz functions with a string
+// argument. Missing directories are created, existing entries are replaced.
+func (this *MapPath) ExportCorpus(dir string) error {
+	if this == nil {
+		this = empty
+	}
+	tree, err := json.Marshal(canonicalValue(map[string]interface{}(this.root)))
+	if err != nil {
+		return fmt.Errorf("Cannot export corpus: %s", err)
+	}
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return err
+	} else if err = ioutil.WriteFile(filepath.Join(dir, "tree.json"), tree, 0644); err != nil {
+		return err
+	}
+
+	valid, invalid := map[string]bool{}, map[string]bool{}
+	corpusPaths(this.root, nil, func(keys []string, val interface{}) {
+		path := formatKeys(keys)
+		segments := make([]Segment, len(keys))
+		for i, key := range keys {
+			segments[i] = Segment{Key: key, Quoted: true}
+		}
+		valid[path], valid[FormatPath(segments)] = true, true
+
+		// the index after the last child, below scalars the first one
+		children, _ := childrenOf(val)
+		candidates := []string{path + `\`, path + `\x`, `"` + path, path + `/"`, path + "/-1", path + "/" + strconv.Itoa(len(children))}
+		for _, candidate := range candidates {
+			if _, err := this.Get(candidate); err != nil {
+				invalid[candidate] = true
+			}
+		}
+	})
+	if err = writeCorpus(filepath.Join(dir, "valid"), valid); err != nil {
+		return err
+	}
+	return writeCorpus(filepath.Join(dir, "invalid"), invalid)
+}
+
+// corpusPaths calls fn with the keys of every value below current, parents before their children
+func corpusPaths(current interface{}, keys []string, fn func(keys []string, val interface{})) {
+	names, values := childrenOf(current)
+	for i, name := range names {
+		path := append(append([]string{}, keys...), name)
+		fn(path, values[i])
+		corpusPaths(values[i], path, fn)
+	}
+}
+
+// writeCorpus writes each string as entry of the Go fuzzing corpus into dir, named by its digest
+func writeCorpus(dir string, entries map[string]bool) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	sorted := make([]string, 0, len(entries))
+	for entry := range entries {
+		sorted = append(sorted, entry)
+	}
+	sort.Strings(sorted)
+	for _, entry := range sorted {
+		content := []byte("go test fuzz v1\nstring(" + strconv.Quote(entry) + ")\n")
+		name := fmt.Sprintf("%x", sha256.Sum256(content))[:16]
+		if err := ioutil.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// canonicalValue returns val with all maps converted to map[string]interface{}, which can be encoded as JSON
+func canonicalValue(val interface{}) interface{} {
+	if m, ok := toStringMap(val); ok {
+		result := make(map[string]interface{}, len(m))
+		for key, value := range m {
+			result[key] = canonicalValue(value)
+		}
+		return result
+	} else if isSlice(val) {
+		if _, ok := val.([]byte); ok {
+			return val
+		}
+		_, values := childrenOf(val)
+		for i, value := range values {
+			values[i] = canonicalValue(value)
+		}
+		return values
+	}
+	return val
+}
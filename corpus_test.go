@@ -0,0 +1,71 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+/*
+ * -------
+ * Corpus
+ * -------
+ */
+
+// readCorpus returns the string values of all corpus entries in dir
+func readCorpus(t *testing.T, dir string) []string {
+	entries, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err, "Corpus directory "+dir)
+	values := []string{}
+	for _, entry := range entries {
+		content, _ := ioutil.ReadFile(filepath.Join(dir, entry.Name()))
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		if assert.Equal(t, 2, len(lines), "Header and value of "+entry.Name()) {
+			assert.Equal(t, "go test fuzz v1", lines[0], "Header of "+entry.Name())
+			value, err := strconv.Unquote(strings.TrimSuffix(strings.TrimPrefix(lines[1], "string("), ")"))
+			assert.Nil(t, err, "Quoted value of "+entry.Name())
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func TestExportCorpus(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "mappath")
+	defer os.RemoveAll(dir)
+	m := NewMapPath(map[string]interface{}{
+		"name":  "corpus",
+		"a/b":   1,
+		"list":  []interface{}{"x", map[string]interface{}{"y": true}},
+		"yaml":  map[interface{}]interface{}{1: "one"},
+		"empty": nil,
+	})
+	assert.Nil(t, m.ExportCorpus(dir), "Corpus exported")
+
+	tree, _ := ioutil.ReadFile(filepath.Join(dir, "tree.json"))
+	assert.Equal(t, `{"a/b":1,"empty":null,"list":["x",{"y":true}],"name":"corpus","yaml":{"1":"one"}}`, string(tree), "Canonical JSON")
+
+	valid := readCorpus(t, filepath.Join(dir, "valid"))
+	for _, path := range []string{"name", `a\/b`, `"a/b"`, "list/1/y", `"list"/"1"/"y"`, "yaml/1", "empty"} {
+		assert.Contains(t, valid, path, "Valid path "+path)
+	}
+	for _, path := range valid {
+		assert.True(t, m.Has(path), "Valid path exists "+path)
+	}
+
+	invalid := readCorpus(t, filepath.Join(dir, "invalid"))
+	for _, path := range []string{`name\`, `"name`, "list/2", "name/0", "list/-1", "empty/0"} {
+		assert.Contains(t, invalid, path, "Invalid path "+path)
+	}
+	for _, path := range invalid {
+		_, err := m.Get(path)
+		assert.NotNil(t, err, "Invalid path fails "+path)
+	}
+
+	assert.Nil(t, m.ExportCorpus(dir), "Corpus replaced")
+	assert.Equal(t, len(valid), len(readCorpus(t, filepath.Join(dir, "valid"))), "Entries replaced")
+}
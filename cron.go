@@ -0,0 +1,215 @@
+package mappath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed cron expression, see MapPath.Cron. Each field contains the sorted values
+// matched by the expression.
+type CronSchedule struct {
+	// Expression is the original expression
+	Expression string
+	// Seconds (0-59) is [0] for expressions with 5 fields
+	Seconds []int
+	// Minutes (0-59)
+	Minutes []int
+	// Hours (0-23)
+	Hours []int
+	// DaysOfMonth (1-31)
+	DaysOfMonth []int
+	// Months (1-12)
+	Months []int
+	// DaysOfWeek (0-6, Sunday is 0)
+	DaysOfWeek []int
+
+	// any day of month or week ("*"), which decides whether both must match or either
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// CronError is returned by Cron for invalid cron expressions
+type CronError struct {
+	// Path of the expression
+	Path string
+	// Field of the expression, eg "minute", or empty if the expression as a whole is invalid
+	Field string
+	// Value of the field
+	Value string
+	// Reason why it is invalid
+	Reason string
+}
+
+func (err *CronError) Error() string {
+	if err.Field == "" {
+		return fmt.Sprintf("Invalid cron expression \"%s\" of path \"%s\": %s", err.Value, err.Path, err.Reason)
+	}
+	return fmt.Sprintf("Invalid %s \"%s\" of cron expression of path \"%s\": %s", err.Field, err.Value, err.Path, err.Reason)
+}
+
+// cronField describes a field of cron expressions
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var cronFields = []cronField{
+	{name: "second", max: 59},
+	{name: "minute", max: 59},
+	{name: "hour", max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"", "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{name: "day of week", max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Cron returns the parsed cron expression of path. Expressions have either the 5 fields minute, hour,
+// day of month, month and day of week or an additional leading second field. Fields are "*", values,
+// ranges ("1-5"), steps ("*/15", "0-30/10") or lists of them ("1,15,30"). Months and days of week can
+// be named ("jan", "mon"), 7 is Sunday as well. The macros @yearly (@annually), @monthly, @weekly,
+// @daily (@midnight) and @hourly are supported. Invalid expressions result in a *CronError.
+func (this *MapPath) Cron(path string) (*CronSchedule, error) {
+	expr, err := this.String(path)
+	if err != nil {
+		return nil, err
+	}
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+	parts := strings.Fields(spec)
+	if len(parts) == 5 {
+		parts = append([]string{"0"}, parts...)
+	} else if len(parts) != 6 {
+		return nil, &CronError{Path: path, Value: expr, Reason: fmt.Sprintf("expected 5 or 6 fields, got %d", len(parts))}
+	}
+
+	schedule := &CronSchedule{Expression: expr}
+	targets := []*[]int{&schedule.Seconds, &schedule.Minutes, &schedule.Hours, &schedule.DaysOfMonth, &schedule.Months, &schedule.DaysOfWeek}
+	for i, field := range cronFields {
+		values, err := field.parse(parts[i])
+		if err != nil {
+			return nil, &CronError{Path: path, Field: field.name, Value: parts[i], Reason: err.Error()}
+		}
+		*targets[i] = values
+	}
+	schedule.anyDayOfMonth = strings.HasPrefix(parts[3], "*")
+	schedule.anyDayOfWeek = strings.HasPrefix(parts[5], "*")
+	return schedule, nil
+}
+
+// parse returns the sorted values of the field expression
+func (this cronField) parse(expr string) ([]int, error) {
+	matched := make([]bool, this.max+1)
+	for _, item := range strings.Split(expr, ",") {
+		rng, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			s, err := strconv.Atoi(item[i+1:])
+			if err != nil || s < 1 {
+				return nil, fmt.Errorf("invalid step \"%s\"", item[i+1:])
+			}
+			rng, step = item[:i], s
+		}
+		from, to := this.min, this.max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if from, err = this.value(bounds[0]); err != nil {
+				return nil, err
+			}
+			to = from
+			if len(bounds) == 2 {
+				if to, err = this.value(bounds[1]); err != nil {
+					return nil, err
+				} else if to < from {
+					return nil, fmt.Errorf("range \"%s\" is descending", rng)
+				}
+			} else if step > 1 {
+				to = this.max
+			}
+		}
+		for v := from; v <= to; v += step {
+			matched[v] = true
+		}
+	}
+	if this.name == "day of week" && matched[7] {
+		matched[0], matched[7] = true, false
+	}
+	result := []int{}
+	for v, ok := range matched {
+		if ok {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+// value returns the number of the single value (number or name) of the field
+func (this cronField) value(expr string) (int, error) {
+	for i, name := range this.names {
+		if name != "" && strings.EqualFold(expr, name) {
+			return i, nil
+		}
+	}
+	v, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value \"%s\"", expr)
+	} else if v < this.min || v > this.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, this.min, this.max)
+	}
+	return v, nil
+}
+
+// Next returns the first time after t matching the schedule, in the location of t. Returns the zero time
+// if there is none within the next five years (eg "0 0 30 2 *").
+func (this *CronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Second).Add(time.Second)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if !cronContains(this.Months, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		} else if !this.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		} else if !cronContains(this.Hours, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		} else if !cronContains(this.Minutes, t.Minute()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, t.Location())
+		} else if !cronContains(this.Seconds, t.Second()) {
+			t = t.Add(time.Second)
+		} else {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// matchesDay checks the day of month and week, of which either must match if both are restricted
+func (this *CronSchedule) matchesDay(t time.Time) bool {
+	dom := cronContains(this.DaysOfMonth, t.Day())
+	dow := cronContains(this.DaysOfWeek, int(t.Weekday()))
+	if !this.anyDayOfMonth && !this.anyDayOfWeek {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+func cronContains(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
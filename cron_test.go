@@ -0,0 +1,59 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * Cron
+ * -------
+ */
+
+var cronTests = []struct {
+	expr string
+	next string
+	err  string
+}{
+	{"*/15 * * * *", "2021-03-10T12:15:00Z", ""},
+	{"0 9-17/4 * * mon-fri", "2021-03-10T13:00:00Z", ""},
+	{"30 0 6 * * *", "2021-03-11T06:00:30Z", ""},
+	{"0 0 1 jan *", "2022-01-01T00:00:00Z", ""},
+	{"0 0 13 * 5", "2021-03-12T00:00:00Z", ""},
+	{"0 0 * * 7", "2021-03-14T00:00:00Z", ""},
+	{"@hourly", "2021-03-10T13:00:00Z", ""},
+	{"0 0 30 2 *", "0001-01-01T00:00:00Z", ""},
+	{"* * *", "", `Invalid cron expression "* * *" of path "cron": expected 5 or 6 fields, got 3`},
+	{"60 * * * *", "", `Invalid minute "60" of cron expression of path "cron": value 60 out of range [0, 59]`},
+	{"* * 0 * *", "", `Invalid day of month "0" of cron expression of path "cron": value 0 out of range [1, 31]`},
+	{"* * * foo *", "", `Invalid month "foo" of cron expression of path "cron": invalid value "foo"`},
+	{"*/0 * * * *", "", `Invalid minute "*/0" of cron expression of path "cron": invalid step "0"`},
+	{"* 5-1 * * *", "", `Invalid hour "5-1" of cron expression of path "cron": range "5-1" is descending`},
+}
+
+func TestCron(t *testing.T) {
+	now := time.Date(2021, 3, 10, 12, 7, 0, 0, time.UTC) // Wednesday
+	for _, test := range cronTests {
+		m := NewMapPath(map[string]interface{}{"cron": test.expr})
+		schedule, err := m.Cron("cron")
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, "Error of "+test.expr)
+			assert.IsType(t, &CronError{}, err, "Type of error of "+test.expr)
+			continue
+		}
+		assert.Nil(t, err, "No error of "+test.expr)
+		assert.Equal(t, test.next, schedule.Next(now).Format(time.RFC3339), "Next of "+test.expr)
+	}
+
+	schedule, _ := NewMapPath(map[string]interface{}{"cron": "0,30 8 * * sun,7"}).Cron("cron")
+	assert.Equal(t, []int{0}, schedule.Seconds, "Seconds of 5 fields")
+	assert.Equal(t, []int{0, 30}, schedule.Minutes, "List of minutes")
+	assert.Equal(t, []int{8}, schedule.Hours, "Single hour")
+	assert.Len(t, schedule.DaysOfMonth, 31, "Any day of month")
+	assert.Equal(t, []int{0}, schedule.DaysOfWeek, "Sunday as 0 and 7")
+
+	_, err := NewMapPath(map[string]interface{}{}).Cron("missing")
+	assert.IsType(t, NotFoundError(""), err, "Missing path")
+}
@@ -0,0 +1,159 @@
+package mappath
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number with the value Unscaled * 10^-Scale. It is meant for money
+// and other values which must not suffer from binary floating point rounding. For interop with
+// other decimal packages use String or DecimalInto.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+// MaxDecimalExponent is the largest absolute exponent ParseDecimal accepts, eg "1e1000". Exact values of
+// larger exponents would need huge amounts of memory and time.
+const MaxDecimalExponent = 1000
+
+// ParseDecimal parses a decimal string like "-12.340" or "1.5e3" exactly. Exponents beyond
+// MaxDecimalExponent are refused.
+func ParseDecimal(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+	exp := 0
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.Atoi(str[i+1:])
+		if err != nil {
+			return Decimal{}, fmt.Errorf("Cannot parse \"%s\" as decimal", s)
+		} else if e > MaxDecimalExponent || e < -MaxDecimalExponent {
+			return Decimal{}, fmt.Errorf("Cannot parse \"%s\" as decimal: exponent exceeds %d", s, MaxDecimalExponent)
+		}
+		exp, str = e, str[:i]
+	}
+	sign := ""
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		sign, str = str[:1], str[1:]
+	}
+	intPart, fracPart := str, ""
+	if i := strings.Index(str, "."); i >= 0 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+	digits := intPart + fracPart
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("Cannot parse \"%s\" as decimal", s)
+	}
+	unscaled, _ := new(big.Int).SetString(sign+digits, 10)
+	d := Decimal{unscaled: unscaled, scale: len(fracPart) - exp}
+	if d.scale < 0 {
+		d.unscaled.Mul(d.unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-d.scale)), nil))
+		d.scale = 0
+	}
+	return d, nil
+}
+
+// Unscaled returns the unscaled integer value
+func (this Decimal) Unscaled() *big.Int {
+	if this.unscaled == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(this.unscaled)
+}
+
+// Scale returns the amount of digits after the decimal point
+func (this Decimal) Scale() int {
+	return this.scale
+}
+
+// Rat returns the exact value as rational number
+func (this Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(this.Unscaled(), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(this.scale)), nil))
+}
+
+// Float64 returns the nearest float64 value, which might not be exact
+func (this Decimal) Float64() float64 {
+	f, _ := this.Rat().Float64()
+	return f
+}
+
+// String returns the exact decimal representation, eg "-12.340"
+func (this Decimal) String() string {
+	digits := this.Unscaled().String()
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if this.scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= this.scale {
+		digits = strings.Repeat("0", this.scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-this.scale] + "." + digits[len(digits)-this.scale:]
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (this Decimal) MarshalText() ([]byte, error) {
+	return []byte(this.String()), nil
+}
+
+// Decimal returns the exact decimal value of path. Strings (including json.Number) and integers
+// are converted exactly. Float64 values are converted from their shortest representation, unless
+// the MapPath was created with WithStrictDecimal, in which case an InvalidTypeError is returned.
+func (this *MapPath) Decimal(path string, fallback ...Decimal) (Decimal, error) {
+	val, err := this.Get(path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return Decimal{}, err
+	} else if val == nil {
+		return Decimal{}, NullValueError(path)
+	}
+
+	ref := reflect.ValueOf(val)
+	switch kind := ref.Kind(); {
+	case kind == reflect.String:
+		if d, err := ParseDecimal(ref.String()); err == nil {
+			return d, nil
+		}
+	case kind == reflect.Int, kind == reflect.Int8, kind == reflect.Int16, kind == reflect.Int32, kind == reflect.Int64:
+		return Decimal{unscaled: big.NewInt(ref.Int())}, nil
+	case isOfKind(kind, kindsInt):
+		return Decimal{unscaled: new(big.Int).SetUint64(ref.Uint())}, nil
+	case isOfKind(kind, kindsFloat):
+		if this.opts().strictDecimal {
+			return Decimal{}, &InvalidTypeError{val, "decimal (float64 is not exact in strict mode)"}
+		} else if f := ref.Float(); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return ParseDecimal(strconv.FormatFloat(f, 'f', -1, ref.Type().Bits()))
+		}
+	}
+	return Decimal{}, &InvalidTypeError{val, "decimal"}
+}
+
+// DecimalV returns Decimal value of path. If value cannot be parsed or converted then fallback or zero is returned. Handy in single value context.
+func (this *MapPath) DecimalV(path string, fallback ...Decimal) Decimal {
+	if val, err := this.Decimal(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return Decimal{}
+	} else {
+		return val
+	}
+}
+
+// DecimalInto reads the exact decimal value of path (see Decimal) and passes its string
+// representation to target, which is implemented by most decimal packages (eg shopspring/decimal).
+func (this *MapPath) DecimalInto(path string, target encoding.TextUnmarshaler) error {
+	d, err := this.Decimal(path)
+	if err != nil {
+		return err
+	}
+	return target.UnmarshalText([]byte(d.String()))
+}
@@ -0,0 +1,116 @@
+package mappath
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+/*
+ * -------
+ * Decimal
+ * -------
+ */
+
+var parseDecimalTests = []struct {
+	in     string
+	err    bool
+	expect string
+}{
+	{"19.99", false, "19.99"},
+	{"-0.05", false, "-0.05"},
+	{"+12.340", false, "12.340"},
+	{"100", false, "100"},
+	{".5", false, "0.5"},
+	{"1.5e3", false, "1500"},
+	{"15e-4", false, "0.0015"},
+	{"0", false, "0"},
+	{"", true, ""},
+	{"1.2.3", true, ""},
+	{"abc", true, ""},
+	{"1e", true, ""},
+	{"1e1000", false, "1" + strings.Repeat("0", 1000)},
+	{"1e-1000", false, "0." + strings.Repeat("0", 999) + "1"},
+	{"1e1001", true, ""},
+	{"1e50000000", true, ""},
+	{"1e-50000000", true, ""},
+	{"1e99999999999999999999", true, ""},
+}
+
+func TestParseDecimal(t *testing.T) {
+	for _, test := range parseDecimalTests {
+		d, err := ParseDecimal(test.in)
+		if test.err {
+			assert.NotNil(t, err, "Error returned for "+test.in)
+		} else {
+			assert.Nil(t, err, "No error returned for "+test.in)
+			assert.Equal(t, test.expect, d.String(), "Expected value for "+test.in)
+		}
+	}
+	d, _ := ParseDecimal("-12.340")
+	assert.Equal(t, big.NewInt(-12340), d.Unscaled(), "Unscaled value")
+	assert.Equal(t, 3, d.Scale(), "Scale")
+	assert.Equal(t, big.NewRat(-617, 50), d.Rat(), "Rational value")
+	assert.Equal(t, -12.34, d.Float64(), "Float value")
+	assert.Equal(t, "0", Decimal{}.String(), "Zero value")
+}
+
+var decimalTest = map[string]interface{}{
+	"string": "19.99",
+	"number": json.Number("1234567890.123456789"),
+	"int":    42,
+	"float":  19.99,
+	"text":   "foo",
+}
+
+func TestGetDecimal(t *testing.T) {
+	m := NewMapPath(decimalTest)
+	tests := map[string]string{
+		"string": "19.99",
+		"number": "1234567890.123456789",
+		"int":    "42",
+		"float":  "19.99",
+	}
+	for path, expect := range tests {
+		d, err := m.Decimal(path)
+		assert.Nil(t, err, "No error for "+path)
+		assert.Equal(t, expect, d.String(), "Expected value for "+path)
+	}
+	_, err := m.Decimal("text")
+	_, ok := err.(*InvalidTypeError)
+	assert.True(t, ok, "Invalid type error for unparsable string")
+
+	one, _ := ParseDecimal("1")
+	assert.Equal(t, one, m.DecimalV("missing", one), "Fallback returned")
+}
+
+func TestGetDecimalStrict(t *testing.T) {
+	m := NewMapPath(decimalTest, WithStrictDecimal())
+	_, err := m.Decimal("float")
+	_, ok := err.(*InvalidTypeError)
+	assert.True(t, ok, "Float refused in strict mode")
+	assert.Equal(t, "19.99", m.DecimalV("string").String(), "String accepted in strict mode")
+
+	child := NewMapPath(map[string]interface{}{"sub": decimalTest}).With(WithStrictDecimal()).ChildV("sub")
+	_, err = child.Decimal("float")
+	assert.NotNil(t, err, "Strict mode inherited by child")
+}
+
+type decimalTestTarget struct {
+	text string
+}
+
+func (this *decimalTestTarget) UnmarshalText(b []byte) error {
+	this.text = string(b)
+	return nil
+}
+
+func TestGetDecimalInto(t *testing.T) {
+	m := NewMapPath(decimalTest)
+	target := &decimalTestTarget{}
+	assert.Nil(t, m.DecimalInto("number", target), "No error returned")
+	assert.Equal(t, "1234567890.123456789", target.text, "Exact string passed")
+	assert.NotNil(t, m.DecimalInto("missing", target), "Error on missing path")
+}
@@ -0,0 +1,79 @@
+package mappath
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FromDirTree is a factory method to create a MapPath from a directory tree, like a Kubernetes ConfigMap
+// volume mount: sub directories become maps, files with a supported extension (see FromFile) are parsed
+// and stored under their name without extension, eg "db.json" under "db", and all other files are stored
+// as strings under their full name, with a single trailing newline removed. Hidden entries (starting with
+// a dot, like the "..data" directory of Kubernetes mounts) are skipped, symlinks are followed. Digest and
+// signature (see WithSHA256 and WithVerifier) are verified for each file, parsed or not.
+func FromDirTree(dir string, opts ...Option) (*MapPath, error) {
+	root, err := dirTree(dir, opts)
+	if err != nil {
+		return nil, err
+	}
+	return NewMapPath(root, opts...), nil
+}
+
+func dirTree(dir string, opts []Option) (map[string]interface{}, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	sources := map[string]string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		file := filepath.Join(dir, name)
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		key := name
+		var val interface{}
+		if info.IsDir() {
+			val, err = dirTree(file, opts)
+		} else if ext := filepath.Ext(name); fileFormats[strings.ToLower(ext)] != nil {
+			key = strings.TrimSuffix(name, ext)
+			var m *MapPath
+			if m, err = FromFile(file, opts...); err == nil {
+				val = map[string]interface{}(m.root)
+			}
+		} else {
+			val, err = dirTreeString(file, opts)
+		}
+		if err != nil {
+			return nil, err
+		} else if other, ok := sources[key]; ok {
+			return nil, fmt.Errorf("Cannot load \"%s\": key \"%s\" already loaded from \"%s\"", file, key, other)
+		}
+		sources[key] = file
+		result[key] = val
+	}
+	return result, nil
+}
+
+// dirTreeString reads and verifies (see WithVerifier) the plain file as string
+func dirTreeString(file string, opts []Option) (string, error) {
+	fh, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer fh.Close()
+	in, err := readDocument(fh, newOptions(opts))
+	if err != nil {
+		return "", fmt.Errorf("Cannot load \"%s\": %w", file, err)
+	}
+	str := strings.TrimSuffix(string(in), "\n")
+	return strings.TrimSuffix(str, "\r"), nil
+}
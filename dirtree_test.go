@@ -0,0 +1,83 @@
+package mappath
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+/*
+ * -------
+ * Directory tree
+ * -------
+ */
+
+func TestFromDirTree(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "mappath")
+	defer os.RemoveAll(dir)
+	write := func(name, content string) {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(file), 0755)
+		ioutil.WriteFile(file, []byte(content), 0644)
+	}
+	write("..data/secret", "hidden")
+	write(".hidden", "hidden")
+	write("db.json", `{"host":"localhost","port":5432}`)
+	write("app/name", "demo\n")
+	write("app/token.txt", "abc\r\n")
+	write("app/feature/flags.jsonc", "{\"beta\": true, // comment\n}")
+	os.Symlink(filepath.Join(dir, "..data", "secret"), filepath.Join(dir, "secret"))
+
+	m, err := FromDirTree(dir)
+	assert.Nil(t, err, "No error on tree")
+	assert.Equal(t, 5432, m.IntV("db/port"), "Parsed file under name without extension")
+	assert.Equal(t, "demo", m.StringV("app/name"), "Plain file as string")
+	assert.Equal(t, "abc", m.StringV("app/token.txt"), "Plain file under full name")
+	assert.True(t, m.BoolV("app/feature/flags/beta"), "Nested directories")
+	assert.Equal(t, "hidden", m.StringV("secret"), "Symlink followed")
+	assert.False(t, m.Has("..data"), "Hidden directory skipped")
+	assert.False(t, m.Has(".hidden"), "Hidden file skipped")
+
+	write("db/extra", "x")
+	_, err = FromDirTree(dir)
+	assert.NotNil(t, err, "Conflicting keys")
+	os.RemoveAll(filepath.Join(dir, "db"))
+
+	write("app/broken.json", "{")
+	_, err = FromDirTree(dir)
+	assert.True(t, strings.Contains(err.Error(), filepath.Join(dir, "app", "broken.json")), "Error names file")
+
+	_, err = FromDirTree(filepath.Join(dir, "missing"))
+	assert.True(t, os.IsNotExist(err), "Missing directory")
+}
+
+func TestFromDirTreeVerified(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "mappath")
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "db.json"), []byte(`{"host":"localhost"}`), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "token"), []byte("signed"), 0644)
+
+	refused := errors.New("Refused")
+	verifier := WithVerifier(func(data []byte) error {
+		if strings.Contains(string(data), "tampered") {
+			return refused
+		}
+		return nil
+	})
+	m, err := FromDirTree(dir, verifier)
+	assert.Nil(t, err, "No error on verified files")
+	assert.Equal(t, "signed", m.StringV("token"), "Plain file loaded")
+
+	ioutil.WriteFile(filepath.Join(dir, "token"), []byte("tampered"), 0644)
+	_, err = FromDirTree(dir, verifier)
+	assert.True(t, errors.Is(err, refused), "Tampered plain file refused")
+
+	ioutil.WriteFile(filepath.Join(dir, "token"), []byte("signed"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "db.json"), []byte(`{"host":"tampered"}`), 0644)
+	_, err = FromDirTree(dir, verifier)
+	assert.True(t, errors.Is(err, refused), "Tampered parsed file refused")
+}
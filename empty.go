@@ -0,0 +1,33 @@
+package mappath
+
+import (
+	"errors"
+)
+
+// empty is the shared empty MapPath, see Empty
+var empty = &MapPath{root: Branch{}, config: newOptions(nil)}
+
+// ErrEmptyReadOnly is returned when trying to modify the empty MapPath, see Empty
+var ErrEmptyReadOnly = errors.New("Cannot modify the empty MapPath")
+
+// Empty returns the shared, read only, empty MapPath. A nil *MapPath behaves the same, so navigating
+// into optional sections never panics. All getters on it return a NotFoundError (or the
+// fallback), Set returns ErrEmptyReadOnly and Mount as well as Computed are ignored. ChildV called on it
+// always returns it again, which allows chained calls like m.ChildV("a").ChildV("b").StringV("c") without
+// checking for nil, when used together with WithEmptyChilds.
+func Empty() *MapPath {
+	return empty
+}
+
+// IsEmpty returns whether this is the empty MapPath, see Empty
+func (this *MapPath) IsEmpty() bool {
+	return this == empty
+}
+
+// WithEmptyChilds makes ChildV return Empty, instead of nil, if the path does not exist or is no map
+// and no fallback was given
+func WithEmptyChilds() Option {
+	return func(o *options) {
+		o.emptyChilds = true
+	}
+}
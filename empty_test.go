@@ -0,0 +1,106 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+/*
+ * -------
+ * Empty
+ * -------
+ */
+
+func TestEmpty(t *testing.T) {
+	e := Empty()
+	assert.Same(t, e, Empty(), "Singleton")
+	assert.True(t, e.IsEmpty(), "Is empty")
+	assert.False(t, NewMapPath(map[string]interface{}{}).IsEmpty(), "Other MapPath is not empty")
+
+	_, err := e.String("foo")
+	assert.IsType(t, NotFoundError(""), err, "Getter returns not found")
+	assert.Equal(t, "bar", e.StringV("foo", "bar"), "Fallback used")
+	assert.Same(t, e, e.ChildV("foo").ChildV("bar"), "ChildV returns empty")
+
+	assert.Equal(t, ErrEmptyReadOnly, e.Set("foo", "bar"), "Set refused")
+	e.Mount("foo", NewMapPath(map[string]interface{}{"bar": "baz"}))
+	e.Computed("foo", nil, func(*MapPath) (interface{}, error) { return "bar", nil })
+	assert.False(t, e.Has("foo"), "Still empty")
+}
+
+func TestWithEmptyChilds(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"a": map[string]interface{}{"b": map[string]interface{}{"c": "value"}},
+		"x": "scalar",
+	})
+	assert.Nil(t, m.ChildV("missing"), "Nil without option")
+
+	m = m.With(WithEmptyChilds())
+	assert.Equal(t, "value", m.ChildV("a").ChildV("b").StringV("c"), "Existing chain")
+	assert.Equal(t, "", m.ChildV("missing").ChildV("b").StringV("c"), "Missing chain does not panic")
+	assert.Same(t, Empty(), m.ChildV("x"), "Empty for non map value")
+	assert.Same(t, Empty(), m.ChildV("a").ChildV("missing"), "Option inherited by sub structures")
+	fallback := NewMapPath(map[string]interface{}{})
+	assert.Same(t, fallback, m.ChildV("missing", fallback), "Fallback preferred")
+}
+
+/*
+ * -------
+ * Nil receiver
+ * -------
+ */
+
+func TestNilReceiver(t *testing.T) {
+	var m *MapPath
+	assert.False(t, m.Has("foo"), "Nothing found")
+	assert.Equal(t, "bar", m.StringV("foo", "bar"), "Fallback used")
+	_, err := m.Int("foo")
+	assert.IsType(t, NotFoundError(""), err, "Getter returns not found")
+	assert.Equal(t, "", m.ChildV("a").ChildV("b").StringV("c"), "Chained navigation")
+	assert.Nil(t, m.Root(), "No root")
+	assert.Equal(t, ErrEmptyReadOnly, m.Set("foo", "bar"), "Set refused")
+	assert.Nil(t, m.With(WithSortedKeys()).Set("foo", "bar"), "With returns a new MapPath")
+	assert.False(t, Empty().Has("foo"), "Empty not modified")
+
+	// every method must be callable on nil, only the Must getters panic by design
+	ref := reflect.ValueOf(m)
+	for i := 0; i < ref.NumMethod(); i++ {
+		method := ref.Type().Method(i)
+		if strings.HasPrefix(method.Name, "Must") {
+			continue
+		}
+		typ := method.Type
+		args := []reflect.Value{ref}
+		for j := 1; j < typ.NumIn(); j++ {
+			if typ.IsVariadic() && j == typ.NumIn()-1 {
+				break
+			}
+			switch in := typ.In(j); {
+			case in.Kind() == reflect.String:
+				args = append(args, reflect.ValueOf("foo/bar").Convert(in))
+			case in == reflect.TypeOf((*reflect.Type)(nil)).Elem():
+				args = append(args, reflect.ValueOf(reflect.TypeOf("")))
+			default:
+				args = append(args, reflect.Zero(in))
+			}
+		}
+		assert.NotPanics(t, func() { method.Func.Call(args) }, "Method "+method.Name+" on nil")
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	m := &MapPath{}
+	_, err := m.Get("foo")
+	assert.IsType(t, NotFoundError(""), err, "Get returns not found")
+	_, err = m.String("foo/bar")
+	assert.IsType(t, NotFoundError(""), err, "Getter returns not found")
+	assert.Equal(t, "bar", m.StringV("foo", "bar"), "Fallback used")
+	assert.False(t, m.Has("foo"), "Nothing found")
+	assert.Empty(t, m.Paths("*"), "No paths")
+	assert.Empty(t, m.UnusedPaths(), "No unused paths")
+	assert.Nil(t, m.ChildV("foo"), "No child")
+	_, err = m.ToJson()
+	assert.Nil(t, err, "No error on encoding")
+}
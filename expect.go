@@ -0,0 +1,191 @@
+package mappath
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Kind is the kind of value Expect checks for. Kinds can be combined, eg KindString|KindNumber accepts
+// either, and wrapped with ArrayOf to describe the elements of arrays.
+type Kind int
+
+// Kinds of values, see Expect
+const (
+	KindNull Kind = 1 << iota
+	KindBool
+	KindNumber
+	KindString
+	KindMap
+	KindArray
+)
+
+// kindElemShift is the bit offset of the element kinds of arrays, see ArrayOf
+const kindElemShift = 8
+
+var kindNames = []struct {
+	kind   Kind
+	name   string
+	plural string
+}{
+	{KindNull, "null", "nulls"},
+	{KindBool, "bool", "bools"},
+	{KindNumber, "number", "numbers"},
+	{KindString, "string", "strings"},
+	{KindMap, "map", "maps"},
+	{KindArray, "array", "arrays"},
+}
+
+// ArrayOf returns the kind of arrays, whose elements are all of the given kind, eg ArrayOf(KindMap)
+func ArrayOf(elem Kind) Kind {
+	return KindArray | elem<<kindElemShift
+}
+
+// Elem returns the kind of the elements of arrays, or 0 if elements are not checked
+func (this Kind) Elem() Kind {
+	return this >> kindElemShift
+}
+
+func (this Kind) String() string {
+	return this.name(false)
+}
+
+func (this Kind) name(plural bool) string {
+	names := []string{}
+	for _, k := range kindNames {
+		if this&k.kind == 0 {
+			continue
+		}
+		name := k.name
+		if plural {
+			name = k.plural
+		}
+		if k.kind == KindArray && this.Elem() != 0 {
+			name += " of " + this.Elem().name(true)
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return "nothing"
+	}
+	return strings.Join(names, " or ")
+}
+
+// kindOf returns the kind of the value
+func kindOf(val interface{}) Kind {
+	if val == nil {
+		return KindNull
+	}
+	switch kind := reflect.TypeOf(val).Kind(); {
+	case kind == reflect.Bool:
+		return KindBool
+	case isOfKind(kind, kindsInt), isOfKind(kind, kindsFloat), kind == reflect.Uint, kind == reflect.Uint8,
+		kind == reflect.Uint16, kind == reflect.Uint32, kind == reflect.Uint64:
+		return KindNumber
+	case isOfKind(kind, kindsString):
+		return KindString
+	case kind == reflect.Map:
+		return KindMap
+	case kind == reflect.Slice, kind == reflect.Array:
+		return KindArray
+	}
+	return 0
+}
+
+// ExpectError is returned by Expect if the value of a path is not of the expected kind
+type ExpectError struct {
+	// Path of the value, for array elements the path of the element
+	Path string
+	// Expected kind of the value
+	Expected Kind
+	// Found kind of the value
+	Found Kind
+	// Value is the unexpected value
+	Value interface{}
+	// Hint is a guess of the cause, if any
+	Hint string
+}
+
+func (err *ExpectError) Error() string {
+	msg := fmt.Sprintf("Expected %s at %s, found %s", err.Expected, err.Path, err.Found)
+	if err.Found != KindNull {
+		msg += " (" + describeValue(err.Value) + ")"
+	}
+	if err.Hint != "" {
+		msg += " — " + err.Hint
+	}
+	return msg
+}
+
+// Expect checks that the value of path is of the given kind, eg ArrayOf(KindMap) for a list of maps.
+// Returns a NotFoundError if the path does not exist, and an ExpectError describing the found value,
+// with a hint on common mistakes in hand written configs, if it is of another kind:
+//
+//	Expected array of maps at servers, found string ("web-1") — did you forget the list dash in YAML?
+func (this *MapPath) Expect(path string, kind Kind) error {
+	val, err := this.Get(path)
+	if err != nil {
+		return err
+	}
+	return expectKind(path, val, kind)
+}
+
+// expectKind checks the value and, for arrays, its elements
+func expectKind(path string, val interface{}, kind Kind) error {
+	found := kindOf(val)
+	if kind&found == 0 {
+		return &ExpectError{path, kind, found, val, kindHint(val, found, kind)}
+	} else if found != KindArray || kind.Elem() == 0 {
+		return nil
+	}
+	_, values := childrenOf(val)
+	for i, value := range values {
+		if err := expectKind(fmt.Sprintf("%s/%d", path, i), value, kind.Elem()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kindHint guesses the cause of an unexpected kind of value
+func kindHint(val interface{}, found, expected Kind) string {
+	str, isString := val.(string)
+	switch {
+	case found == KindNull:
+		return "is the value missing after the key?"
+	case expected&KindArray != 0 && expected.Elem()&found != 0:
+		return "did you forget the list dash in YAML?"
+	case expected&KindNumber != 0 && isString:
+		if _, err := strconv.ParseFloat(strings.TrimSpace(str), 64); err == nil {
+			return "remove the quotes around the number"
+		}
+	case expected&KindBool != 0 && isString:
+		switch strings.ToLower(strings.TrimSpace(str)) {
+		case "true", "false", "yes", "no", "on", "off":
+			return "remove the quotes around the bool"
+		}
+	case expected&KindMap != 0 && isString && strings.Contains(str, ":"):
+		return "did you forget the space after the colon in YAML?"
+	case expected&KindString != 0 && found == KindMap:
+		return "quote the value if it contains \": \""
+	}
+	return ""
+}
+
+// describeValue returns a short representation of the value for error messages
+func describeValue(val interface{}) string {
+	switch kindOf(val) {
+	case KindString:
+		str := reflect.ValueOf(val).String()
+		if runes := []rune(str); len(runes) > 40 {
+			str = string(runes[:37]) + "..."
+		}
+		return strconv.Quote(str)
+	case KindMap:
+		return fmt.Sprintf("%d keys", reflect.ValueOf(val).Len())
+	case KindArray:
+		return fmt.Sprintf("%d elements", reflect.ValueOf(val).Len())
+	}
+	return fmt.Sprintf("%v", val)
+}
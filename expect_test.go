@@ -0,0 +1,77 @@
+package mappath
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Expect
+ * -------
+ */
+
+var kindStringTests = []struct {
+	kind     Kind
+	expected string
+}{
+	{KindString, "string"},
+	{KindString | KindNumber, "number or string"},
+	{ArrayOf(KindMap), "array of maps"},
+	{ArrayOf(ArrayOf(KindNumber)), "array of arrays of numbers"},
+	{KindNull | ArrayOf(KindString), "null or array of strings"},
+	{0, "nothing"},
+}
+
+func TestKindString(t *testing.T) {
+	for _, test := range kindStringTests {
+		assert.Equal(t, test.expected, test.kind.String(), fmt.Sprintf("Name of kind %d", test.kind))
+	}
+}
+
+var expectTests = []struct {
+	path     string
+	kind     Kind
+	expected string
+}{
+	{"servers", ArrayOf(KindMap), ""},
+	{"servers", KindArray, ""},
+	{"name", KindString | KindNull, ""},
+	{"port", KindNumber, ""},
+	{"single", ArrayOf(KindString), `Expected array of strings at single, found string ("web-1") — did you forget the list dash in YAML?`},
+	{"servers", ArrayOf(KindString), `Expected string at servers/0, found map (1 keys) — quote the value if it contains ": "`},
+	{"quoted", KindNumber, `Expected number at quoted, found string ("8080") — remove the quotes around the number`},
+	{"flag", KindBool, `Expected bool at flag, found string ("yes") — remove the quotes around the bool`},
+	{"colon", KindMap, `Expected map at colon, found string ("key:value") — did you forget the space after the colon in YAML?`},
+	{"empty", KindMap, `Expected map at empty, found null — is the value missing after the key?`},
+	{"port", KindString, `Expected string at port, found number (8080)`},
+	{"long", KindNumber, `Expected number at long, found string ("äääääääääääääääääääääääääääääääääääää...")`},
+}
+
+func TestExpect(t *testing.T) {
+	long := ""
+	for i := 0; i < 50; i++ {
+		long += "ä"
+	}
+	m := NewMapPath(map[string]interface{}{
+		"servers": []interface{}{map[string]interface{}{"host": "a"}},
+		"single":  "web-1",
+		"name":    "app",
+		"port":    8080,
+		"quoted":  "8080",
+		"flag":    "yes",
+		"colon":   "key:value",
+		"empty":   nil,
+		"long":    long,
+	})
+	for _, test := range expectTests {
+		err := m.Expect(test.path, test.kind)
+		if test.expected == "" {
+			assert.Nil(t, err, fmt.Sprintf("%s is %s", test.path, test.kind))
+		} else if assert.IsType(t, &ExpectError{}, err, fmt.Sprintf("%s is not %s", test.path, test.kind)) {
+			assert.EqualError(t, err, test.expected, fmt.Sprintf("Message of %s as %s", test.path, test.kind))
+		}
+	}
+	assert.Equal(t, NotFoundError("missing"), m.Expect("missing", KindString), "Missing path")
+}
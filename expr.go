@@ -0,0 +1,386 @@
+package mappath
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// expression evaluates simple expressions with the following syntax:
+//
+//	literals:    123, 1.5, 'text', "text", true, false, null
+//	references:  ${some/path} (value of the path), env("NAME") (environment variable or "")
+//	operators:   || && ! == != < <= > >= + - * / % and parentheses
+//
+// Integer arithmetic stays integer, unless a division does not come out even. The operators && and ||
+// short-circuit: their right operand is parsed but not evaluated if the left one decides the result.
+type expression struct {
+	src     string
+	pos     int
+	resolve func(path string) (interface{}, error)
+	// skip is greater than 0 while parsing operands which are not evaluated
+	skip int
+}
+
+// Eval evaluates the string value of path as expression and returns the result, eg "${workers} * 2".
+// Expressions support literals (123, 1.5, 'text', true, null), references to other paths with ${path},
+// environment variables with env("NAME"), the operators || && ! == != < <= > >= + - * / % and
+// parentheses. Referenced values are used as is and not evaluated themselves. The operators && and ||
+// short-circuit, so that eg "${db} != null && ${db/enabled}" does not fail if db is missing. Values of other types
+// than string are returned unchanged.
+func (this *MapPath) Eval(path string) (interface{}, error) {
+	val, err := this.Get(path)
+	if err != nil {
+		return nil, err
+	} else if expr, ok := val.(string); ok {
+		return evalExpr(expr, func(p string) (interface{}, error) {
+			return this.Get(p)
+		})
+	}
+	return val, nil
+}
+
+// evalExpr evaluates the expression and uses resolve to read referenced paths
+func evalExpr(src string, resolve func(path string) (interface{}, error)) (interface{}, error) {
+	e := &expression{src: src, resolve: resolve}
+	val, err := e.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if e.skipSpace(); e.pos < len(e.src) {
+		return nil, e.errorf("unexpected \"%s\"", e.src[e.pos:])
+	}
+	return val, nil
+}
+
+func (this *expression) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("Cannot evaluate expression \"%s\": %s", this.src, fmt.Sprintf(format, args...))
+}
+
+func (this *expression) skipSpace() {
+	for this.pos < len(this.src) && strings.ContainsRune(" \t\r\n", rune(this.src[this.pos])) {
+		this.pos++
+	}
+}
+
+// accept consumes the first of the operators found at the current position
+func (this *expression) accept(ops ...string) string {
+	this.skipSpace()
+	for _, op := range ops {
+		if strings.HasPrefix(this.src[this.pos:], op) {
+			this.pos += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+func (this *expression) parseOr() (interface{}, error) {
+	left, err := this.parseAnd()
+	for err == nil && this.accept("||") != "" {
+		var right interface{}
+		if left == true {
+			err = this.skipped(this.parseAnd)
+		} else if right, err = this.parseAnd(); err == nil {
+			left, err = this.logical("||", left, right)
+		}
+	}
+	return left, err
+}
+
+func (this *expression) parseAnd() (interface{}, error) {
+	left, err := this.parseCompare()
+	for err == nil && this.accept("&&") != "" {
+		var right interface{}
+		if left == false {
+			err = this.skipped(this.parseCompare)
+		} else if right, err = this.parseCompare(); err == nil {
+			left, err = this.logical("&&", left, right)
+		}
+	}
+	return left, err
+}
+
+// skipped parses an operand without evaluating it, so that only syntax errors are returned
+func (this *expression) skipped(parse func() (interface{}, error)) error {
+	this.skip++
+	defer func() { this.skip-- }()
+	_, err := parse()
+	return err
+}
+
+func (this *expression) parseCompare() (interface{}, error) {
+	left, err := this.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if op := this.accept("==", "!=", "<=", ">=", "<", ">"); op != "" {
+		right, err := this.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		return this.compare(op, left, right)
+	}
+	return left, nil
+}
+
+func (this *expression) parseSum() (interface{}, error) {
+	left, err := this.parseProduct()
+	for err == nil {
+		op := this.accept("+", "-")
+		if op == "" {
+			break
+		}
+		var right interface{}
+		if right, err = this.parseProduct(); err == nil {
+			left, err = this.arithmetic(op, left, right)
+		}
+	}
+	return left, err
+}
+
+func (this *expression) parseProduct() (interface{}, error) {
+	left, err := this.parseUnary()
+	for err == nil {
+		op := this.accept("*", "/", "%")
+		if op == "" {
+			break
+		}
+		var right interface{}
+		if right, err = this.parseUnary(); err == nil {
+			left, err = this.arithmetic(op, left, right)
+		}
+	}
+	return left, err
+}
+
+func (this *expression) parseUnary() (interface{}, error) {
+	switch this.accept("!", "-") {
+	case "!":
+		val, err := this.parseUnary()
+		if err != nil || this.skip > 0 {
+			return nil, err
+		} else if b, ok := val.(bool); ok {
+			return !b, nil
+		}
+		return nil, this.errorf("cannot negate %v", val)
+	case "-":
+		val, err := this.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return this.arithmetic("-", 0, val)
+	}
+	return this.parseOperand()
+}
+
+func (this *expression) parseOperand() (interface{}, error) {
+	this.skipSpace()
+	if this.pos >= len(this.src) {
+		return nil, this.errorf("unexpected end")
+	}
+	rest := this.src[this.pos:]
+	switch c := rest[0]; {
+	case c == '(':
+		this.pos++
+		val, err := this.parseOr()
+		if err != nil {
+			return nil, err
+		} else if this.accept(")") == "" {
+			return nil, this.errorf("missing \")\"")
+		}
+		return val, nil
+	case strings.HasPrefix(rest, "${"):
+		end := strings.Index(rest, "}")
+		if end < 0 {
+			return nil, this.errorf("missing \"}\"")
+		}
+		this.pos += end + 1
+		if this.skip > 0 {
+			return nil, nil
+		}
+		val, err := this.resolve(strings.TrimSpace(rest[2:end]))
+		if err != nil {
+			return nil, err
+		}
+		return exprValue(val), nil
+	case c == '\'' || c == '"':
+		end := strings.IndexByte(rest[1:], c)
+		if end < 0 {
+			return nil, this.errorf("unterminated string")
+		}
+		this.pos += end + 2
+		return rest[1 : end+1], nil
+	case c >= '0' && c <= '9' || c == '.':
+		end := 0
+		for end < len(rest) && strings.ContainsRune("0123456789.eE", rune(rest[end])) {
+			end++
+		}
+		this.pos += end
+		if i, err := strconv.Atoi(rest[:end]); err == nil {
+			return i, nil
+		} else if f, err := strconv.ParseFloat(rest[:end], 64); err == nil {
+			return f, nil
+		}
+		return nil, this.errorf("invalid number \"%s\"", rest[:end])
+	}
+	for _, ident := range []string{"true", "false", "null", "env("} {
+		if strings.HasPrefix(rest, ident) {
+			this.pos += len(ident)
+			switch ident {
+			case "true":
+				return true, nil
+			case "false":
+				return false, nil
+			case "null":
+				return nil, nil
+			}
+			name, err := this.parseOperand()
+			if err != nil {
+				return nil, err
+			} else if this.accept(")") == "" {
+				return nil, this.errorf("missing \")\"")
+			} else if _, ok := name.(string); !ok {
+				return nil, this.errorf("env() requires a string argument")
+			}
+			return os.Getenv(name.(string)), nil
+		}
+	}
+	return nil, this.errorf("unexpected \"%s\"", rest)
+}
+
+func (this *expression) logical(op string, left, right interface{}) (interface{}, error) {
+	if this.skip > 0 {
+		return nil, nil
+	}
+	l, lok := left.(bool)
+	r, rok := right.(bool)
+	if !lok || !rok {
+		return nil, this.errorf("operator %s requires booleans, got %v and %v", op, left, right)
+	} else if op == "&&" {
+		return l && r, nil
+	}
+	return l || r, nil
+}
+
+func (this *expression) compare(op string, left, right interface{}) (interface{}, error) {
+	if this.skip > 0 {
+		return nil, nil
+	}
+	lf, lnum := exprFloat(left)
+	rf, rnum := exprFloat(right)
+	ls, lstr := left.(string)
+	rs, rstr := right.(string)
+	switch op {
+	case "==", "!=":
+		equal := reflect.DeepEqual(left, right)
+		if lnum && rnum {
+			equal = lf == rf
+		}
+		return equal == (op == "=="), nil
+	}
+	var less, equal bool
+	if lnum && rnum {
+		less, equal = lf < rf, lf == rf
+	} else if lstr && rstr {
+		less, equal = ls < rs, ls == rs
+	} else {
+		return nil, this.errorf("cannot compare %v and %v", left, right)
+	}
+	switch op {
+	case "<":
+		return less, nil
+	case "<=":
+		return less || equal, nil
+	case ">":
+		return !less && !equal, nil
+	}
+	return !less, nil
+}
+
+func (this *expression) arithmetic(op string, left, right interface{}) (interface{}, error) {
+	if this.skip > 0 {
+		return nil, nil
+	}
+	if ls, ok := left.(string); ok && op == "+" {
+		if rs, ok := right.(string); ok {
+			return ls + rs, nil
+		}
+	}
+	li, lint := left.(int)
+	ri, rint := right.(int)
+	if lint && rint {
+		switch op {
+		case "+":
+			return li + ri, nil
+		case "-":
+			return li - ri, nil
+		case "*":
+			return li * ri, nil
+		case "/", "%":
+			if ri == 0 {
+				return nil, this.errorf("division by zero")
+			} else if op == "%" {
+				return li % ri, nil
+			} else if li%ri == 0 {
+				return li / ri, nil
+			}
+		}
+	}
+	lf, lnum := exprFloat(left)
+	rf, rnum := exprFloat(right)
+	if !lnum || !rnum {
+		return nil, this.errorf("operator %s requires numbers, got %v and %v", op, left, right)
+	}
+	switch op {
+	case "+":
+		return lf + rf, nil
+	case "-":
+		return lf - rf, nil
+	case "*":
+		return lf * rf, nil
+	case "%":
+		return math.Mod(lf, rf), nil
+	}
+	if rf == 0 {
+		return nil, this.errorf("division by zero")
+	}
+	return lf / rf, nil
+}
+
+// exprValue normalizes numbers of the structure to int or float64
+func exprValue(val interface{}) interface{} {
+	if n, ok := val.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return int(i)
+		}
+		f, _ := n.Float64()
+		return f
+	} else if val == nil {
+		return nil
+	}
+	ref := reflect.ValueOf(val)
+	switch kind := ref.Kind(); {
+	case kind == reflect.Int, kind == reflect.Int8, kind == reflect.Int16, kind == reflect.Int32, kind == reflect.Int64:
+		return int(ref.Int())
+	case isOfKind(kind, kindsInt):
+		return int(ref.Uint())
+	case isOfKind(kind, kindsFloat):
+		return ref.Float()
+	}
+	return val
+}
+
+func exprFloat(val interface{}) (float64, bool) {
+	switch v := val.(type) {
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
@@ -0,0 +1,85 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Eval
+ * -------
+ */
+
+var evalTest = map[string]interface{}{
+	"workers":  4,
+	"threads":  "${workers} * 2",
+	"ratio":    "${workers} / 8",
+	"enabled":  "${workers} > 2",
+	"plain":    12,
+	"invalid":  "${workers} *",
+	"indirect": "${threads} + 1",
+}
+
+func TestEval(t *testing.T) {
+	m := NewMapPath(evalTest)
+	tests := map[string]interface{}{
+		"threads": 8,
+		"ratio":   0.5,
+		"enabled": true,
+		"plain":   12,
+	}
+	for path, expect := range tests {
+		val, err := m.Eval(path)
+		assert.Nil(t, err, "No error returned for "+path)
+		assert.Equal(t, expect, val, "Expected value for "+path)
+	}
+
+	_, err := m.Eval("invalid")
+	assert.NotNil(t, err, "Error on invalid expression")
+	_, err = m.Eval("indirect")
+	assert.NotNil(t, err, "Referenced expressions are not evaluated")
+	_, err = m.Eval("missing")
+	_, ok := err.(NotFoundError)
+	assert.True(t, ok, "Not found error returned")
+}
+
+func TestEvalCompareMaps(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"sub":  map[string]interface{}{"a": 1},
+		"test": "${sub} == 1",
+	})
+	val, err := m.Eval("test")
+	assert.Nil(t, err, "No error comparing maps")
+	assert.Equal(t, false, val, "Map not equal to number")
+}
+
+func TestEvalShortCircuit(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"opt":  nil,
+		"on":   map[string]interface{}{"x": true},
+		"and":  "false && ${missing}",
+		"or":   "true || ${missing}",
+		"nest": "(1 > 2 && ${missing} / 0) || !(true || ${missing} + 1)",
+		"none": "${opt} != null && ${opt/x}",
+		"some": "${on} != null && ${on/x}",
+		"eval": "true && ${missing}",
+		"bad":  "false && ${missing} *",
+	})
+	tests := map[string]interface{}{
+		"and":  false,
+		"or":   true,
+		"nest": false,
+		"none": false,
+		"some": true,
+	}
+	for path, expect := range tests {
+		val, err := m.Eval(path)
+		assert.Nil(t, err, "No error returned for "+path)
+		assert.Equal(t, expect, val, "Expected value for "+path)
+	}
+	_, err := m.Eval("eval")
+	assert.IsType(t, NotFoundError(""), err, "Right operand evaluated if needed")
+	_, err = m.Eval("bad")
+	assert.NotNil(t, err, "Syntax of skipped operand checked")
+}
@@ -0,0 +1,61 @@
+package mappath
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// fileFormats maps file extensions to the factory methods parsing their content, see FromFile
+var fileFormats = map[string]func(in []byte, opts ...Option) (*MapPath, error){
+	".json":  FromJson,
+	".jsonc": FromJsonc,
+	".yaml":  FromYaml,
+	".yml":   FromYaml,
+	".xml":   FromXml,
+}
+
+// FromFile is a factory method to create a MapPath from a file, which is parsed according to its
+// extension: ".json", ".jsonc", ".yaml", ".yml" or ".xml". Errors name the file they occurred in and wrap
+// the original error, eg a *LimitError, which can be checked with errors.As.
+func FromFile(file string, opts ...Option) (*MapPath, error) {
+	parse, ok := fileFormats[strings.ToLower(filepath.Ext(file))]
+	if !ok {
+		return nil, fmt.Errorf("Cannot load \"%s\": unsupported file extension", file)
+	}
+	fh, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+	in, err := readDocument(fh, newOptions(opts))
+	if err == nil {
+		var m *MapPath
+		if m, err = parse(in, verified(opts)...); err == nil {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("Cannot load \"%s\": %w", file, err)
+}
+
+// FromFileWithOverride is a factory method to create a MapPath from a base file, eg "config.json", and an
+// optional override file, eg "config.local.json", which is deep merged over the base: maps are merged, all
+// other values (including arrays) replaced. A missing override file is ignored. Both files are loaded with
+// FromFile.
+func FromFileWithOverride(base, override string, opts ...Option) (*MapPath, error) {
+	m, err := FromFile(base, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(override); os.IsNotExist(err) {
+		return m, nil
+	}
+	o, err := FromFile(override, opts...)
+	if err != nil {
+		return nil, err
+	} else if err := mergeInto(map[string]interface{}(m.root), map[string]interface{}(o.root)); err != nil {
+		return nil, fmt.Errorf("Cannot merge \"%s\" into \"%s\": %s", override, base, err)
+	}
+	return m, nil
+}
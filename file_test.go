@@ -0,0 +1,76 @@
+package mappath
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+/*
+ * -------
+ * Files
+ * -------
+ */
+
+func TestFromFile(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "mappath")
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"foo":"bar"}`), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "config.jsonc"), []byte("{\"foo\":\"baz\", // comment\n}"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"foo":`), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "config.txt"), []byte(`foo`), 0644)
+
+	m, err := FromFile(filepath.Join(dir, "config.json"))
+	assert.Nil(t, err, "No error on JSON")
+	assert.Equal(t, "bar", m.StringV("foo"), "JSON parsed")
+	m, err = FromFile(filepath.Join(dir, "config.jsonc"))
+	assert.Nil(t, err, "No error on JSONC")
+	assert.Equal(t, "baz", m.StringV("foo"), "JSONC parsed")
+
+	_, err = FromFile(filepath.Join(dir, "broken.json"))
+	assert.True(t, strings.HasPrefix(err.Error(), `Cannot load "`+filepath.Join(dir, "broken.json")+`": `), "Error names file")
+	_, err = FromFile(filepath.Join(dir, "config.txt"))
+	assert.EqualError(t, err, `Cannot load "`+filepath.Join(dir, "config.txt")+`": unsupported file extension`, "Unsupported extension")
+	_, err = FromFile(filepath.Join(dir, "missing.json"))
+	assert.True(t, os.IsNotExist(err), "Missing file")
+	_, err = FromFile(filepath.Join(dir, "config.json"), WithMaxBytes(5))
+	var limit *LimitError
+	assert.True(t, errors.As(err, &limit), "Limits applied")
+
+	ioutil.WriteFile(filepath.Join(dir, "dup.json"), []byte(`{"a":1,"a":2}`), 0644)
+	_, err = FromFile(filepath.Join(dir, "dup.json"), WithStrictKeys())
+	var duplicates DuplicateKeysError
+	if assert.True(t, errors.As(err, &duplicates), "Error of parser wrapped") {
+		assert.Equal(t, DuplicateKeysError{"a"}, duplicates, "Duplicate keys reported")
+	}
+}
+
+func TestFromFileWithOverride(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "mappath")
+	defer os.RemoveAll(dir)
+	base := filepath.Join(dir, "config.json")
+	override := filepath.Join(dir, "config.local.json")
+	ioutil.WriteFile(base, []byte(`{"db":{"host":"prod","port":5432},"hosts":["a","b"],"name":"app"}`), 0644)
+
+	m, err := FromFileWithOverride(base, override)
+	assert.Nil(t, err, "No error without override")
+	assert.Equal(t, "prod", m.StringV("db/host"), "Base used")
+
+	ioutil.WriteFile(override, []byte(`{"db":{"host":"localhost"},"hosts":["c"]}`), 0644)
+	m, err = FromFileWithOverride(base, override)
+	assert.Nil(t, err, "No error with override")
+	assert.Equal(t, "localhost", m.StringV("db/host"), "Value overridden")
+	assert.Equal(t, 5432, m.IntV("db/port"), "Nested value of base kept")
+	assert.Equal(t, []string{"c"}, m.StringsV("hosts"), "Array replaced")
+	assert.Equal(t, "app", m.StringV("name"), "Value of base kept")
+
+	ioutil.WriteFile(override, []byte(`{"db":`), 0644)
+	_, err = FromFileWithOverride(base, override)
+	assert.True(t, strings.HasPrefix(err.Error(), `Cannot load "`+override+`": `), "Error names override file")
+	_, err = FromFileWithOverride(filepath.Join(dir, "missing.json"), override)
+	assert.NotNil(t, err, "Missing base")
+}
@@ -0,0 +1,90 @@
+package mappath
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Position is a GeoJSON position. Additional elements (eg the altitude) are ignored.
+type Position struct {
+	Lon float64
+	Lat float64
+}
+
+// BBox is the bounding box of positions
+type BBox struct {
+	MinLon float64
+	MinLat float64
+	MaxLon float64
+	MaxLat float64
+}
+
+// Contains returns whether the position lies within the bounding box, including its edges
+func (this BBox) Contains(pos Position) bool {
+	return pos.Lon >= this.MinLon && pos.Lon <= this.MaxLon && pos.Lat >= this.MinLat && pos.Lat <= this.MaxLat
+}
+
+// Coordinates returns all positions of the GeoJSON coordinates found at path, in order. The coordinates
+// can be a single position ([lon, lat]) or arrays of positions in any depth, as used by LineString,
+// Polygon or MultiPolygon geometries. Values are converted (eg int) or parsed (string) into float64.
+func (this *MapPath) Coordinates(path string) ([]Position, error) {
+	val, err := this.Get(path)
+	if err != nil {
+		return nil, err
+	} else if val == nil {
+		return nil, NullValueError(path)
+	}
+	positions := []Position{}
+	var collect func(val interface{}) error
+	collect = func(val interface{}) error {
+		ref := reflect.ValueOf(val)
+		if val == nil || ref.Kind() != reflect.Slice {
+			return &InvalidTypeError{val, "coordinates"}
+		} else if ref.Len() > 0 && !isSlice(ref.Index(0).Interface()) {
+			if ref.Len() < 2 {
+				return fmt.Errorf("Position of path \"%s\" needs longitude and latitude, got %v", path, val)
+			}
+			pos := make([]float64, 2)
+			for i := range pos {
+				item := ref.Index(i).Interface()
+				f, err := toFloat(item)
+				if err != nil {
+					return &InvalidTypeError{item, "float64"}
+				}
+				pos[i] = f
+			}
+			positions = append(positions, Position{Lon: pos[0], Lat: pos[1]})
+			return nil
+		}
+		for i := 0; i < ref.Len(); i++ {
+			if err := collect(ref.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := collect(val); err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+// BoundingBox returns the bounding box of all positions of the GeoJSON coordinates found at path, see
+// Coordinates. Returns an error if there are no positions.
+func (this *MapPath) BoundingBox(path string) (BBox, error) {
+	positions, err := this.Coordinates(path)
+	if err != nil {
+		return BBox{}, err
+	} else if len(positions) == 0 {
+		return BBox{}, fmt.Errorf("No positions found in path \"%s\"", path)
+	}
+	box := BBox{MinLon: math.Inf(1), MinLat: math.Inf(1), MaxLon: math.Inf(-1), MaxLat: math.Inf(-1)}
+	for _, pos := range positions {
+		box.MinLon = math.Min(box.MinLon, pos.Lon)
+		box.MinLat = math.Min(box.MinLat, pos.Lat)
+		box.MaxLon = math.Max(box.MaxLon, pos.Lon)
+		box.MaxLat = math.Max(box.MaxLat, pos.Lat)
+	}
+	return box, nil
+}
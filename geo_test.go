@@ -0,0 +1,58 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * GeoJSON
+ * -------
+ */
+
+const geoTestDoc = `{
+	"point": {"type": "Point", "coordinates": [13.4, 52.5, 34]},
+	"line": {"type": "LineString", "coordinates": [[13.4, 52.5], [2, "48.8"]]},
+	"polygon": {"type": "MultiPolygon", "coordinates": [[[[0, 0], [10, 0], [10, -5], [0, 0]]], [[[-3, 1], [-1, 2], [-3, 1]]]]},
+	"empty": {"coordinates": []},
+	"short": {"coordinates": [1]},
+	"invalid": {"coordinates": [[1, "x"]]},
+	"scalar": {"coordinates": 1}
+}`
+
+func TestCoordinates(t *testing.T) {
+	m, _ := FromJson([]byte(geoTestDoc))
+	positions, err := m.Coordinates("point/coordinates")
+	assert.Nil(t, err, "No error on point")
+	assert.Equal(t, []Position{{13.4, 52.5}}, positions, "Single position, altitude ignored")
+
+	positions, err = m.Coordinates("line/coordinates")
+	assert.Nil(t, err, "No error on line")
+	assert.Equal(t, []Position{{13.4, 52.5}, {2, 48.8}}, positions, "Positions in order")
+
+	positions, err = m.Coordinates("polygon/coordinates")
+	assert.Nil(t, err, "No error on multi polygon")
+	assert.Len(t, positions, 7, "All positions of nested arrays")
+
+	positions, err = m.Coordinates("empty/coordinates")
+	assert.Nil(t, err, "No error on empty coordinates")
+	assert.Equal(t, []Position{}, positions, "No positions")
+
+	for _, path := range []string{"short/coordinates", "invalid/coordinates", "scalar/coordinates", "missing"} {
+		_, err = m.Coordinates(path)
+		assert.NotNil(t, err, "Error on "+path)
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	m, _ := FromJson([]byte(geoTestDoc))
+	box, err := m.BoundingBox("polygon/coordinates")
+	assert.Nil(t, err, "No error on multi polygon")
+	assert.Equal(t, BBox{MinLon: -3, MinLat: -5, MaxLon: 10, MaxLat: 2}, box, "Bounding box of all positions")
+	assert.True(t, box.Contains(Position{Lon: 0, Lat: 2}), "Position on edge contained")
+	assert.False(t, box.Contains(Position{Lon: 11, Lat: 0}), "Position outside")
+
+	_, err = m.BoundingBox("empty/coordinates")
+	assert.EqualError(t, err, `No positions found in path "empty/coordinates"`, "Empty coordinates")
+}
@@ -0,0 +1,33 @@
+package mappath
+
+//go:generate go run ./internal/gengetters
+
+// The typed getters (eg Int) come in the following forms, generated from the typed getter by
+// internal/gengetters:
+//
+//	Int(path, fallback...)     (int, error)
+//	IntV(path, fallback...)    int, the zero value on error
+//	MustInt(path)              int, panics on error
+//	IntPtr(path)               (*int, error), nil if the path does not exist (value types only)
+//	GetInt, GetIntV            deprecated v1 names of Int and IntV
+
+// GetV returns the value of path. If the path does not exist then fallback or nil is returned. Handy in single value context.
+func (this *MapPath) GetV(path string, fallback ...interface{}) interface{} {
+	if val, err := this.Get(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return nil
+	} else {
+		return val
+	}
+}
+
+// MustGet returns the value of path. Panics if the path does not exist.
+func (this *MapPath) MustGet(path string) interface{} {
+	val, err := this.Get(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
@@ -0,0 +1,942 @@
+// Code generated by internal/gengetters. DO NOT EDIT.
+
+package mappath
+
+import (
+	"math/big"
+	"time"
+)
+
+// GetBool returns bool value of path, see Bool.
+//
+// Deprecated: Use Bool, the Get prefix was removed in v2.
+func (this *MapPath) GetBool(path string, fallback ...bool) (bool, error) {
+	return this.Bool(path, fallback...)
+}
+
+// GetBoolV returns bool value of path, see BoolV.
+//
+// Deprecated: Use BoolV, the Get prefix was removed in v2.
+func (this *MapPath) GetBoolV(path string, fallback ...bool) bool {
+	return this.BoolV(path, fallback...)
+}
+
+// MustBool returns bool value of path, see Bool. Panics if the value cannot be found or converted.
+func (this *MapPath) MustBool(path string) bool {
+	val, err := this.Bool(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// BoolPtr returns a pointer to the bool value of path, see Bool. Returns nil, without error, if the path does not exist.
+func (this *MapPath) BoolPtr(path string) (*bool, error) {
+	val, err := this.Bool(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetInt returns int value of path, see Int.
+//
+// Deprecated: Use Int, the Get prefix was removed in v2.
+func (this *MapPath) GetInt(path string, fallback ...int) (int, error) {
+	return this.Int(path, fallback...)
+}
+
+// GetIntV returns int value of path, see IntV.
+//
+// Deprecated: Use IntV, the Get prefix was removed in v2.
+func (this *MapPath) GetIntV(path string, fallback ...int) int {
+	return this.IntV(path, fallback...)
+}
+
+// MustInt returns int value of path, see Int. Panics if the value cannot be found or converted.
+func (this *MapPath) MustInt(path string) int {
+	val, err := this.Int(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// IntPtr returns a pointer to the int value of path, see Int. Returns nil, without error, if the path does not exist.
+func (this *MapPath) IntPtr(path string) (*int, error) {
+	val, err := this.Int(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetInt8 returns int8 value of path, see Int8.
+//
+// Deprecated: Use Int8, the Get prefix was removed in v2.
+func (this *MapPath) GetInt8(path string, fallback ...int8) (int8, error) {
+	return this.Int8(path, fallback...)
+}
+
+// GetInt8V returns int8 value of path, see Int8V.
+//
+// Deprecated: Use Int8V, the Get prefix was removed in v2.
+func (this *MapPath) GetInt8V(path string, fallback ...int8) int8 {
+	return this.Int8V(path, fallback...)
+}
+
+// MustInt8 returns int8 value of path, see Int8. Panics if the value cannot be found or converted.
+func (this *MapPath) MustInt8(path string) int8 {
+	val, err := this.Int8(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// Int8Ptr returns a pointer to the int8 value of path, see Int8. Returns nil, without error, if the path does not exist.
+func (this *MapPath) Int8Ptr(path string) (*int8, error) {
+	val, err := this.Int8(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetInt16 returns int16 value of path, see Int16.
+//
+// Deprecated: Use Int16, the Get prefix was removed in v2.
+func (this *MapPath) GetInt16(path string, fallback ...int16) (int16, error) {
+	return this.Int16(path, fallback...)
+}
+
+// GetInt16V returns int16 value of path, see Int16V.
+//
+// Deprecated: Use Int16V, the Get prefix was removed in v2.
+func (this *MapPath) GetInt16V(path string, fallback ...int16) int16 {
+	return this.Int16V(path, fallback...)
+}
+
+// MustInt16 returns int16 value of path, see Int16. Panics if the value cannot be found or converted.
+func (this *MapPath) MustInt16(path string) int16 {
+	val, err := this.Int16(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// Int16Ptr returns a pointer to the int16 value of path, see Int16. Returns nil, without error, if the path does not exist.
+func (this *MapPath) Int16Ptr(path string) (*int16, error) {
+	val, err := this.Int16(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetInt32 returns int32 value of path, see Int32.
+//
+// Deprecated: Use Int32, the Get prefix was removed in v2.
+func (this *MapPath) GetInt32(path string, fallback ...int32) (int32, error) {
+	return this.Int32(path, fallback...)
+}
+
+// GetInt32V returns int32 value of path, see Int32V.
+//
+// Deprecated: Use Int32V, the Get prefix was removed in v2.
+func (this *MapPath) GetInt32V(path string, fallback ...int32) int32 {
+	return this.Int32V(path, fallback...)
+}
+
+// MustInt32 returns int32 value of path, see Int32. Panics if the value cannot be found or converted.
+func (this *MapPath) MustInt32(path string) int32 {
+	val, err := this.Int32(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// Int32Ptr returns a pointer to the int32 value of path, see Int32. Returns nil, without error, if the path does not exist.
+func (this *MapPath) Int32Ptr(path string) (*int32, error) {
+	val, err := this.Int32(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetInt64 returns int64 value of path, see Int64.
+//
+// Deprecated: Use Int64, the Get prefix was removed in v2.
+func (this *MapPath) GetInt64(path string, fallback ...int64) (int64, error) {
+	return this.Int64(path, fallback...)
+}
+
+// GetInt64V returns int64 value of path, see Int64V.
+//
+// Deprecated: Use Int64V, the Get prefix was removed in v2.
+func (this *MapPath) GetInt64V(path string, fallback ...int64) int64 {
+	return this.Int64V(path, fallback...)
+}
+
+// MustInt64 returns int64 value of path, see Int64. Panics if the value cannot be found or converted.
+func (this *MapPath) MustInt64(path string) int64 {
+	val, err := this.Int64(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// Int64Ptr returns a pointer to the int64 value of path, see Int64. Returns nil, without error, if the path does not exist.
+func (this *MapPath) Int64Ptr(path string) (*int64, error) {
+	val, err := this.Int64(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetUint returns uint value of path, see Uint.
+//
+// Deprecated: Use Uint, the Get prefix was removed in v2.
+func (this *MapPath) GetUint(path string, fallback ...uint) (uint, error) {
+	return this.Uint(path, fallback...)
+}
+
+// GetUintV returns uint value of path, see UintV.
+//
+// Deprecated: Use UintV, the Get prefix was removed in v2.
+func (this *MapPath) GetUintV(path string, fallback ...uint) uint {
+	return this.UintV(path, fallback...)
+}
+
+// MustUint returns uint value of path, see Uint. Panics if the value cannot be found or converted.
+func (this *MapPath) MustUint(path string) uint {
+	val, err := this.Uint(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// UintPtr returns a pointer to the uint value of path, see Uint. Returns nil, without error, if the path does not exist.
+func (this *MapPath) UintPtr(path string) (*uint, error) {
+	val, err := this.Uint(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetUint8 returns uint8 value of path, see Uint8.
+//
+// Deprecated: Use Uint8, the Get prefix was removed in v2.
+func (this *MapPath) GetUint8(path string, fallback ...uint8) (uint8, error) {
+	return this.Uint8(path, fallback...)
+}
+
+// GetUint8V returns uint8 value of path, see Uint8V.
+//
+// Deprecated: Use Uint8V, the Get prefix was removed in v2.
+func (this *MapPath) GetUint8V(path string, fallback ...uint8) uint8 {
+	return this.Uint8V(path, fallback...)
+}
+
+// MustUint8 returns uint8 value of path, see Uint8. Panics if the value cannot be found or converted.
+func (this *MapPath) MustUint8(path string) uint8 {
+	val, err := this.Uint8(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// Uint8Ptr returns a pointer to the uint8 value of path, see Uint8. Returns nil, without error, if the path does not exist.
+func (this *MapPath) Uint8Ptr(path string) (*uint8, error) {
+	val, err := this.Uint8(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetUint16 returns uint16 value of path, see Uint16.
+//
+// Deprecated: Use Uint16, the Get prefix was removed in v2.
+func (this *MapPath) GetUint16(path string, fallback ...uint16) (uint16, error) {
+	return this.Uint16(path, fallback...)
+}
+
+// GetUint16V returns uint16 value of path, see Uint16V.
+//
+// Deprecated: Use Uint16V, the Get prefix was removed in v2.
+func (this *MapPath) GetUint16V(path string, fallback ...uint16) uint16 {
+	return this.Uint16V(path, fallback...)
+}
+
+// MustUint16 returns uint16 value of path, see Uint16. Panics if the value cannot be found or converted.
+func (this *MapPath) MustUint16(path string) uint16 {
+	val, err := this.Uint16(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// Uint16Ptr returns a pointer to the uint16 value of path, see Uint16. Returns nil, without error, if the path does not exist.
+func (this *MapPath) Uint16Ptr(path string) (*uint16, error) {
+	val, err := this.Uint16(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetUint32 returns uint32 value of path, see Uint32.
+//
+// Deprecated: Use Uint32, the Get prefix was removed in v2.
+func (this *MapPath) GetUint32(path string, fallback ...uint32) (uint32, error) {
+	return this.Uint32(path, fallback...)
+}
+
+// GetUint32V returns uint32 value of path, see Uint32V.
+//
+// Deprecated: Use Uint32V, the Get prefix was removed in v2.
+func (this *MapPath) GetUint32V(path string, fallback ...uint32) uint32 {
+	return this.Uint32V(path, fallback...)
+}
+
+// MustUint32 returns uint32 value of path, see Uint32. Panics if the value cannot be found or converted.
+func (this *MapPath) MustUint32(path string) uint32 {
+	val, err := this.Uint32(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// Uint32Ptr returns a pointer to the uint32 value of path, see Uint32. Returns nil, without error, if the path does not exist.
+func (this *MapPath) Uint32Ptr(path string) (*uint32, error) {
+	val, err := this.Uint32(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetUint64 returns uint64 value of path, see Uint64.
+//
+// Deprecated: Use Uint64, the Get prefix was removed in v2.
+func (this *MapPath) GetUint64(path string, fallback ...uint64) (uint64, error) {
+	return this.Uint64(path, fallback...)
+}
+
+// GetUint64V returns uint64 value of path, see Uint64V.
+//
+// Deprecated: Use Uint64V, the Get prefix was removed in v2.
+func (this *MapPath) GetUint64V(path string, fallback ...uint64) uint64 {
+	return this.Uint64V(path, fallback...)
+}
+
+// MustUint64 returns uint64 value of path, see Uint64. Panics if the value cannot be found or converted.
+func (this *MapPath) MustUint64(path string) uint64 {
+	val, err := this.Uint64(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// Uint64Ptr returns a pointer to the uint64 value of path, see Uint64. Returns nil, without error, if the path does not exist.
+func (this *MapPath) Uint64Ptr(path string) (*uint64, error) {
+	val, err := this.Uint64(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetFloat returns float64 value of path, see Float.
+//
+// Deprecated: Use Float, the Get prefix was removed in v2.
+func (this *MapPath) GetFloat(path string, fallback ...float64) (float64, error) {
+	return this.Float(path, fallback...)
+}
+
+// GetFloatV returns float64 value of path, see FloatV.
+//
+// Deprecated: Use FloatV, the Get prefix was removed in v2.
+func (this *MapPath) GetFloatV(path string, fallback ...float64) float64 {
+	return this.FloatV(path, fallback...)
+}
+
+// MustFloat returns float64 value of path, see Float. Panics if the value cannot be found or converted.
+func (this *MapPath) MustFloat(path string) float64 {
+	val, err := this.Float(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// FloatPtr returns a pointer to the float64 value of path, see Float. Returns nil, without error, if the path does not exist.
+func (this *MapPath) FloatPtr(path string) (*float64, error) {
+	val, err := this.Float(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetFloat32 returns float32 value of path, see Float32.
+//
+// Deprecated: Use Float32, the Get prefix was removed in v2.
+func (this *MapPath) GetFloat32(path string, fallback ...float32) (float32, error) {
+	return this.Float32(path, fallback...)
+}
+
+// GetFloat32V returns float32 value of path, see Float32V.
+//
+// Deprecated: Use Float32V, the Get prefix was removed in v2.
+func (this *MapPath) GetFloat32V(path string, fallback ...float32) float32 {
+	return this.Float32V(path, fallback...)
+}
+
+// MustFloat32 returns float32 value of path, see Float32. Panics if the value cannot be found or converted.
+func (this *MapPath) MustFloat32(path string) float32 {
+	val, err := this.Float32(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// Float32Ptr returns a pointer to the float32 value of path, see Float32. Returns nil, without error, if the path does not exist.
+func (this *MapPath) Float32Ptr(path string) (*float32, error) {
+	val, err := this.Float32(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetString returns string value of path, see String.
+//
+// Deprecated: Use String, the Get prefix was removed in v2.
+func (this *MapPath) GetString(path string, fallback ...string) (string, error) {
+	return this.String(path, fallback...)
+}
+
+// GetStringV returns string value of path, see StringV.
+//
+// Deprecated: Use StringV, the Get prefix was removed in v2.
+func (this *MapPath) GetStringV(path string, fallback ...string) string {
+	return this.StringV(path, fallback...)
+}
+
+// MustString returns string value of path, see String. Panics if the value cannot be found or converted.
+func (this *MapPath) MustString(path string) string {
+	val, err := this.String(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// StringPtr returns a pointer to the string value of path, see String. Returns nil, without error, if the path does not exist.
+func (this *MapPath) StringPtr(path string) (*string, error) {
+	val, err := this.String(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetMap returns map[string]interface{} value of path, see Map.
+//
+// Deprecated: Use Map, the Get prefix was removed in v2.
+func (this *MapPath) GetMap(path string, fallback ...map[string]interface{}) (map[string]interface{}, error) {
+	return this.Map(path, fallback...)
+}
+
+// GetMapV returns map[string]interface{} value of path, see MapV.
+//
+// Deprecated: Use MapV, the Get prefix was removed in v2.
+func (this *MapPath) GetMapV(path string, fallback ...map[string]interface{}) map[string]interface{} {
+	return this.MapV(path, fallback...)
+}
+
+// MustMap returns map[string]interface{} value of path, see Map. Panics if the value cannot be found or converted.
+func (this *MapPath) MustMap(path string) map[string]interface{} {
+	val, err := this.Map(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetSub returns *MapPath value of path, see Child.
+//
+// Deprecated: Use Child, the Get prefix was removed in v2.
+func (this *MapPath) GetSub(path string, fallback ...*MapPath) (*MapPath, error) {
+	return this.Child(path, fallback...)
+}
+
+// GetSubV returns *MapPath value of path, see ChildV.
+//
+// Deprecated: Use ChildV, the Get prefix was removed in v2.
+func (this *MapPath) GetSubV(path string, fallback ...*MapPath) *MapPath {
+	return this.ChildV(path, fallback...)
+}
+
+// MustChild returns *MapPath value of path, see Child. Panics if the value cannot be found or converted.
+func (this *MapPath) MustChild(path string) *MapPath {
+	val, err := this.Child(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetBools returns []bool value of path, see Bools.
+//
+// Deprecated: Use Bools, the Get prefix was removed in v2.
+func (this *MapPath) GetBools(path string, fallback ...[]bool) ([]bool, error) {
+	return this.Bools(path, fallback...)
+}
+
+// GetBoolsV returns []bool value of path, see BoolsV.
+//
+// Deprecated: Use BoolsV, the Get prefix was removed in v2.
+func (this *MapPath) GetBoolsV(path string, fallback ...[]bool) []bool {
+	return this.BoolsV(path, fallback...)
+}
+
+// MustBools returns []bool value of path, see Bools. Panics if the value cannot be found or converted.
+func (this *MapPath) MustBools(path string) []bool {
+	val, err := this.Bools(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetInts returns []int value of path, see Ints.
+//
+// Deprecated: Use Ints, the Get prefix was removed in v2.
+func (this *MapPath) GetInts(path string, fallback ...[]int) ([]int, error) {
+	return this.Ints(path, fallback...)
+}
+
+// GetIntsV returns []int value of path, see IntsV.
+//
+// Deprecated: Use IntsV, the Get prefix was removed in v2.
+func (this *MapPath) GetIntsV(path string, fallback ...[]int) []int {
+	return this.IntsV(path, fallback...)
+}
+
+// MustInts returns []int value of path, see Ints. Panics if the value cannot be found or converted.
+func (this *MapPath) MustInts(path string) []int {
+	val, err := this.Ints(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetInt64s returns []int64 value of path, see Int64s.
+//
+// Deprecated: Use Int64s, the Get prefix was removed in v2.
+func (this *MapPath) GetInt64s(path string, fallback ...[]int64) ([]int64, error) {
+	return this.Int64s(path, fallback...)
+}
+
+// GetInt64sV returns []int64 value of path, see Int64sV.
+//
+// Deprecated: Use Int64sV, the Get prefix was removed in v2.
+func (this *MapPath) GetInt64sV(path string, fallback ...[]int64) []int64 {
+	return this.Int64sV(path, fallback...)
+}
+
+// MustInt64s returns []int64 value of path, see Int64s. Panics if the value cannot be found or converted.
+func (this *MapPath) MustInt64s(path string) []int64 {
+	val, err := this.Int64s(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetUint64s returns []uint64 value of path, see Uint64s.
+//
+// Deprecated: Use Uint64s, the Get prefix was removed in v2.
+func (this *MapPath) GetUint64s(path string, fallback ...[]uint64) ([]uint64, error) {
+	return this.Uint64s(path, fallback...)
+}
+
+// GetUint64sV returns []uint64 value of path, see Uint64sV.
+//
+// Deprecated: Use Uint64sV, the Get prefix was removed in v2.
+func (this *MapPath) GetUint64sV(path string, fallback ...[]uint64) []uint64 {
+	return this.Uint64sV(path, fallback...)
+}
+
+// MustUint64s returns []uint64 value of path, see Uint64s. Panics if the value cannot be found or converted.
+func (this *MapPath) MustUint64s(path string) []uint64 {
+	val, err := this.Uint64s(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetFloats returns []float64 value of path, see Floats.
+//
+// Deprecated: Use Floats, the Get prefix was removed in v2.
+func (this *MapPath) GetFloats(path string, fallback ...[]float64) ([]float64, error) {
+	return this.Floats(path, fallback...)
+}
+
+// GetFloatsV returns []float64 value of path, see FloatsV.
+//
+// Deprecated: Use FloatsV, the Get prefix was removed in v2.
+func (this *MapPath) GetFloatsV(path string, fallback ...[]float64) []float64 {
+	return this.FloatsV(path, fallback...)
+}
+
+// MustFloats returns []float64 value of path, see Floats. Panics if the value cannot be found or converted.
+func (this *MapPath) MustFloats(path string) []float64 {
+	val, err := this.Floats(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetFloats2D returns [][]float64 value of path, see Floats2D.
+//
+// Deprecated: Use Floats2D, the Get prefix was removed in v2.
+func (this *MapPath) GetFloats2D(path string, fallback ...[][]float64) ([][]float64, error) {
+	return this.Floats2D(path, fallback...)
+}
+
+// GetFloats2DV returns [][]float64 value of path, see Floats2DV.
+//
+// Deprecated: Use Floats2DV, the Get prefix was removed in v2.
+func (this *MapPath) GetFloats2DV(path string, fallback ...[][]float64) [][]float64 {
+	return this.Floats2DV(path, fallback...)
+}
+
+// MustFloats2D returns [][]float64 value of path, see Floats2D. Panics if the value cannot be found or converted.
+func (this *MapPath) MustFloats2D(path string) [][]float64 {
+	val, err := this.Floats2D(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetStrings returns []string value of path, see Strings.
+//
+// Deprecated: Use Strings, the Get prefix was removed in v2.
+func (this *MapPath) GetStrings(path string, fallback ...[]string) ([]string, error) {
+	return this.Strings(path, fallback...)
+}
+
+// GetStringsV returns []string value of path, see StringsV.
+//
+// Deprecated: Use StringsV, the Get prefix was removed in v2.
+func (this *MapPath) GetStringsV(path string, fallback ...[]string) []string {
+	return this.StringsV(path, fallback...)
+}
+
+// MustStrings returns []string value of path, see Strings. Panics if the value cannot be found or converted.
+func (this *MapPath) MustStrings(path string) []string {
+	val, err := this.Strings(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetMaps returns []map[string]interface{} value of path, see Maps.
+//
+// Deprecated: Use Maps, the Get prefix was removed in v2.
+func (this *MapPath) GetMaps(path string, fallback ...[]map[string]interface{}) ([]map[string]interface{}, error) {
+	return this.Maps(path, fallback...)
+}
+
+// GetMapsV returns []map[string]interface{} value of path, see MapsV.
+//
+// Deprecated: Use MapsV, the Get prefix was removed in v2.
+func (this *MapPath) GetMapsV(path string, fallback ...[]map[string]interface{}) []map[string]interface{} {
+	return this.MapsV(path, fallback...)
+}
+
+// MustMaps returns []map[string]interface{} value of path, see Maps. Panics if the value cannot be found or converted.
+func (this *MapPath) MustMaps(path string) []map[string]interface{} {
+	val, err := this.Maps(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetSubs returns []*MapPath value of path, see Childs.
+//
+// Deprecated: Use Childs, the Get prefix was removed in v2.
+func (this *MapPath) GetSubs(path string, fallback ...[]*MapPath) ([]*MapPath, error) {
+	return this.Childs(path, fallback...)
+}
+
+// GetSubsV returns []*MapPath value of path, see ChildsV.
+//
+// Deprecated: Use ChildsV, the Get prefix was removed in v2.
+func (this *MapPath) GetSubsV(path string, fallback ...[]*MapPath) []*MapPath {
+	return this.ChildsV(path, fallback...)
+}
+
+// MustChilds returns []*MapPath value of path, see Childs. Panics if the value cannot be found or converted.
+func (this *MapPath) MustChilds(path string) []*MapPath {
+	val, err := this.Childs(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetTimes returns []time.Time value of path, see Times.
+//
+// Deprecated: Use Times, the Get prefix was removed in v2.
+func (this *MapPath) GetTimes(path string, fallback ...[]time.Time) ([]time.Time, error) {
+	return this.Times(path, fallback...)
+}
+
+// GetTimesV returns []time.Time value of path, see TimesV.
+//
+// Deprecated: Use TimesV, the Get prefix was removed in v2.
+func (this *MapPath) GetTimesV(path string, fallback ...[]time.Time) []time.Time {
+	return this.TimesV(path, fallback...)
+}
+
+// MustTimes returns []time.Time value of path, see Times. Panics if the value cannot be found or converted.
+func (this *MapPath) MustTimes(path string) []time.Time {
+	val, err := this.Times(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetTime returns time.Time value of path, see Time.
+//
+// Deprecated: Use Time, the Get prefix was removed in v2.
+func (this *MapPath) GetTime(path string, fallback ...time.Time) (time.Time, error) {
+	return this.Time(path, fallback...)
+}
+
+// GetTimeV returns time.Time value of path, see TimeV.
+//
+// Deprecated: Use TimeV, the Get prefix was removed in v2.
+func (this *MapPath) GetTimeV(path string, fallback ...time.Time) time.Time {
+	return this.TimeV(path, fallback...)
+}
+
+// MustTime returns time.Time value of path, see Time. Panics if the value cannot be found or converted.
+func (this *MapPath) MustTime(path string) time.Time {
+	val, err := this.Time(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// TimePtr returns a pointer to the time.Time value of path, see Time. Returns nil, without error, if the path does not exist.
+func (this *MapPath) TimePtr(path string) (*time.Time, error) {
+	val, err := this.Time(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetDuration returns time.Duration value of path, see Duration.
+//
+// Deprecated: Use Duration, the Get prefix was removed in v2.
+func (this *MapPath) GetDuration(path string, fallback ...time.Duration) (time.Duration, error) {
+	return this.Duration(path, fallback...)
+}
+
+// GetDurationV returns time.Duration value of path, see DurationV.
+//
+// Deprecated: Use DurationV, the Get prefix was removed in v2.
+func (this *MapPath) GetDurationV(path string, fallback ...time.Duration) time.Duration {
+	return this.DurationV(path, fallback...)
+}
+
+// MustDuration returns time.Duration value of path, see Duration. Panics if the value cannot be found or converted.
+func (this *MapPath) MustDuration(path string) time.Duration {
+	val, err := this.Duration(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// DurationPtr returns a pointer to the time.Duration value of path, see Duration. Returns nil, without error, if the path does not exist.
+func (this *MapPath) DurationPtr(path string) (*time.Duration, error) {
+	val, err := this.Duration(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetBigInt returns *big.Int value of path, see BigInt.
+//
+// Deprecated: Use BigInt, the Get prefix was removed in v2.
+func (this *MapPath) GetBigInt(path string, fallback ...*big.Int) (*big.Int, error) {
+	return this.BigInt(path, fallback...)
+}
+
+// GetBigIntV returns *big.Int value of path, see BigIntV.
+//
+// Deprecated: Use BigIntV, the Get prefix was removed in v2.
+func (this *MapPath) GetBigIntV(path string, fallback ...*big.Int) *big.Int {
+	return this.BigIntV(path, fallback...)
+}
+
+// MustBigInt returns *big.Int value of path, see BigInt. Panics if the value cannot be found or converted.
+func (this *MapPath) MustBigInt(path string) *big.Int {
+	val, err := this.BigInt(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetBigFloat returns *big.Float value of path, see BigFloat.
+//
+// Deprecated: Use BigFloat, the Get prefix was removed in v2.
+func (this *MapPath) GetBigFloat(path string, fallback ...*big.Float) (*big.Float, error) {
+	return this.BigFloat(path, fallback...)
+}
+
+// GetBigFloatV returns *big.Float value of path, see BigFloatV.
+//
+// Deprecated: Use BigFloatV, the Get prefix was removed in v2.
+func (this *MapPath) GetBigFloatV(path string, fallback ...*big.Float) *big.Float {
+	return this.BigFloatV(path, fallback...)
+}
+
+// MustBigFloat returns *big.Float value of path, see BigFloat. Panics if the value cannot be found or converted.
+func (this *MapPath) MustBigFloat(path string) *big.Float {
+	val, err := this.BigFloat(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetDecimal returns Decimal value of path, see Decimal.
+//
+// Deprecated: Use Decimal, the Get prefix was removed in v2.
+func (this *MapPath) GetDecimal(path string, fallback ...Decimal) (Decimal, error) {
+	return this.Decimal(path, fallback...)
+}
+
+// GetDecimalV returns Decimal value of path, see DecimalV.
+//
+// Deprecated: Use DecimalV, the Get prefix was removed in v2.
+func (this *MapPath) GetDecimalV(path string, fallback ...Decimal) Decimal {
+	return this.DecimalV(path, fallback...)
+}
+
+// MustDecimal returns Decimal value of path, see Decimal. Panics if the value cannot be found or converted.
+func (this *MapPath) MustDecimal(path string) Decimal {
+	val, err := this.Decimal(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// DecimalPtr returns a pointer to the Decimal value of path, see Decimal. Returns nil, without error, if the path does not exist.
+func (this *MapPath) DecimalPtr(path string) (*Decimal, error) {
+	val, err := this.Decimal(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetHostPort returns string value of path, see HostPort.
+//
+// Deprecated: Use HostPort, the Get prefix was removed in v2.
+func (this *MapPath) GetHostPort(path string, fallback ...string) (string, error) {
+	return this.HostPort(path, fallback...)
+}
+
+// GetHostPortV returns string value of path, see HostPortV.
+//
+// Deprecated: Use HostPortV, the Get prefix was removed in v2.
+func (this *MapPath) GetHostPortV(path string, fallback ...string) string {
+	return this.HostPortV(path, fallback...)
+}
+
+// MustHostPort returns string value of path, see HostPort. Panics if the value cannot be found or converted.
+func (this *MapPath) MustHostPort(path string) string {
+	val, err := this.HostPort(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// HostPortPtr returns a pointer to the string value of path, see HostPort. Returns nil, without error, if the path does not exist.
+func (this *MapPath) HostPortPtr(path string) (*string, error) {
+	val, err := this.HostPort(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
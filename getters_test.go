@@ -0,0 +1,69 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * Getter families
+ * -------
+ */
+
+func TestGetterFamilies(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"int":      "42",
+		"timeout":  "5s",
+		"invalid":  "x",
+		"servers":  []interface{}{map[string]interface{}{"name": "a"}},
+		"sub":      map[string]interface{}{"foo": "bar"},
+		"strings":  []interface{}{"a", "b"},
+		"interval": 3,
+	})
+
+	assert.Equal(t, 42, m.GetIntV("int"), "Deprecated V getter")
+	i, err := m.GetInt("invalid", 1)
+	assert.NotNil(t, err, "Deprecated getter returns error")
+	assert.Equal(t, 0, i, "Deprecated getter returns zero value")
+	assert.Equal(t, "bar", m.GetSubV("sub").StringV("foo"), "Legacy name of Child")
+	assert.Len(t, m.GetSubsV("servers"), 1, "Legacy name of Childs")
+
+	assert.Equal(t, 5*time.Second, m.MustDuration("timeout"), "Must getter")
+	assert.Equal(t, []string{"a", "b"}, m.MustStrings("strings"), "Must getter of array")
+	assert.Panics(t, func() { m.MustInt("invalid") }, "Must getter panics on invalid value")
+	assert.Panics(t, func() { m.MustChild("missing") }, "Must getter panics on missing value")
+
+	ptr, err := m.IntPtr("int")
+	assert.Nil(t, err, "No error on Ptr getter")
+	assert.Equal(t, 42, *ptr, "Ptr getter")
+	ptr, err = m.IntPtr("missing")
+	assert.Nil(t, err, "No error on missing path")
+	assert.Nil(t, ptr, "Nil on missing path")
+	_, err = m.IntPtr("invalid")
+	assert.NotNil(t, err, "Error on invalid value")
+
+	assert.Equal(t, 3, m.GetV("interval"), "GetV")
+	assert.Equal(t, "x", m.GetV("missing", "x"), "GetV fallback")
+	assert.Nil(t, m.GetV("missing"), "GetV nil")
+	assert.Equal(t, "42", m.MustGet("int"), "MustGet")
+	assert.Panics(t, func() { m.MustGet("missing") }, "MustGet panics on missing value")
+}
+
+func TestGetterFamiliesComplete(t *testing.T) {
+	typ := reflect.TypeOf(&MapPath{})
+	for i := 0; i < typ.NumMethod(); i++ {
+		name := typ.Method(i).Name
+		if !strings.HasPrefix(name, "Must") {
+			continue
+		}
+		getter := strings.TrimPrefix(name, "Must")
+		for _, form := range []string{getter, getter + "V"} {
+			_, ok := typ.MethodByName(form)
+			assert.True(t, ok, "Getter "+form+" of "+name+" exists")
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package mappath
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Paths returns all concrete paths matching the glob expression, without fetching their values.
+// Each segment of the glob is matched against map keys and array indices with the syntax of
+// path.Match (eg "*", "srv-?", "[ab]*"). The segment "**" matches any amount of segments,
+// including none. Like in paths, "/" within keys is escaped as `\/`, while quoted segments (eg
+// `labels/"app.kubernetes.io/*"`) match literally. The result is ordered by sorted map keys and
+// ascending array indices. Keys within the returned paths are escaped, see FormatPath.
+func (this *MapPath) Paths(glob string) []string {
+	if this == nil {
+		this = empty
+	}
+	result := []string{}
+	this.glob(glob, func(path string, val interface{}) {
+		result = append(result, path)
+	})
+	return result
+}
+
+// glob calls fn for each path (and its value) matching the glob expression
+func (this *MapPath) glob(glob string, fn func(path string, val interface{})) {
+	seen := make(map[string]bool)
+	globWalk(splitGlob(glob), this.root, nil, func(parts []string, val interface{}) {
+		p := formatKeys(parts)
+		if len(parts) > 0 && !seen[p] {
+			seen[p] = true
+			if this.auditing() {
+				this.audit(p, true, nil)
+			}
+			fn(p, val)
+		}
+	})
+}
+
+// splitGlob returns the patterns of the segments of the glob expression. Escaped "/" and `"` are
+// unescaped, quoted segments escaped for path.Match and all other escapes kept for path.Match.
+func splitGlob(glob string) []string {
+	patterns := []string{}
+	pattern := new(strings.Builder)
+	inQuotes := false
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case c == '/' && !inQuotes:
+			patterns = append(patterns, pattern.String())
+			pattern.Reset()
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == '\\' && i+1 < len(glob):
+			i++
+			if next := glob[i]; next != '/' && next != '"' {
+				pattern.WriteByte(c)
+			}
+			pattern.WriteByte(glob[i])
+		case inQuotes && strings.IndexByte(`*?[\`, c) >= 0:
+			pattern.WriteByte('\\')
+			pattern.WriteByte(c)
+		default:
+			pattern.WriteByte(c)
+		}
+	}
+	return append(patterns, pattern.String())
+}
+
+func globWalk(patterns []string, current interface{}, parts []string, fn func(parts []string, val interface{})) {
+	if len(patterns) == 0 {
+		fn(parts, current)
+		return
+	}
+	pattern := patterns[0]
+	if pattern == "**" {
+		globWalk(patterns[1:], current, parts, fn)
+		keys, values := childrenOf(current)
+		for i, key := range keys {
+			globWalk(patterns, values[i], append(parts[:len(parts):len(parts)], key), fn)
+		}
+		return
+	} else if !strings.ContainsAny(pattern, "*?[\\") {
+		if val, ok := childOf(current, pattern); ok {
+			globWalk(patterns[1:], val, append(parts[:len(parts):len(parts)], pattern), fn)
+		}
+		return
+	}
+	keys, values := childrenOf(current)
+	for i, key := range keys {
+		if ok, _ := path.Match(pattern, key); ok {
+			globWalk(patterns[1:], values[i], append(parts[:len(parts):len(parts)], key), fn)
+		}
+	}
+}
+
+// childrenOf returns the keys and values of a map, ordered by key, or the indices and values of an array
+func childrenOf(val interface{}) ([]string, []interface{}) {
+	if val == nil {
+		return nil, nil
+	}
+	ref := reflect.ValueOf(val)
+	switch ref.Kind() {
+	case reflect.Map:
+		m, ok := toStringMap(val)
+		if !ok {
+			return nil, nil
+		}
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		values := make([]interface{}, len(keys))
+		for i, key := range keys {
+			values[i] = m[key]
+		}
+		return keys, values
+	case reflect.Slice:
+		keys := make([]string, ref.Len())
+		values := make([]interface{}, ref.Len())
+		for i := range keys {
+			keys[i] = fmt.Sprintf("%d", i)
+			values[i] = ref.Index(i).Interface()
+		}
+		return keys, values
+	}
+	return nil, nil
+}
+
+// matchGlob checks whether the path matches the glob expression (see Paths). If ancestors is set then
+// paths of which a matching path could be a descendant (eg "secrets" for "secrets/*") match as well.
+func matchGlob(patterns, parts []string, ancestors bool) bool {
+	if len(parts) == 0 {
+		if len(patterns) == 0 || ancestors {
+			return true
+		}
+		for _, pattern := range patterns {
+			if pattern != "**" {
+				return false
+			}
+		}
+		return true
+	} else if len(patterns) == 0 {
+		return false
+	} else if patterns[0] == "**" {
+		return matchGlob(patterns[1:], parts, ancestors) || matchGlob(patterns, parts[1:], ancestors)
+	} else if ok, _ := path.Match(patterns[0], parts[0]); ok {
+		return matchGlob(patterns[1:], parts[1:], ancestors)
+	}
+	return false
+}
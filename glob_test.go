@@ -0,0 +1,82 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Paths
+ * -------
+ */
+
+var globTest = map[string]interface{}{
+	"servers": []interface{}{
+		map[string]interface{}{"name": "web-1", "addr": "10.0.0.1"},
+		map[string]interface{}{"name": "web-2", "addr": "10.0.0.2"},
+		map[string]interface{}{"name": "db-1"},
+	},
+	"services": map[string]interface{}{
+		"api":    map[string]interface{}{"addr": "10.0.1.1"},
+		"worker": map[string]interface{}{"addr": "10.0.1.2", "deep": map[string]interface{}{"addr": "x"}},
+	},
+	"name": "root",
+}
+
+var pathsTests = []struct {
+	glob   string
+	expect []string
+}{
+	{"name", []string{"name"}},
+	{"missing", []string{}},
+	{"servers/*/name", []string{"servers/0/name", "servers/1/name", "servers/2/name"}},
+	{"servers/*/addr", []string{"servers/0/addr", "servers/1/addr"}},
+	{"services/*", []string{"services/api", "services/worker"}},
+	{"services/w*/addr", []string{"services/worker/addr"}},
+	{"servers/[01]", []string{"servers/0", "servers/1"}},
+	{"**/addr", []string{"servers/0/addr", "servers/1/addr", "services/api/addr", "services/worker/addr", "services/worker/deep/addr"}},
+	{"services/**", []string{"services", "services/api", "services/api/addr", "services/worker", "services/worker/addr", "services/worker/deep", "services/worker/deep/addr"}},
+	{"servers/[", []string{}},
+	{"**/**/name", []string{"name", "servers/0/name", "servers/1/name", "servers/2/name"}},
+}
+
+func TestPaths(t *testing.T) {
+	m := NewMapPath(globTest)
+	for _, test := range pathsTests {
+		assert.Equal(t, test.expect, m.Paths(test.glob), "Expected paths for "+test.glob)
+	}
+	for _, path := range m.Paths("**") {
+		assert.True(t, m.Has(path), "Path exists: "+path)
+	}
+}
+
+func TestPathsEscaped(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"a/b":    map[string]interface{}{`say "hi"`: 1},
+		"labels": map[string]interface{}{"app.io/name": "web", "app.io/*": "x", "a*": "y"},
+	})
+	assert.Equal(t, []string{`a\/b/say \"hi\"`}, m.Paths(`a\/b/*`), "Keys escaped")
+	assert.Equal(t, []string{`a\/b/say \"hi\"`}, m.Paths(`"a/b"/say*`), "Quoted segment")
+	assert.Equal(t, []string{`labels/app.io\/*`}, m.Paths(`labels/"app.io/*"`), "Quoted segment matches literally")
+	assert.Equal(t, []string{`labels/a*`}, m.Paths(`labels/a\*`), "Escaped pattern character")
+	for _, path := range m.Paths("**") {
+		assert.True(t, m.Has(path), "Path exists: "+path)
+	}
+
+	flat := m.Flatten()
+	assert.Equal(t, 1, flat[`a\/b/say \"hi\"`], "Flatten escapes keys")
+	for path, val := range flat {
+		assert.Equal(t, val, m.GetV(path), "Flattened path readable: "+path)
+	}
+	var walked []string
+	m.With(WithSortedKeys()).Walk(func(path string, val interface{}) error {
+		walked = append(walked, path)
+		return nil
+	})
+	assert.Equal(t, []string{`a\/b`, `a\/b/say \"hi\"`, "labels", "labels/a*", `labels/app.io\/*`, `labels/app.io\/name`}, walked, "Walk escapes keys")
+
+	tracked := NewMapPath(map[string]interface{}{"a/b": 1, "c": 2}, WithAccessTracking())
+	tracked.Int("c")
+	assert.Equal(t, []string{`a\/b`}, tracked.UnusedPaths(), "Unused paths escaped")
+}
@@ -0,0 +1,164 @@
+package mappath
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// maxInt is the maximum value of int
+const maxInt = int(^uint(0) >> 1)
+
+// HTTPClient returns a new http.Client configured by the map at path. All keys are optional:
+//
+//	timeout                    overall timeout of requests (see Duration), 0 for none
+//	proxy                      URL of the proxy, the environment (HTTP_PROXY, ..) is used if missing
+//	max_idle_conns             maximum of idle connections (keep-alive), 0 for no limit
+//	max_idle_conns_per_host    maximum of idle connections per host
+//	max_conns_per_host         maximum of connections per host, 0 for no limit
+//	idle_conn_timeout          duration after which idle connections are closed
+//	tls_handshake_timeout      timeout of TLS handshakes
+//	response_header_timeout    timeout waiting for response headers after writing the request
+//	tls                        map of the TLS configuration, see below
+//
+// The TLS configuration supports the keys server_name, insecure_skip_verify, min_version ("1.2" or
+// "1.3"), ca_file (PEM file of trusted certificate authorities, instead of the system ones) and
+// cert_file plus key_file (PEM files of the client certificate).
+func (this *MapPath) HTTPClient(path string) (*http.Client, error) {
+	child, err := this.Child(path)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	client := &http.Client{Transport: transport}
+	if client.Timeout, err = child.Duration("timeout", 0); err != nil {
+		return nil, httpConfigError("client", path, err)
+	}
+	if proxy, err := child.String("proxy", ""); err != nil {
+		return nil, httpConfigError("client", path, err)
+	} else if proxy != "" {
+		u, err := url.Parse(proxy)
+		if err != nil {
+			return nil, httpConfigError("client", path, err)
+		}
+		transport.Proxy = http.ProxyURL(u)
+	}
+	for key, target := range map[string]*int{
+		"max_idle_conns":          &transport.MaxIdleConns,
+		"max_idle_conns_per_host": &transport.MaxIdleConnsPerHost,
+		"max_conns_per_host":      &transport.MaxConnsPerHost,
+	} {
+		if *target, err = child.IntInRange(key, 0, maxInt, *target); err != nil {
+			return nil, httpConfigError("client", path, err)
+		}
+	}
+	for key, target := range map[string]*time.Duration{
+		"idle_conn_timeout":       &transport.IdleConnTimeout,
+		"tls_handshake_timeout":   &transport.TLSHandshakeTimeout,
+		"response_header_timeout": &transport.ResponseHeaderTimeout,
+	} {
+		if *target, err = child.Duration(key, *target); err != nil {
+			return nil, httpConfigError("client", path, err)
+		}
+	}
+	if child.Has("tls") {
+		if transport.TLSClientConfig, err = child.tlsConfig("tls"); err != nil {
+			return nil, httpConfigError("client", path, err)
+		}
+	}
+	return client, nil
+}
+
+// HTTPServerTimeouts returns a new http.Server with the timeouts and limits configured by the map at
+// path. All keys are optional:
+//
+//	addr                   address to listen on, eg ":8080"
+//	read_timeout           timeout of reading the whole request (see Duration), 0 for none
+//	read_header_timeout    timeout of reading the request headers, read_timeout if missing
+//	write_timeout          timeout of writing the response
+//	idle_timeout           duration after which idle keep-alive connections are closed
+//	max_header_bytes       maximum size of the request headers, 0 for the default of net/http
+//
+// The handler and everything else must be set on the returned server.
+func (this *MapPath) HTTPServerTimeouts(path string) (*http.Server, error) {
+	child, err := this.Child(path)
+	if err != nil {
+		return nil, err
+	}
+	server := &http.Server{}
+	if server.Addr, err = child.String("addr", ""); err != nil {
+		return nil, httpConfigError("server", path, err)
+	}
+	for key, target := range map[string]*time.Duration{
+		"read_timeout":        &server.ReadTimeout,
+		"read_header_timeout": &server.ReadHeaderTimeout,
+		"write_timeout":       &server.WriteTimeout,
+		"idle_timeout":        &server.IdleTimeout,
+	} {
+		if *target, err = child.Duration(key, 0); err != nil {
+			return nil, httpConfigError("server", path, err)
+		}
+	}
+	if server.MaxHeaderBytes, err = child.IntInRange("max_header_bytes", 0, maxInt, 0); err != nil {
+		return nil, httpConfigError("server", path, err)
+	}
+	return server, nil
+}
+
+// tlsVersions are the supported values of min_version, see HTTPClient
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// tlsConfig returns the TLS configuration of the map at path, see HTTPClient
+func (this *MapPath) tlsConfig(path string) (*tls.Config, error) {
+	child, err := this.Child(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{}
+	if config.ServerName, err = child.String("server_name", ""); err != nil {
+		return nil, err
+	} else if config.InsecureSkipVerify, err = child.Bool("insecure_skip_verify", false); err != nil {
+		return nil, err
+	}
+	if version, err := child.String("min_version", ""); err != nil {
+		return nil, err
+	} else if version != "" {
+		if config.MinVersion = tlsVersions[version]; config.MinVersion == 0 {
+			return nil, fmt.Errorf("Unsupported TLS version \"%s\"", version)
+		}
+	}
+	if caFile, err := child.String("ca_file", ""); err != nil {
+		return nil, err
+	} else if caFile != "" {
+		pem, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("No certificates found in \"%s\"", caFile)
+		}
+	}
+	certFile, keyFile := child.StringV("cert_file"), child.StringV("key_file")
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+func httpConfigError(kind, path string, err error) error {
+	return fmt.Errorf("Invalid HTTP %s configuration \"%s\": %s", kind, path, err)
+}
@@ -0,0 +1,102 @@
+package mappath
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * HTTP
+ * -------
+ */
+
+func TestHTTPClient(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	defer srv.Close()
+	dir, _ := ioutil.TempDir("", "mappath")
+	defer os.RemoveAll(dir)
+	caFile := filepath.Join(dir, "ca.pem")
+	ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0644)
+
+	m := NewMapPath(map[string]interface{}{
+		"http": map[string]interface{}{
+			"timeout":            "5s",
+			"proxy":              "http://proxy:3128",
+			"max_idle_conns":     10,
+			"max_conns_per_host": "4",
+			"idle_conn_timeout":  30,
+			"tls":                map[string]interface{}{"ca_file": caFile, "min_version": "1.2"},
+		},
+		"empty":      map[string]interface{}{},
+		"badversion": map[string]interface{}{"tls": map[string]interface{}{"min_version": "2.0"}},
+		"badca":      map[string]interface{}{"tls": map[string]interface{}{"ca_file": filepath.Join(dir, "missing.pem")}},
+		"badtimeout": map[string]interface{}{"timeout": "soon"},
+		"badconns":   map[string]interface{}{"max_idle_conns": -1},
+	})
+
+	client, err := m.HTTPClient("http")
+	assert.Nil(t, err, "No error on valid configuration")
+	transport := client.Transport.(*http.Transport)
+	assert.Equal(t, 5*time.Second, client.Timeout, "Timeout")
+	assert.Equal(t, 10, transport.MaxIdleConns, "Max idle connections")
+	assert.Equal(t, 4, transport.MaxConnsPerHost, "Max connections per host")
+	assert.Equal(t, 30*time.Second, transport.IdleConnTimeout, "Idle connection timeout")
+	assert.Equal(t, uint16(tls.VersionTLS12), transport.TLSClientConfig.MinVersion, "TLS min version")
+	proxy, _ := transport.Proxy(httptest.NewRequest("GET", "http://example.com", nil))
+	assert.Equal(t, "http://proxy:3128", proxy.String(), "Proxy")
+
+	transport.Proxy = nil
+	res, err := client.Get(srv.URL)
+	assert.Nil(t, err, "Request trusted by configured CA")
+	if err == nil {
+		res.Body.Close()
+	}
+
+	client, err = m.HTTPClient("empty")
+	assert.Nil(t, err, "No error on empty configuration")
+	assert.Equal(t, http.DefaultTransport.(*http.Transport).MaxIdleConns, client.Transport.(*http.Transport).MaxIdleConns, "Defaults of net/http")
+	assert.Equal(t, time.Duration(0), client.Timeout, "No timeout by default")
+
+	for _, path := range []string{"badversion", "badca", "badtimeout", "badconns"} {
+		_, err = m.HTTPClient(path)
+		assert.NotNil(t, err, "Error of "+path)
+	}
+	_, err = m.HTTPClient("missing")
+	assert.IsType(t, NotFoundError(""), err, "Missing configuration")
+}
+
+func TestHTTPServerTimeouts(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"server": map[string]interface{}{
+			"addr":             ":8080",
+			"read_timeout":     "10s",
+			"write_timeout":    "1m",
+			"idle_timeout":     120,
+			"max_header_bytes": 4096,
+		},
+		"invalid": map[string]interface{}{"write_timeout": true},
+	})
+	server, err := m.HTTPServerTimeouts("server")
+	assert.Nil(t, err, "No error on valid configuration")
+	assert.Equal(t, ":8080", server.Addr, "Address")
+	assert.Equal(t, 10*time.Second, server.ReadTimeout, "Read timeout")
+	assert.Equal(t, time.Duration(0), server.ReadHeaderTimeout, "Read header timeout not set")
+	assert.Equal(t, time.Minute, server.WriteTimeout, "Write timeout")
+	assert.Equal(t, 2*time.Minute, server.IdleTimeout, "Idle timeout")
+	assert.Equal(t, 4096, server.MaxHeaderBytes, "Max header bytes")
+
+	_, err = m.HTTPServerTimeouts("invalid")
+	assert.NotNil(t, err, "Error on invalid timeout")
+}
@@ -0,0 +1,56 @@
+package mappath
+
+import (
+	"sync"
+)
+
+type trieNode struct {
+	value    interface{}
+	children map[string]*trieNode
+}
+
+// pathIndex is a lazily built trie of all paths of a structure, see WithPathIndex
+type pathIndex struct {
+	mu   sync.RWMutex
+	root *trieNode
+}
+
+// get returns the value of the path given by its keys
+func (this *pathIndex) get(m *MapPath, keys []string) (interface{}, bool) {
+	this.mu.RLock()
+	node := this.root
+	this.mu.RUnlock()
+	if node == nil {
+		this.mu.Lock()
+		if this.root == nil {
+			this.root = buildTrie(map[string]interface{}(m.root))
+		}
+		node = this.root
+		this.mu.Unlock()
+	}
+	for _, key := range keys {
+		if node = node.children[key]; node == nil {
+			return nil, false
+		}
+	}
+	return node.value, true
+}
+
+// reset drops the trie, so that it is rebuilt on the next lookup
+func (this *pathIndex) reset() {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	this.root = nil
+}
+
+func buildTrie(val interface{}) *trieNode {
+	node := &trieNode{value: val}
+	keys, values := childrenOf(val)
+	if len(keys) > 0 {
+		node.children = make(map[string]*trieNode, len(keys))
+		for i, key := range keys {
+			node.children[key] = buildTrie(values[i])
+		}
+	}
+	return node
+}
@@ -0,0 +1,41 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Path index
+ * -------
+ */
+
+func TestWithPathIndex(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"name": "app",
+		"db": map[string]interface{}{
+			"hosts": []interface{}{"a", "b"},
+			"pool":  map[string]interface{}{"size": 10},
+		},
+		"empty": nil,
+	}, WithPathIndex())
+	assert.NotNil(t, m.index, "Index created")
+
+	assert.Equal(t, "app", m.StringV("name"), "Top level value")
+	assert.Equal(t, 10, m.IntV("db/pool/size"), "Nested value")
+	assert.Equal(t, "b", m.StringV("db/hosts/1"), "Array element")
+	assert.Equal(t, "b", m.StringV("db/hosts/01"), "Alternative index spelling")
+	assert.Equal(t, 10, m.ChildV("db").IntV("pool/size"), "Sub structure")
+	assert.True(t, m.Has("empty"), "Null value indexed")
+	assert.False(t, m.Has("db/missing"), "Missing value")
+
+	assert.Nil(t, m.Set("db/pool/size", 20), "Set value")
+	assert.Equal(t, 20, m.IntV("db/pool/size"), "Index rebuilt after Set")
+	assert.Nil(t, m.RenameKey("db", "pool", "conn"), "Rename key")
+	assert.False(t, m.Has("db/pool"), "Renamed key gone")
+	assert.Equal(t, 20, m.IntV("db/conn/size"), "Renamed key indexed")
+
+	assert.Nil(t, NewMapPath(map[string]interface{}{}).index, "No index by default")
+	assert.NotNil(t, NewMapPath(map[string]interface{}{}).With(WithPathIndex()).index, "Index created by With")
+}
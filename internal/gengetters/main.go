@@ -0,0 +1,141 @@
+// Command gengetters generates the getter families (Get*, Must*, *Ptr) of the typed getters of
+// MapPath into getters_gen.go. Run it with go generate from the package directory.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// getter describes a typed getter, eg Int(path string, fallback ...int) (int, error)
+type getter struct {
+	// Name of the getter, eg "Int"
+	Name string
+	// Type of the value, eg "int"
+	Type string
+	// Legacy name, without Get prefix, of the getter in v1, if it differs from Name
+	Legacy string
+	// Ptr enables the *Ptr form, which makes no sense for types which are already pointers
+	Ptr bool
+}
+
+var getters = []getter{
+	{Name: "Bool", Type: "bool", Ptr: true},
+	{Name: "Int", Type: "int", Ptr: true},
+	{Name: "Int8", Type: "int8", Ptr: true},
+	{Name: "Int16", Type: "int16", Ptr: true},
+	{Name: "Int32", Type: "int32", Ptr: true},
+	{Name: "Int64", Type: "int64", Ptr: true},
+	{Name: "Uint", Type: "uint", Ptr: true},
+	{Name: "Uint8", Type: "uint8", Ptr: true},
+	{Name: "Uint16", Type: "uint16", Ptr: true},
+	{Name: "Uint32", Type: "uint32", Ptr: true},
+	{Name: "Uint64", Type: "uint64", Ptr: true},
+	{Name: "Float", Type: "float64", Ptr: true},
+	{Name: "Float32", Type: "float32", Ptr: true},
+	{Name: "String", Type: "string", Ptr: true},
+	{Name: "Map", Type: "map[string]interface{}"},
+	{Name: "Child", Type: "*MapPath", Legacy: "Sub"},
+	{Name: "Bools", Type: "[]bool"},
+	{Name: "Ints", Type: "[]int"},
+	{Name: "Int64s", Type: "[]int64"},
+	{Name: "Uint64s", Type: "[]uint64"},
+	{Name: "Floats", Type: "[]float64"},
+	{Name: "Floats2D", Type: "[][]float64"},
+	{Name: "Strings", Type: "[]string"},
+	{Name: "Maps", Type: "[]map[string]interface{}"},
+	{Name: "Childs", Type: "[]*MapPath", Legacy: "Subs"},
+	{Name: "Times", Type: "[]time.Time"},
+	{Name: "Time", Type: "time.Time", Ptr: true},
+	{Name: "Duration", Type: "time.Duration", Ptr: true},
+	{Name: "BigInt", Type: "*big.Int"},
+	{Name: "BigFloat", Type: "*big.Float"},
+	{Name: "Decimal", Type: "Decimal", Ptr: true},
+	{Name: "HostPort", Type: "string", Ptr: true},
+}
+
+var imports = map[string]string{
+	"time.": "time",
+	"big.":  "math/big",
+}
+
+var tmpl = template.Must(template.New("getters").Parse(`// Code generated by internal/gengetters. DO NOT EDIT.
+
+package mappath
+
+import (
+{{- range .Imports }}
+	"{{ . }}"
+{{- end }}
+)
+{{ range .Getters }}
+// Get{{ or .Legacy .Name }} returns {{ .Type }} value of path, see {{ .Name }}.
+//
+// Deprecated: Use {{ .Name }}, the Get prefix was removed in v2.
+func (this *MapPath) Get{{ or .Legacy .Name }}(path string, fallback ...{{ .Type }}) ({{ .Type }}, error) {
+	return this.{{ .Name }}(path, fallback...)
+}
+
+// Get{{ or .Legacy .Name }}V returns {{ .Type }} value of path, see {{ .Name }}V.
+//
+// Deprecated: Use {{ .Name }}V, the Get prefix was removed in v2.
+func (this *MapPath) Get{{ or .Legacy .Name }}V(path string, fallback ...{{ .Type }}) {{ .Type }} {
+	return this.{{ .Name }}V(path, fallback...)
+}
+
+// Must{{ .Name }} returns {{ .Type }} value of path, see {{ .Name }}. Panics if the value cannot be found or converted.
+func (this *MapPath) Must{{ .Name }}(path string) {{ .Type }} {
+	val, err := this.{{ .Name }}(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+{{ if .Ptr }}
+// {{ .Name }}Ptr returns a pointer to the {{ .Type }} value of path, see {{ .Name }}. Returns nil, without error, if the path does not exist.
+func (this *MapPath) {{ .Name }}Ptr(path string) (*{{ .Type }}, error) {
+	val, err := this.{{ .Name }}(path)
+	if _, ok := err.(NotFoundError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+{{ end -}}
+{{ end -}}
+`))
+
+func main() {
+	used := map[string]bool{}
+	for _, g := range getters {
+		for prefix, pkg := range imports {
+			if strings.Contains(g.Type, prefix) {
+				used[pkg] = true
+			}
+		}
+	}
+	pkgs := []string{}
+	for pkg := range used {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]interface{}{"Imports": pkgs, "Getters": getters}); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(fmt.Errorf("Cannot format generated code: %s", err))
+	}
+	if err := ioutil.WriteFile("getters_gen.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
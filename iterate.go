@@ -0,0 +1,298 @@
+package mappath
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+)
+
+// EachChunk iterates the array of maps at path in batches of at most size elements and calls
+// fn with the index of the first element in the batch and the batch of sub structures. Only the
+// current batch is materialized, the items slice is reused between calls and must not be kept
+// by fn. Iteration stops on the first error returned by fn, which is then returned.
+func (this *MapPath) EachChunk(path string, size int, fn func(start int, items []*MapPath) error) error {
+	if size < 1 {
+		return fmt.Errorf("Chunk size must be at least 1, got %d", size)
+	}
+	refVal, err := this.arrayValue(path)
+	if err != nil {
+		return err
+	}
+
+	total := refVal.Len()
+	items := make([]*MapPath, 0, size)
+	for start := 0; start < total; start += size {
+		items = items[:0]
+		for i := start; i < start+size && i < total; i++ {
+			item, err := this.childAt(path, refVal, i)
+			if err != nil {
+				return err
+			}
+			items = append(items, item)
+		}
+		if err := fn(start, items); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PageInfo describes the position of a page within an array, see Page
+type PageInfo struct {
+	Page    int
+	PerPage int
+	Total   int
+	Pages   int
+}
+
+// HasNext checks whether there is a page after the described one
+func (this PageInfo) HasNext() bool {
+	return this.Page < this.Pages
+}
+
+// Page returns the sub structures of the page (starting with 1) of the array of maps at path, with
+// perPage elements per page, and the total count. Only the elements of the page are materialized.
+// Pages after the last one are empty.
+func (this *MapPath) Page(path string, page, perPage int) ([]*MapPath, PageInfo, error) {
+	if page < 1 || perPage < 1 {
+		return nil, PageInfo{}, fmt.Errorf("Page and page size must be at least 1, got %d and %d", page, perPage)
+	}
+	refVal, err := this.arrayValue(path)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	total := refVal.Len()
+	info := PageInfo{Page: page, PerPage: perPage, Total: total, Pages: (total + perPage - 1) / perPage}
+	items := []*MapPath{}
+	for i := (page - 1) * perPage; i < page*perPage && i < total; i++ {
+		item, err := this.childAt(path, refVal, i)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		items = append(items, item)
+	}
+	return items, info, nil
+}
+
+// arrayValue returns the array at path
+func (this *MapPath) arrayValue(path string) (reflect.Value, error) {
+	val, err := this.Get(path)
+	if err != nil {
+		return reflect.Value{}, err
+	} else if val == nil {
+		return reflect.Value{}, NullValueError(path)
+	} else if reflect.TypeOf(val).Kind() != reflect.Slice {
+		return reflect.Value{}, &InvalidTypeError{val, "array"}
+	}
+	return reflect.ValueOf(val), nil
+}
+
+// childAt returns the map at index i of the array at path as sub structure
+func (this *MapPath) childAt(path string, refVal reflect.Value, i int) (*MapPath, error) {
+	item := refVal.Index(i).Interface()
+	m, ok := toStringMap(item)
+	if !ok {
+		return nil, &InvalidTypeError{item, fmt.Sprintf("[%d]array<map>", i)}
+	}
+	child := this.child(m)
+	child.prefix = this.subPrefix(fmt.Sprintf("%s/%d", path, i))
+	return child, nil
+}
+
+// ChildsByKey returns the array of maps at path as sub structures, indexed by the value of their
+// keyField. If any element lacks the keyField then a NotFoundError is returned, if two elements have
+// the same key then an error is returned.
+func (this *MapPath) ChildsByKey(path, keyField string) (map[string]*MapPath, error) {
+	childs, err := this.Childs(path)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]*MapPath, len(childs))
+	for i, child := range childs {
+		key, err := child.String(keyField)
+		if _, ok := err.(NotFoundError); ok {
+			return nil, NotFoundError(fmt.Sprintf("%s/%d/%s", path, i, keyField))
+		} else if err != nil {
+			return nil, err
+		} else if _, exists := result[key]; exists {
+			return nil, fmt.Errorf("Duplicate key \"%s\" in field \"%s\" of path \"%s\"", key, keyField, path)
+		}
+		result[key] = child
+	}
+	return result, nil
+}
+
+// PickWeighted randomly selects an element of the array of maps at path, with a probability
+// proportional to the numeric value of its weightKey, eg for traffic splitting. Elements with a
+// weight of 0 are never selected. If any element lacks the weightKey then a NotFoundError is returned,
+// negative weights result in a RangeError. The random numbers can be set with WithRand.
+func (this *MapPath) PickWeighted(path, weightKey string) (*MapPath, error) {
+	childs, err := this.Childs(path)
+	if err != nil {
+		return nil, err
+	}
+	weights := make([]float64, len(childs))
+	total := 0.0
+	for i, child := range childs {
+		weight, err := child.Float(weightKey)
+		if _, ok := err.(NotFoundError); ok {
+			return nil, NotFoundError(fmt.Sprintf("%s/%d/%s", path, i, weightKey))
+		} else if err != nil {
+			return nil, err
+		} else if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return nil, &RangeError{fmt.Sprintf("%s/%d/%s", path, i, weightKey), weight, 0, math.MaxFloat64}
+		}
+		weights[i] = weight
+		total += weight
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("Cannot pick from path \"%s\" without positive weights", path)
+	}
+
+	pick := this.random() * total
+	for i, weight := range weights {
+		if pick < weight {
+			return childs[i], nil
+		}
+		pick -= weight
+	}
+	for i := len(weights) - 1; ; i-- {
+		if weights[i] > 0 {
+			return childs[i], nil
+		}
+	}
+}
+
+// random returns a pseudo random number in [0.0,1.0)
+func (this *MapPath) random() float64 {
+	if this.opts().rand != nil {
+		return this.opts().rand.Float64()
+	}
+	return rand.Float64()
+}
+
+// Walk calls fn for every path of the structure (maps, arrays and their leaves), parents before
+// their children. Map keys are visited in random order, unless the MapPath was created with
+// WithSortedKeys. Array elements are always visited in ascending order. Walking stops on the first
+// error returned by fn, which is then returned.
+func (this *MapPath) Walk(fn func(path string, val interface{}) error) error {
+	if this == nil {
+		this = empty
+	}
+	if this.auditing() {
+		audited := fn
+		fn = func(path string, val interface{}) error {
+			this.auditMatching(path, true, nil, false)
+			return audited(path, val)
+		}
+	}
+	return walk(this.root, "", this.opts().sortedKeys, fn)
+}
+
+// Flatten returns all leaf values (anything but non empty maps and arrays) by their full path,
+// eg {"a":{"b":1}} becomes {"a/b":1}
+func (this *MapPath) Flatten() map[string]interface{} {
+	if this == nil {
+		this = empty
+	}
+	result := make(map[string]interface{})
+	this.Walk(func(path string, val interface{}) error {
+		if keys, _ := walkChildren(val, false); len(keys) == 0 {
+			result[path] = val
+		}
+		return nil
+	})
+	return result
+}
+
+// Diff returns the modifications which turn the structure into other: a ChangeSet for every added or
+// modified path and a ChangeDelete for every removed path, without Time and Actor. Maps are compared
+// key by key, all other values (including arrays) as a whole. Map keys are compared in random order,
+// unless the MapPath was created with WithSortedKeys, in which case the changes are sorted by path.
+func (this *MapPath) Diff(other *MapPath) []Change {
+	if this == nil {
+		this = empty
+	}
+	if other == nil {
+		other = empty
+	}
+	changes := []Change{}
+	diff(map[string]interface{}(this.root), map[string]interface{}(other.root), "", this.opts().sortedKeys, &changes)
+	for _, change := range changes {
+		if this.auditing() && change.Existed {
+			this.audit(change.Path, true, nil)
+		}
+		if other.auditing() && change.Op == ChangeSet {
+			other.audit(change.Path, true, nil)
+		}
+	}
+	return changes
+}
+
+// diff adds the changes which turn old at path into new
+func diff(old, new interface{}, path string, sorted bool, changes *[]Change) {
+	oldMap, oldOk := toStringMap(old)
+	newMap, newOk := toStringMap(new)
+	if !oldOk || !newOk {
+		if !reflect.DeepEqual(old, new) {
+			*changes = append(*changes, Change{Op: ChangeSet, Path: path, Old: old, New: new, Existed: true})
+		}
+		return
+	}
+	keys, _ := walkChildren(oldMap, sorted)
+	for key := range newMap {
+		if _, ok := oldMap[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	if sorted {
+		sort.Strings(keys)
+	}
+	for _, key := range keys {
+		p := joinPath(path, key)
+		oldVal, existed := oldMap[key]
+		if newVal, ok := newMap[key]; !ok {
+			*changes = append(*changes, Change{Op: ChangeDelete, Path: p, Old: oldVal, Existed: true})
+		} else if !existed {
+			*changes = append(*changes, Change{Op: ChangeSet, Path: p, New: newVal})
+		} else {
+			diff(oldVal, newVal, p, sorted, changes)
+		}
+	}
+}
+
+func walk(current interface{}, prefix string, sorted bool, fn func(path string, val interface{}) error) error {
+	keys, values := walkChildren(current, sorted)
+	for i, key := range keys {
+		p := joinPath(prefix, key)
+		if err := fn(p, values[i]); err != nil {
+			return err
+		} else if err = walk(values[i], p, sorted, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkChildren returns the keys and values of a map, in map order unless sorted is set, or the
+// indices and values of an array
+func walkChildren(val interface{}, sorted bool) ([]string, []interface{}) {
+	if sorted || val == nil || reflect.TypeOf(val).Kind() != reflect.Map {
+		return childrenOf(val)
+	}
+	m, ok := toStringMap(val)
+	if !ok {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(m))
+	values := make([]interface{}, 0, len(m))
+	for key, value := range m {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return keys, values
+}
@@ -0,0 +1,290 @@
+package mappath
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+/*
+ * -------
+ * EachChunk
+ * -------
+ */
+
+func chunkTest(size int) map[string]interface{} {
+	records := make([]interface{}, size)
+	for i := range records {
+		records[i] = map[string]interface{}{"id": i}
+	}
+	return map[string]interface{}{
+		"records": records,
+		"mixed":   []interface{}{map[string]interface{}{"id": 0}, "foo"},
+		"scalar":  "foo",
+	}
+}
+
+func TestEachChunk(t *testing.T) {
+	m := NewMapPath(chunkTest(7))
+	starts := []int{}
+	ids := []int{}
+	err := m.EachChunk("records", 3, func(start int, items []*MapPath) error {
+		assert.True(t, len(items) <= 3, "Chunk size respected")
+		starts = append(starts, start)
+		for _, item := range items {
+			ids = append(ids, item.IntV("id"))
+		}
+		return nil
+	})
+	assert.Nil(t, err, "NO error returned")
+	assert.Equal(t, []int{0, 3, 6}, starts, "Chunk starts reported")
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6}, ids, "All items iterated in order")
+}
+
+func TestEachChunkStopsOnError(t *testing.T) {
+	m := NewMapPath(chunkTest(7))
+	calls := 0
+	stop := errors.New("stop")
+	err := m.EachChunk("records", 2, func(start int, items []*MapPath) error {
+		calls++
+		return stop
+	})
+	assert.Equal(t, stop, err, "Callback error returned")
+	assert.Equal(t, 1, calls, "Iteration stopped")
+}
+
+func TestEachChunkErrors(t *testing.T) {
+	m := NewMapPath(chunkTest(1))
+	noop := func(start int, items []*MapPath) error { return nil }
+	_, ok := m.EachChunk("missing", 2, noop).(NotFoundError)
+	assert.True(t, ok, "Not found error returned")
+	_, ok = m.EachChunk("scalar", 2, noop).(*InvalidTypeError)
+	assert.True(t, ok, "Invalid type error returned for scalar")
+	_, ok = m.EachChunk("mixed", 2, noop).(*InvalidTypeError)
+	assert.True(t, ok, "Invalid type error returned for non-map items")
+	assert.NotNil(t, m.EachChunk("records", 0, noop), "Error on invalid size")
+}
+
+/*
+ * -------
+ * ChildsByKey
+ * -------
+ */
+
+var childsByKeyTest = map[string]interface{}{
+	"servers": []interface{}{
+		map[string]interface{}{"name": "web", "port": 80},
+		map[string]interface{}{"name": "db", "port": 5432},
+		map[string]interface{}{"name": 1, "port": 1},
+	},
+	"duplicates": []interface{}{
+		map[string]interface{}{"name": "web"},
+		map[string]interface{}{"name": "web"},
+	},
+	"incomplete": []interface{}{
+		map[string]interface{}{"name": "web"},
+		map[string]interface{}{"port": 1},
+	},
+}
+
+func TestChildsByKey(t *testing.T) {
+	m := NewMapPath(childsByKeyTest)
+	r, err := m.ChildsByKey("servers", "name")
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, 3, len(r), "All elements indexed")
+	assert.Equal(t, 5432, r["db"].IntV("port"), "Element found by key")
+	assert.Equal(t, 1, r["1"].IntV("port"), "Non string keys converted")
+
+	_, err = m.ChildsByKey("duplicates", "name")
+	assert.NotNil(t, err, "Error on duplicate keys")
+	_, err = m.ChildsByKey("incomplete", "name")
+	assert.Equal(t, NotFoundError("incomplete/1/name"), err, "Error on missing key field")
+	_, err = m.ChildsByKey("missing", "name")
+	assert.Equal(t, NotFoundError("missing"), err, "Error on missing path")
+}
+
+/*
+ * -------
+ * Walk / Flatten
+ * -------
+ */
+
+var walkTest = map[string]interface{}{
+	"b": map[string]interface{}{"y": 2, "x": 1},
+	"a": []interface{}{"foo", map[string]interface{}{}},
+	"c": nil,
+}
+
+func TestWalkSorted(t *testing.T) {
+	m := NewMapPath(walkTest, WithSortedKeys())
+	paths := []string{}
+	err := m.Walk(func(path string, val interface{}) error {
+		paths = append(paths, path)
+		return nil
+	})
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, []string{"a", "a/0", "a/1", "b", "b/x", "b/y", "c"}, paths, "All paths visited in sorted order")
+
+	for i := 0; i < 10; i++ {
+		again := []string{}
+		m.Walk(func(path string, val interface{}) error {
+			again = append(again, path)
+			return nil
+		})
+		assert.Equal(t, paths, again, "Order is stable")
+	}
+}
+
+func TestWalkStopsOnError(t *testing.T) {
+	m := NewMapPath(walkTest)
+	calls := 0
+	stop := errors.New("stop")
+	err := m.Walk(func(path string, val interface{}) error {
+		calls++
+		return stop
+	})
+	assert.Equal(t, stop, err, "Callback error returned")
+	assert.Equal(t, 1, calls, "Walk stopped")
+
+	paths := []string{}
+	NewMapPath(walkTest).Walk(func(path string, val interface{}) error {
+		paths = append(paths, path)
+		return nil
+	})
+	assert.ElementsMatch(t, []string{"a", "a/0", "a/1", "b", "b/x", "b/y", "c"}, paths, "All paths visited unsorted")
+}
+
+func TestFlatten(t *testing.T) {
+	m := NewMapPath(walkTest)
+	assert.Equal(t, map[string]interface{}{
+		"a/0": "foo",
+		"a/1": map[string]interface{}{},
+		"b/x": 1,
+		"b/y": 2,
+		"c":   nil,
+	}, m.Flatten(), "Leaves flattened")
+}
+
+func TestDiff(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"a": 1,
+		"b": map[string]interface{}{"x": 1, "y": []interface{}{1, 2}, "z": "gone"},
+		"c": "same",
+	}, WithSortedKeys())
+	other := NewMapPath(map[string]interface{}{
+		"a": 2,
+		"b": map[string]interface{}{"x": 1, "y": []interface{}{1, 3}, "w": true},
+		"c": "same",
+		"d": map[string]interface{}{"new": 1},
+	})
+	assert.Equal(t, []Change{
+		{Op: ChangeSet, Path: "a", Old: 1, New: 2, Existed: true},
+		{Op: ChangeSet, Path: "b/w", New: true},
+		{Op: ChangeSet, Path: "b/y", Old: []interface{}{1, 2}, New: []interface{}{1, 3}, Existed: true},
+		{Op: ChangeDelete, Path: "b/z", Old: "gone", Existed: true},
+		{Op: ChangeSet, Path: "d", New: map[string]interface{}{"new": 1}},
+	}, m.Diff(other), "Changes in sorted order")
+	assert.Equal(t, []Change{}, m.Diff(m), "No changes")
+	assert.Equal(t, 2, len(NewMapPath(map[string]interface{}{"a": 1}).Diff(NewMapPath(map[string]interface{}{"b": 1}))), "Unsorted changes")
+}
+
+func TestToCanonicalJson(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"b": map[string]interface{}{"y": 2, "x": 1},
+		"a": []interface{}{"foo", map[interface{}]interface{}{"k": "v"}},
+	})
+	for i := 0; i < 10; i++ {
+		out, err := m.ToCanonicalJson()
+		assert.Nil(t, err, "No error returned")
+		assert.Equal(t, `{"a":["foo",{"k":"v"}],"b":{"x":1,"y":2}}`, string(out), "Compact JSON with sorted keys")
+	}
+}
+
+/*
+ * -------
+ * PickWeighted
+ * -------
+ */
+
+var pickWeightedTest = map[string]interface{}{
+	"backends": []interface{}{
+		map[string]interface{}{"name": "a", "weight": 1},
+		map[string]interface{}{"name": "b", "weight": 3},
+		map[string]interface{}{"name": "never", "weight": 0},
+	},
+	"zero":     []interface{}{map[string]interface{}{"weight": 0}},
+	"negative": []interface{}{map[string]interface{}{"weight": -1}},
+	"missing":  []interface{}{map[string]interface{}{"name": "x"}},
+}
+
+func TestPickWeighted(t *testing.T) {
+	m := NewMapPath(pickWeightedTest, WithRand(rand.New(rand.NewSource(1))))
+	counts := map[string]int{}
+	for i := 0; i < 4000; i++ {
+		r, err := m.PickWeighted("backends", "weight")
+		assert.Nil(t, err, "No error returned")
+		counts[r.StringV("name")]++
+	}
+	assert.Equal(t, 0, counts["never"], "Zero weight never picked")
+	assert.InDelta(t, 3.0, float64(counts["b"])/float64(counts["a"]), 0.3, "Picked proportionally")
+}
+
+func TestPickWeightedErrors(t *testing.T) {
+	m := NewMapPath(pickWeightedTest)
+	_, err := m.PickWeighted("zero", "weight")
+	assert.NotNil(t, err, "Error without positive weights")
+	_, err = m.PickWeighted("negative", "weight")
+	_, ok := err.(*RangeError)
+	assert.True(t, ok, "Range error on negative weight")
+	_, err = m.PickWeighted("missing", "weight")
+	assert.Equal(t, NotFoundError("missing/0/weight"), err, "Error on missing weight")
+	_, err = m.PickWeighted("nope", "weight")
+	assert.Equal(t, NotFoundError("nope"), err, "Error on missing path")
+}
+
+/*
+ * -------
+ * Page
+ * -------
+ */
+
+var pageTests = []struct {
+	page   int
+	ids    []int
+	hasNxt bool
+}{
+	{1, []int{0, 1, 2}, true},
+	{2, []int{3, 4, 5}, true},
+	{3, []int{6}, false},
+	{4, []int{}, false},
+}
+
+func TestPage(t *testing.T) {
+	m := NewMapPath(chunkTest(7))
+	for _, test := range pageTests {
+		items, info, err := m.Page("records", test.page, 3)
+		assert.Nil(t, err, "No error returned")
+		ids := []int{}
+		for _, item := range items {
+			ids = append(ids, item.IntV("id"))
+		}
+		assert.Equal(t, test.ids, ids, "Elements of page returned")
+		assert.Equal(t, PageInfo{Page: test.page, PerPage: 3, Total: 7, Pages: 3}, info, "Page info returned")
+		assert.Equal(t, test.hasNxt, info.HasNext(), "Next page reported")
+	}
+}
+
+func TestPageErrors(t *testing.T) {
+	m := NewMapPath(chunkTest(1))
+	_, _, err := m.Page("records", 0, 3)
+	assert.NotNil(t, err, "Error on invalid page")
+	_, _, err = m.Page("records", 1, 0)
+	assert.NotNil(t, err, "Error on invalid page size")
+	_, _, err = m.Page("missing", 1, 3)
+	_, ok := err.(NotFoundError)
+	assert.True(t, ok, "Not found error returned")
+	_, _, err = m.Page("mixed", 1, 3)
+	_, ok = err.(*InvalidTypeError)
+	assert.True(t, ok, "Invalid type error returned for non-map items")
+}
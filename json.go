@@ -1,33 +1,250 @@
 package mappath
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/fs"
 	"io/ioutil"
+	"os"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
+// ValueKey is the default key under which FromJsonValue wraps non object documents
+const ValueKey = "value"
+
+// LimitError is returned by loaders if a document exceeds the limits set with WithMaxBytes or WithMaxDepth
+type LimitError struct {
+	Limit string
+	Max   int
+}
+
+func (this *LimitError) Error() string {
+	return fmt.Sprintf("The document exceeds the maximum %s of %d", this.Limit, this.Max)
+}
+
+// DuplicateKeysError is returned by loaders with WithStrictKeys and lists the paths of all keys
+// which occur more than once in the same object
+type DuplicateKeysError []string
+
+func (err DuplicateKeysError) Error() string {
+	return "Duplicate keys in document: \"" + strings.Join(err, "\", \"") + "\""
+}
+
 // FromJson is a factory method to create a MapPath from JSON byte data
-func FromJson(in []byte) (*MapPath, error) {
-	var data interface{}
-	err := json.Unmarshal(in, &data)
+func FromJson(in []byte, opts ...Option) (*MapPath, error) {
+	data, err := decodeJson(in, newOptions(opts))
 	if err != nil {
 		return nil, err
 	}
 	switch data.(type) {
 	case map[string]interface{}:
-		return NewMapPath(data.(map[string]interface{})), nil
+		return NewMapPath(data.(map[string]interface{}), opts...), nil
 	}
 
 	return nil, fmt.Errorf("Cannot JSON which is marshalled to %+v. Must be marshallable to map[string]interface {}", reflect.TypeOf(data))
 }
 
+// ToJson returns the structure as indented JSON document. NaN and infinite floats result in a
+// NonFiniteError, unless encoded otherwise with WithNonFiniteJson.
+func (this *MapPath) ToJson() ([]byte, error) {
+	if this == nil {
+		this = empty
+	}
+	this.auditTree("", map[string]interface{}(this.root))
+	out, err := json.MarshalIndent(map[string]interface{}(this.root), "", "  ")
+	if _, unsupported := err.(*json.UnsupportedValueError); unsupported {
+		// only structures which cannot be encoded as they are are searched for non-finite floats
+		root, _, ferr := encodableJson(map[string]interface{}(this.root), nil, this.opts().nonFiniteJson)
+		if ferr != nil {
+			return nil, ferr
+		}
+		return json.MarshalIndent(root, "", "  ")
+	}
+	return out, err
+}
+
+// ToCanonicalJson returns the structure as canonical JSON document: compact and with sorted keys, so
+// that equal structures result in equal documents, eg to hash, sign or compare them. Non-finite floats
+// are handled like ToJson does.
+func (this *MapPath) ToCanonicalJson() ([]byte, error) {
+	if this == nil {
+		this = empty
+	}
+	this.auditTree("", map[string]interface{}(this.root))
+	root := canonicalValue(map[string]interface{}(this.root))
+	out, err := json.Marshal(root)
+	if _, unsupported := err.(*json.UnsupportedValueError); unsupported {
+		encodable, _, ferr := encodableJson(root, nil, this.opts().nonFiniteJson)
+		if ferr != nil {
+			return nil, ferr
+		}
+		return json.Marshal(encodable)
+	}
+	return out, err
+}
+
+// FromJsonValue is a factory method to create a MapPath from any JSON document. Objects are used as
+// is, while all other documents (arrays, scalars, null) are wrapped under the key ValueKey, or the
+// key set with WithValueKey.
+func FromJsonValue(in []byte, opts ...Option) (*MapPath, error) {
+	o := newOptions(opts)
+	data, err := decodeJson(in, o)
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := data.(map[string]interface{}); ok {
+		return NewMapPath(m, opts...), nil
+	}
+	key := o.valueKey
+	if key == "" {
+		key = ValueKey
+	}
+	return NewMapPath(map[string]interface{}{key: data}, opts...), nil
+}
+
 // FromJsonFile is a factory method to create a MapPath from a JSON file
-func FromJsonFile(file string) (*MapPath, error) {
-	in, err := ioutil.ReadFile(file)
+func FromJsonFile(file string, opts ...Option) (*MapPath, error) {
+	fh, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+	in, err := readDocument(fh, newOptions(opts))
 	if err != nil {
 		return nil, err
 	}
 
-	return FromJson(in)
+	return FromJson(in, verified(opts)...)
+}
+
+// FromJsonFS is a factory method to create a MapPath from a JSON file within the given
+// file system, eg an embed.FS
+func FromJsonFS(fsys fs.FS, name string, opts ...Option) (*MapPath, error) {
+	fh, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+	in, err := readDocument(fh, newOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return FromJson(in, verified(opts)...)
+}
+
+// readLimited reads all data from the reader, but not more than the maximum bytes
+func readLimited(r io.Reader, o *options) ([]byte, error) {
+	if o.maxBytes <= 0 {
+		return ioutil.ReadAll(r)
+	}
+	in, err := ioutil.ReadAll(io.LimitReader(r, int64(o.maxBytes)+1))
+	if err != nil {
+		return nil, err
+	} else if len(in) > o.maxBytes {
+		return nil, &LimitError{"bytes", o.maxBytes}
+	}
+	return in, nil
+}
+
+// decodeJson unmarshals the data after checking the size limit, the digest and signature, and the depth
+// limit
+func decodeJson(in []byte, o *options) (interface{}, error) {
+	if o.maxBytes > 0 && len(in) > o.maxBytes {
+		return nil, &LimitError{"bytes", o.maxBytes}
+	} else if err := verifyDocument(in, o); err != nil {
+		return nil, err
+	} else if o.maxDepth > 0 && jsonDepth(in) > o.maxDepth {
+		return nil, &LimitError{"depth", o.maxDepth}
+	}
+	var data interface{}
+	if err := json.Unmarshal(in, &data); err != nil {
+		return nil, err
+	}
+	if o.strictKeys {
+		dec := json.NewDecoder(bytes.NewReader(in))
+		var duplicates DuplicateKeysError
+		if err := jsonDuplicates(dec, "", &duplicates); err != nil {
+			return nil, err
+		} else if len(duplicates) > 0 {
+			return nil, duplicates
+		}
+	}
+	return data, nil
+}
+
+// jsonDuplicates reads the next value from the decoder and collects the paths of duplicate keys
+func jsonDuplicates(dec *json.Decoder, path string, duplicates *DuplicateKeysError) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case json.Delim('{'):
+		seen := make(map[string]bool)
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key := joinPath(path, tok.(string))
+			if seen[key] {
+				*duplicates = append(*duplicates, key)
+			}
+			seen[key] = true
+			if err = jsonDuplicates(dec, key, duplicates); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	case json.Delim('['):
+		for i := 0; dec.More(); i++ {
+			if err = jsonDuplicates(dec, joinPath(path, strconv.Itoa(i)), duplicates); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	}
+	return err
+}
+
+// joinPath returns the path of the key within the parent path, with the key escaped, see FormatPath
+func joinPath(parent, key string) string {
+	if parent == "" {
+		return escapeKey(key)
+	}
+	return parent + "/" + escapeKey(key)
+}
+
+// jsonDepth returns the maximum nesting of objects and arrays, without parsing the document
+func jsonDepth(in []byte) int {
+	depth, max := 0, 0
+	inString, escaped := false, false
+	for _, c := range in {
+		if inString {
+			if escaped {
+				escaped = false
+			} else if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			if depth++; depth > max {
+				max = depth
+			}
+		case '}', ']':
+			depth--
+		}
+	}
+	return max
 }
@@ -2,7 +2,9 @@ package mappath
 
 import (
 	"github.com/stretchr/testify/assert"
+	"os"
 	"testing"
+	"testing/fstest"
 )
 
 func TestFromValidJson(t *testing.T) {
@@ -30,3 +32,108 @@ func TestFromMissingJsonFile(t *testing.T) {
 	assert.NotNil(t, e, "Error has been returned")
 	assert.Nil(t, r, "No result is returned")
 }
+
+func TestFromValidJsonFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config/ok.json": &fstest.MapFile{Data: []byte(`{"foo":"bar"}`)},
+	}
+	r, e := FromJsonFS(fsys, "config/ok.json")
+	assert.Nil(t, e, "No error returned")
+	d, e := r.String("foo")
+	assert.Nil(t, e, "foo key found")
+	assert.Equal(t, "bar", d, "bar value returned")
+}
+
+func TestFromMissingJsonFS(t *testing.T) {
+	r, e := FromJsonFS(os.DirFS("resources"), "missing.json")
+	assert.NotNil(t, e, "Error has been returned")
+	assert.Nil(t, r, "No result is returned")
+
+	r, e = FromJsonFS(os.DirFS("resources"), "ok.json")
+	assert.Nil(t, e, "No error returned from directory file system")
+	assert.NotNil(t, r, "Result is returned")
+}
+
+var fromJsonValueTests = []struct {
+	in     string
+	key    string
+	expect interface{}
+}{
+	{`[1,2]`, "value", []interface{}{1.0, 2.0}},
+	{`"foo"`, "value", "foo"},
+	{`12.5`, "value", 12.5},
+	{`null`, "value", nil},
+	{`{"foo":"bar"}`, "foo", "bar"},
+}
+
+func TestFromJsonValue(t *testing.T) {
+	for _, test := range fromJsonValueTests {
+		r, e := FromJsonValue([]byte(test.in))
+		assert.Nil(t, e, "No error returned for "+test.in)
+		v, e := r.Get(test.key)
+		assert.Nil(t, e, "Key found for "+test.in)
+		assert.Equal(t, test.expect, v, "Expected value for "+test.in)
+	}
+
+	r, e := FromJsonValue([]byte(`[1]`), WithValueKey("items"))
+	assert.Nil(t, e, "No error returned with custom key")
+	assert.Equal(t, 1, r.IntV("items/0"), "Wrapped under custom key")
+
+	_, e = FromJsonValue([]byte(`[1`))
+	assert.NotNil(t, e, "Error returned for invalid JSON")
+}
+
+var jsonLimitTests = []struct {
+	in       string
+	maxBytes int
+	maxDepth int
+	limit    string
+}{
+	{`{"a":1}`, 7, 0, ""},
+	{`{"a":12}`, 7, 0, "bytes"},
+	{`{"a":{"b":[1]}}`, 0, 3, ""},
+	{`{"a":{"b":[[1]]}}`, 0, 3, "depth"},
+	{`{"a":"{{{{[[[["}`, 0, 1, ""},
+	{`{"a":"\"{{{"}`, 0, 1, ""},
+}
+
+func TestFromJsonLimits(t *testing.T) {
+	for _, test := range jsonLimitTests {
+		r, e := FromJson([]byte(test.in), WithMaxBytes(test.maxBytes), WithMaxDepth(test.maxDepth))
+		if test.limit == "" {
+			assert.Nil(t, e, "No error returned for "+test.in)
+			assert.NotNil(t, r, "Result returned for "+test.in)
+		} else {
+			le, ok := e.(*LimitError)
+			assert.True(t, ok, "Limit error returned for "+test.in)
+			assert.Equal(t, test.limit, le.Limit, "Exceeded limit reported for "+test.in)
+			assert.Nil(t, r, "No result returned for "+test.in)
+		}
+	}
+}
+
+func TestFromJsonFileMaxBytes(t *testing.T) {
+	_, e := FromJsonFile("resources/ok.json", WithMaxBytes(3))
+	_, ok := e.(*LimitError)
+	assert.True(t, ok, "Limit error returned for file")
+	_, e = FromJsonFS(os.DirFS("resources"), "ok.json", WithMaxBytes(3))
+	_, ok = e.(*LimitError)
+	assert.True(t, ok, "Limit error returned for file system")
+	assert.Equal(t, "The document exceeds the maximum depth of 2", (&LimitError{"depth", 2}).Error(), "Error correctly formatted")
+}
+
+func TestFromJsonStrictKeys(t *testing.T) {
+	in := []byte(`{"a":1,"b":{"c":1,"c":2},"l":[{"x":1,"x":1}],"a":2}`)
+	r, e := FromJson(in)
+	assert.Nil(t, e, "No error without strict keys")
+	assert.Equal(t, 2, r.IntV("a"), "Last value wins")
+
+	r, e = FromJson(in, WithStrictKeys())
+	assert.Nil(t, r, "No result returned")
+	assert.Equal(t, DuplicateKeysError{"b/c", "l/0/x", "a"}, e, "Duplicate keys reported with paths")
+	assert.Equal(t, "Duplicate keys in document: \"b/c\", \"l/0/x\", \"a\"", e.Error(), "Error correctly formatted")
+
+	r, e = FromJson([]byte(`{"a":{"c":1},"b":{"c":1}}`), WithStrictKeys())
+	assert.Nil(t, e, "Same keys in different objects are fine")
+	assert.NotNil(t, r, "Result returned")
+}
@@ -0,0 +1,78 @@
+package mappath
+
+import (
+	"os"
+)
+
+// FromJsonc is a factory method to create a MapPath from JSON with comments (JSONC). Line comments
+// (// ...), block comments (/* ... */) and trailing commas in objects and arrays are removed before
+// parsing. Removed characters are replaced with spaces, so that offsets in syntax errors still match
+// the original document. Digest and signature, see WithSHA256, are verified against the original document.
+func FromJsonc(in []byte, opts ...Option) (*MapPath, error) {
+	o := newOptions(opts)
+	if o.maxBytes > 0 && len(in) > o.maxBytes {
+		return nil, &LimitError{"bytes", o.maxBytes}
+	} else if err := verifyDocument(in, o); err != nil {
+		return nil, err
+	}
+	return FromJson(stripJsonc(in), verified(opts)...)
+}
+
+// FromJsoncFile is a factory method to create a MapPath from a JSONC file
+func FromJsoncFile(file string, opts ...Option) (*MapPath, error) {
+	fh, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+	in, err := readDocument(fh, newOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return FromJsonc(in, verified(opts)...)
+}
+
+// stripJsonc returns a copy of the data with comments and trailing commas blanked out
+func stripJsonc(in []byte) []byte {
+	out := make([]byte, len(in))
+	copy(out, in)
+	comma := -1
+	for i := 0; i < len(out); i++ {
+		switch c := out[i]; {
+		case c == '"':
+			comma = -1
+			for i++; i < len(out) && out[i] != '"'; i++ {
+				if out[i] == '\\' {
+					i++
+				}
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			out[i], out[i+1] = ' ', ' '
+			for i += 2; i < len(out) && !(out[i] == '*' && i+1 < len(out) && out[i+1] == '/'); i++ {
+				if out[i] != '\n' {
+					out[i] = ' '
+				}
+			}
+			if i < len(out) {
+				out[i], out[i+1] = ' ', ' '
+				i++
+			}
+		case c == ',':
+			comma = i
+		case c == '}' || c == ']':
+			if comma >= 0 {
+				out[comma] = ' '
+			}
+			comma = -1
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+		default:
+			comma = -1
+		}
+	}
+	return out
+}
@@ -0,0 +1,50 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * JSONC
+ * -------
+ */
+
+var stripJsoncTests = []struct {
+	in     string
+	expect string
+}{
+	{`{"a":1}`, `{"a":1}`},
+	{"{\"a\":1 // comment\n}", "{\"a\":1           \n}"},
+	{`{"a":/* x */1}`, `{"a":       1}`},
+	{`{"a":[1,2,],}`, `{"a":[1,2 ] }`},
+	{"{\"a\":1, // c\n}", "{\"a\":1      \n}"},
+	{`{"a":"// no comment, /* */ ,}"}`, `{"a":"// no comment, /* */ ,}"}`},
+	{`{"a":"\"//"}`, `{"a":"\"//"}`},
+	{"{/* a\nb */}", "{    \n    }"},
+}
+
+func TestStripJsonc(t *testing.T) {
+	for _, test := range stripJsoncTests {
+		assert.Equal(t, test.expect, string(stripJsonc([]byte(test.in))), "Stripped "+test.in)
+	}
+}
+
+func TestFromJsonc(t *testing.T) {
+	r, e := FromJsonc([]byte(`{
+		// the server
+		"server": {
+			"host": "localhost", /* default */
+			"ports": [80, 443,],
+		},
+	}`))
+	assert.Nil(t, e, "No error returned")
+	assert.Equal(t, "localhost", r.StringV("server/host"), "Value found")
+	assert.Equal(t, 443, r.IntV("server/ports/1"), "Array with trailing comma parsed")
+
+	_, e = FromJsonc([]byte(`{"a":1,,}`))
+	assert.NotNil(t, e, "Error on invalid document")
+	_, e = FromJsoncFile("resources/missing.jsonc")
+	assert.NotNil(t, e, "Error on missing file")
+}
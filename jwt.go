@@ -0,0 +1,24 @@
+package mappath
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// FromJWTPayload is a factory method to create a MapPath from the claims (payload) of a JWT/JWS token
+// in compact serialization ("header.payload.signature"), eg for inspecting the claims of a token.
+//
+// The signature is NOT verified: never trust the claims for authentication or authorization unless the
+// token has been verified by other means.
+func FromJWTPayload(token string, opts ...Option) (*MapPath, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("Invalid token: expected 3 segments, got %d", len(parts))
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid token payload: %s", err)
+	}
+	return FromJson(payload, opts...)
+}
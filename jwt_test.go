@@ -0,0 +1,36 @@
+package mappath
+
+import (
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * JWT
+ * -------
+ */
+
+func TestFromJWTPayload(t *testing.T) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"1234","name":"Jean-Luc","roles":["captain"],"exp":1700000000}`))
+
+	m, err := FromJWTPayload(header + "." + payload + ".signature")
+	assert.Nil(t, err, "No error on valid token")
+	assert.Equal(t, "Jean-Luc", m.StringV("name"), "Claim found")
+	assert.Equal(t, []string{"captain"}, m.StringsV("roles"), "Array claim found")
+	assert.Equal(t, int64(1700000000), m.TimeV("exp").Unix(), "Time claim found")
+
+	padded := base64.URLEncoding.EncodeToString([]byte(`{"sub":"12"}`))
+	m, err = FromJWTPayload(" " + header + "." + padded + ". ")
+	assert.Nil(t, err, "No error on padded payload and surrounding whitespace")
+	assert.Equal(t, "12", m.StringV("sub"), "Claim of padded payload found")
+
+	_, err = FromJWTPayload(header + "." + payload)
+	assert.EqualError(t, err, "Invalid token: expected 3 segments, got 2", "Missing segment")
+	_, err = FromJWTPayload(header + ".!!!.signature")
+	assert.NotNil(t, err, "Invalid base64")
+	_, err = FromJWTPayload(header + "." + header[:4] + ".signature")
+	assert.NotNil(t, err, "Invalid JSON")
+}
@@ -0,0 +1,56 @@
+package mappath
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// WithKeyCaseMatching makes lookups of missing map keys fall back to keys in another casing
+// convention: camelCase, PascalCase, snake_case and kebab-case spellings of the same words match each
+// other, eg Get("maxRetries") returns the value of "max_retries" and Get("max_retries") the one of
+// "maxRetries". Keys matching exactly always win. If multiple keys match, the first in sorted order
+// is used. Modifications (eg Set) are not affected and use the key as given.
+func WithKeyCaseMatching() Option {
+	return func(o *options) {
+		o.keyCasing = true
+	}
+}
+
+// matchKeyCase returns the value of the key of current matching name in another casing convention
+func matchKeyCase(current map[string]interface{}, name string) (interface{}, bool) {
+	want := snakeKey(name)
+	candidates := []string{}
+	for key := range current {
+		if snakeKey(key) == want {
+			candidates = append(candidates, key)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, false
+	}
+	sort.Strings(candidates)
+	return current[candidates[0]], true
+}
+
+// snakeKey returns the key in snake_case, eg "maxRetries", "MaxRetries" and "max-retries" become
+// "max_retries" and "HTTPServer" becomes "http_server"
+func snakeKey(key string) string {
+	runes := []rune(key)
+	b := new(strings.Builder)
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == '_':
+			b.WriteRune('_')
+			continue
+		case unicode.IsUpper(r) && i > 0:
+			prev := runes[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Key casing
+ * -------
+ */
+
+var snakeKeyTests = []struct {
+	key      string
+	expected string
+}{
+	{"maxRetries", "max_retries"},
+	{"MaxRetries", "max_retries"},
+	{"max_retries", "max_retries"},
+	{"max-retries", "max_retries"},
+	{"HTTPServer", "http_server"},
+	{"serverID", "server_id"},
+	{"v2Api", "v2_api"},
+	{"plain", "plain"},
+}
+
+func TestSnakeKey(t *testing.T) {
+	for _, test := range snakeKeyTests {
+		assert.Equal(t, test.expected, snakeKey(test.key), "Snake case of "+test.key)
+	}
+}
+
+func TestWithKeyCaseMatching(t *testing.T) {
+	data := map[string]interface{}{
+		"max_retries": 3,
+		"httpClient": map[string]interface{}{
+			"timeout-seconds": 10,
+		},
+		"exact_key": "snake",
+		"exactKey":  "camel",
+		"servers":   []interface{}{map[string]interface{}{"host_name": "a"}},
+	}
+	assert.False(t, NewMapPath(data).Has("maxRetries"), "No matching by default")
+
+	m := NewMapPath(data, WithKeyCaseMatching())
+	assert.Equal(t, 3, m.IntV("maxRetries"), "camelCase matches snake_case")
+	assert.Equal(t, 3, m.IntV("MaxRetries"), "PascalCase matches snake_case")
+	assert.Equal(t, 10, m.IntV("http_client/timeoutSeconds"), "Matched on every level")
+	assert.Equal(t, 10, m.ChildV("http_client").IntV("timeoutSeconds"), "Matched in sub structure")
+	assert.Equal(t, "a", m.StringV("servers/0/hostName"), "Matched within array")
+	assert.Equal(t, "camel", m.StringV("exactKey"), "Exact key wins")
+	assert.Equal(t, "snake", m.StringV("exact_key"), "Exact key wins")
+	assert.Equal(t, "camel", m.StringV("exact-key"), "First sorted key of multiple")
+	assert.False(t, m.Has("maxretries"), "Words must match")
+
+	values, err := m.GetMany([]string{"maxRetries", "httpClient/timeoutSeconds"})
+	assert.Nil(t, err, "No error with GetMany")
+	assert.Equal(t, 3, values["maxRetries"], "Matched by GetMany")
+}
@@ -0,0 +1,57 @@
+package mappath
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToLabels returns the scalar leaves (any value which is neither map nor array) matching the glob expression
+// (see Paths) as metric label set, eg for Prometheus. Label names are the paths with all characters other than
+// letters, digits and underscores replaced by underscores ("service/region" becomes "service_region"), a
+// leading digit prefixed with an underscore and leading double underscores, which are reserved, reduced to one.
+// Null values become empty strings. If two paths result in the same label name then an error is returned.
+func (this *MapPath) ToLabels(glob string) (map[string]string, error) {
+	if this == nil {
+		this = empty
+	}
+	labels := map[string]string{}
+	sources := map[string]string{}
+	var err error
+	this.glob(glob, func(path string, val interface{}) {
+		if err != nil || isMap(val) || isSlice(val) {
+			return
+		}
+		name := labelName(path)
+		if other, ok := sources[name]; ok {
+			err = fmt.Errorf("Paths \"%s\" and \"%s\" result in the same label \"%s\"", other, path, name)
+			return
+		}
+		sources[name] = path
+		if val == nil {
+			labels[name] = ""
+		} else {
+			labels[name] = fmt.Sprint(val)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// labelName sanitizes path into a valid label name
+func labelName(path string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, path)
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	if strings.HasPrefix(name, "__") {
+		name = "_" + strings.TrimLeft(name, "_")
+	}
+	return name
+}
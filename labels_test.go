@@ -0,0 +1,62 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Labels
+ * -------
+ */
+
+var labelNameTests = []struct {
+	path     string
+	expected string
+}{
+	{"region", "region"},
+	{"service/region", "service_region"},
+	{"service/app-name.v2", "service_app_name_v2"},
+	{"servers/0/host", "servers_0_host"},
+	{"0/host", "_0_host"},
+	{"__meta", "_meta"},
+	{"/x", "_x"},
+	{"zone/ümlaut", "zone__mlaut"},
+}
+
+func TestLabelName(t *testing.T) {
+	for _, test := range labelNameTests {
+		assert.Equal(t, test.expected, labelName(test.path), "Label name of "+test.path)
+	}
+}
+
+func TestToLabels(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"service": map[string]interface{}{
+			"name":    "api",
+			"replica": 3,
+			"canary":  true,
+			"owner":   nil,
+			"tags":    []interface{}{"a"},
+			"db":      map[string]interface{}{"host": "x"},
+		},
+		"a-b": "one",
+		"a_b": "two",
+	})
+	labels, err := m.ToLabels("service/*")
+	assert.Nil(t, err, "No error")
+	assert.Equal(t, map[string]string{
+		"service_name":    "api",
+		"service_replica": "3",
+		"service_canary":  "true",
+		"service_owner":   "",
+	}, labels, "Scalar leaves as labels")
+
+	labels, err = m.ToLabels("missing/*")
+	assert.Nil(t, err, "No error without matches")
+	assert.Equal(t, map[string]string{}, labels, "Empty label set")
+
+	_, err = m.ToLabels("a?b")
+	assert.EqualError(t, err, `Paths "a-b" and "a_b" result in the same label "a_b"`, "Label collision")
+}
@@ -0,0 +1,214 @@
+package mappath
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LanguageTag is a BCP 47 language tag like "en-US" or "zh-Hant-TW", see MapPath.LanguageTag. The parts
+// are in canonical case.
+type LanguageTag struct {
+	// Language is the primary language subtag including extended language subtags, eg "en" or "zh-yue"
+	Language string
+	// Script subtag, eg "Latn", if any
+	Script string
+	// Region subtag, eg "US" or "419", if any
+	Region string
+	// Variants subtags, eg "1996", if any
+	Variants []string
+	// Extensions including their singleton, eg "u-ca-gregory", if any
+	Extensions []string
+	// PrivateUse subtags including "x", eg "x-custom", if any
+	PrivateUse string
+}
+
+// String returns the tag in canonical case
+func (this LanguageTag) String() string {
+	parts := []string{}
+	for _, part := range append([]string{this.Language, this.Script, this.Region}, append(this.Variants, this.Extensions...)...) {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if this.PrivateUse != "" {
+		parts = append(parts, this.PrivateUse)
+	}
+	return strings.Join(parts, "-")
+}
+
+// LanguageTag returns the BCP 47 language tag of path, eg "en-US" or "de-CH-1996". Underscores are
+// accepted as separators (eg "en_US"). The syntax of the tag is validated, not whether its subtags are
+// registered. Invalid tags result in an InvalidTypeError.
+func (this *MapPath) LanguageTag(path string, fallback ...LanguageTag) (LanguageTag, error) {
+	val, err := this.Get(path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return LanguageTag{}, err
+	} else if val == nil {
+		return LanguageTag{}, NullValueError(path)
+	}
+	str, _ := val.(string)
+	tag, ok := parseLanguageTag(str)
+	if !ok {
+		return LanguageTag{}, &InvalidTypeError{val, "language tag"}
+	}
+	return tag, nil
+}
+
+// LanguageTagV returns the BCP 47 language tag of path. If value cannot be parsed then fallback or the zero tag is returned. Handy in single value context.
+func (this *MapPath) LanguageTagV(path string, fallback ...LanguageTag) LanguageTag {
+	if val, err := this.LanguageTag(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return LanguageTag{}
+	} else {
+		return val
+	}
+}
+
+// LanguageTags returns the array of BCP 47 language tags of path, eg ["en-US", "de"], see LanguageTag
+func (this *MapPath) LanguageTags(path string, fallback ...[]LanguageTag) ([]LanguageTag, error) {
+	val, err := this.arrayValue(path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return nil, err
+	}
+	result := make([]LanguageTag, val.Len())
+	for i := range result {
+		item := val.Index(i).Interface()
+		str, _ := item.(string)
+		tag, ok := parseLanguageTag(str)
+		if !ok {
+			return nil, &InvalidTypeError{item, fmt.Sprintf("[%d]array<language tag>", i)}
+		}
+		result[i] = tag
+	}
+	return result, nil
+}
+
+// LanguageTagsV returns the array of BCP 47 language tags of path. If value cannot be parsed then fallback or nil is returned. Handy in single value context.
+func (this *MapPath) LanguageTagsV(path string, fallback ...[]LanguageTag) []LanguageTag {
+	if val, err := this.LanguageTags(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return nil
+	} else {
+		return val
+	}
+}
+
+// parseLanguageTag parses the syntax of BCP 47 language tags, without grandfathered tags
+func parseLanguageTag(str string) (LanguageTag, bool) {
+	tag := LanguageTag{}
+	subtags := strings.Split(strings.ToLower(strings.Replace(strings.TrimSpace(str), "_", "-", -1)), "-")
+	for _, subtag := range subtags {
+		if len(subtag) == 0 || len(subtag) > 8 || !isAlnum(subtag) {
+			return tag, false
+		}
+	}
+	i := 0
+	next := func(valid func(string) bool) (string, bool) {
+		if i < len(subtags) && valid(subtags[i]) {
+			i++
+			return subtags[i-1], true
+		}
+		return "", false
+	}
+
+	if subtags[0] == "x" {
+		if len(subtags) < 2 {
+			return tag, false
+		}
+		tag.PrivateUse = strings.Join(subtags, "-")
+		return tag, true
+	}
+	language, ok := next(func(s string) bool { return isAlpha(s) && (len(s) >= 2 && len(s) <= 3 || len(s) >= 5) })
+	if !ok {
+		return tag, false
+	}
+	if len(language) <= 3 {
+		for n := 0; n < 3; n++ {
+			extlang, ok := next(func(s string) bool { return len(s) == 3 && isAlpha(s) })
+			if !ok {
+				break
+			}
+			language += "-" + extlang
+		}
+	}
+	tag.Language = language
+	if script, ok := next(func(s string) bool { return len(s) == 4 && isAlpha(s) }); ok {
+		tag.Script = strings.ToUpper(script[:1]) + script[1:]
+	}
+	if region, ok := next(func(s string) bool { return len(s) == 2 && isAlpha(s) || len(s) == 3 && isDigits(s) }); ok {
+		tag.Region = strings.ToUpper(region)
+	}
+	for {
+		variant, ok := next(func(s string) bool { return len(s) >= 5 || len(s) == 4 && s[0] >= '0' && s[0] <= '9' })
+		if !ok {
+			break
+		}
+		tag.Variants = append(tag.Variants, variant)
+	}
+	seen := map[string]bool{}
+	for i < len(subtags) && len(subtags[i]) == 1 && subtags[i] != "x" {
+		singleton := subtags[i]
+		if seen[singleton] {
+			return tag, false
+		}
+		seen[singleton] = true
+		i++
+		ext := []string{singleton}
+		for {
+			part, ok := next(func(s string) bool { return len(s) >= 2 })
+			if !ok {
+				break
+			}
+			ext = append(ext, part)
+		}
+		if len(ext) == 1 {
+			return tag, false
+		}
+		tag.Extensions = append(tag.Extensions, strings.Join(ext, "-"))
+	}
+	if i < len(subtags) && subtags[i] == "x" {
+		if i == len(subtags)-1 {
+			return tag, false
+		}
+		tag.PrivateUse = strings.Join(subtags[i:], "-")
+		i = len(subtags)
+	}
+	return tag, i == len(subtags)
+}
+
+func isAlnum(s string) bool {
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlpha(s string) bool {
+	for _, c := range s {
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
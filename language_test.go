@@ -0,0 +1,70 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Language tags
+ * -------
+ */
+
+var languageTagTests = []struct {
+	val      interface{}
+	expected string
+}{
+	{"en", "en"},
+	{"en-US", "en-US"},
+	{"en_us", "en-US"},
+	{"ZH-hant-tw", "zh-Hant-TW"},
+	{"es-419", "es-419"},
+	{"de-CH-1996", "de-CH-1996"},
+	{"sl-rozaj-biske", "sl-rozaj-biske"},
+	{"zh-yue-HK", "zh-yue-HK"},
+	{"en-US-u-ca-gregory-x-custom", "en-US-u-ca-gregory-x-custom"},
+	{"x-whatever", "x-whatever"},
+	{"", ""},
+	{"e", ""},
+	{"en-", ""},
+	{"en-US-u", ""},
+	{"en-a-bbb-a-ccc", ""},
+	{"en-x", ""},
+	{"toolongtag", ""},
+	{"en US", ""},
+	{"de-419-DE", ""},
+	{123, ""},
+	{false, ""},
+}
+
+func TestLanguageTag(t *testing.T) {
+	for _, test := range languageTagTests {
+		m := NewMapPath(map[string]interface{}{"lang": test.val})
+		tag, err := m.LanguageTag("lang")
+		assert.Equal(t, test.expected == "", err != nil, "Error of %v", test.val)
+		assert.Equal(t, test.expected, tag.String(), "Tag of %v", test.val)
+	}
+
+	tag := NewMapPath(map[string]interface{}{"lang": "zh-Hant-TW-u-nu-hanidec"}).LanguageTagV("lang")
+	assert.Equal(t, LanguageTag{Language: "zh", Script: "Hant", Region: "TW", Extensions: []string{"u-nu-hanidec"}}, tag, "Parts of tag")
+	fallback := LanguageTag{Language: "en"}
+	assert.Equal(t, fallback, NewMapPath(map[string]interface{}{}).LanguageTagV("missing", fallback), "Fallback used")
+}
+
+func TestLanguageTags(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"langs":   []interface{}{"en-US", "de", "fr_CA"},
+		"invalid": []interface{}{"en", "-"},
+		"scalar":  "en",
+	})
+	tags, err := m.LanguageTags("langs")
+	assert.Nil(t, err, "No error on valid tags")
+	assert.Equal(t, []LanguageTag{{Language: "en", Region: "US"}, {Language: "de"}, {Language: "fr", Region: "CA"}}, tags, "Tags parsed")
+
+	_, err = m.LanguageTags("invalid")
+	assert.EqualError(t, err, `Could not cast string into [1]array<language tag>`, "Invalid element")
+	_, err = m.LanguageTags("scalar")
+	assert.IsType(t, &InvalidTypeError{}, err, "No array")
+	assert.Nil(t, m.LanguageTagsV("missing"), "Nil on missing")
+}
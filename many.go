@@ -0,0 +1,117 @@
+package mappath
+
+import (
+	"sort"
+	"strings"
+)
+
+// PathErrors is returned by GetMany and SetMany and contains the error of each path which could not be
+// read or written
+type PathErrors map[string]error
+
+func (err PathErrors) Error() string {
+	paths := make([]string, 0, len(err))
+	for path := range err {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	msgs := make([]string, len(paths))
+	for i, path := range paths {
+		msgs[i] = err[path].Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// GetMany returns the values of all paths in one call, indexed by path. Common prefixes of the paths
+// are traversed only once. If any path cannot be read then the values of all others are returned
+// along with PathErrors, which contains the error (eg NotFoundError) of each failed path.
+func (this *MapPath) GetMany(paths []string) (map[string]interface{}, error) {
+	if this == nil {
+		this = empty
+	}
+	result := make(map[string]interface{}, len(paths))
+	errs := PathErrors{}
+	direct := this.computed == nil && len(this.mounts) == 0 && this.fallback == nil && this.provider == nil &&
+		this.opts().tracker == nil && this.opts().audit == nil && !this.opts().emptyAsMissing && !this.opts().keyCasing
+
+	containers := map[string]interface{}{"": map[string]interface{}(this.root)}
+	for _, path := range paths {
+		if !direct {
+			if val, err := this.Get(path); err != nil {
+				errs[path] = err
+			} else {
+				result[path] = val
+			}
+			continue
+		}
+
+		keys, err := splitPath(path)
+		if err != nil {
+			errs[path] = err
+			continue
+		}
+		// start with the longest already traversed prefix
+		start := len(keys) - 1
+		for ; start > 0; start-- {
+			if _, ok := containers[strings.Join(keys[:start], "\x00")]; ok {
+				break
+			}
+		}
+		current := containers[strings.Join(keys[:start], "\x00")]
+		found := true
+		for i := start; i < len(keys) && found; i++ {
+			if current, found = childOf(current, keys[i]); found && i < len(keys)-1 {
+				containers[strings.Join(keys[:i+1], "\x00")] = current
+			}
+		}
+		if found {
+			result[path] = current
+		} else {
+			errs[path] = NotFoundError(path)
+		}
+	}
+
+	if len(errs) > 0 {
+		return result, errs
+	}
+	return result, nil
+}
+
+// SetMany stores all values at their paths, like Set, as a single modification: either all values are
+// stored or, if any path cannot be written, none. The values are stored in the order of their sorted
+// paths, so that parents are written before their sub paths. The error of each path which cannot be
+// written is returned in PathErrors.
+func (this *MapPath) SetMany(values map[string]interface{}) error {
+	if this == nil || this == empty {
+		return ErrEmptyReadOnly
+	}
+	paths := make([]string, 0, len(values))
+	for path := range values {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	// dry run on a copy, to find all failing paths without modifying the structure
+	trial := deepCopy(map[string]interface{}(this.root)).(map[string]interface{})
+	errs := PathErrors{}
+	for _, path := range paths {
+		if _, err := set(trial, path, values[path]); err != nil {
+			errs[path] = err
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	defer this.changed()
+	changes := make([]Change, 0, len(paths))
+	defer func() { this.record(changes...) }()
+	for _, path := range paths {
+		change, err := set(this.root, path, values[path])
+		if err != nil {
+			return PathErrors{path: err}
+		}
+		changes = append(changes, change)
+	}
+	return nil
+}
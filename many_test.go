@@ -0,0 +1,91 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * GetMany
+ * -------
+ */
+
+func TestGetMany(t *testing.T) {
+	m := NewMapPath(defaultTest)
+	r, err := m.GetMany([]string{"hello", "foo/bar", "foo/baz/bam", "array/realints/1", "foo/baz"})
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, map[string]interface{}{
+		"hello":            "world",
+		"foo/bar":          "baz",
+		"foo/baz/bam":      42,
+		"array/realints/1": 2,
+		"foo/baz":          map[string]interface{}{"bam": 42},
+	}, r, "All values returned")
+}
+
+func TestGetManyErrors(t *testing.T) {
+	m := NewMapPath(defaultTest)
+	r, err := m.GetMany([]string{"hello", "foo/missing", "foo/bar/deeper", `bad\`})
+	assert.Equal(t, map[string]interface{}{"hello": "world"}, r, "Found values returned")
+	errs, ok := err.(PathErrors)
+	if assert.True(t, ok, "Path errors returned") {
+		assert.Equal(t, 3, len(errs), "Error per failed path")
+		assert.Equal(t, NotFoundError("foo/missing"), errs["foo/missing"], "Not found error of path")
+		assert.Equal(t, NotFoundError("foo/bar/deeper"), errs["foo/bar/deeper"], "Not found error below scalar")
+		_, ok = errs[`bad\`].(*PathError)
+		assert.True(t, ok, "Path error of malformed path")
+	}
+}
+
+func TestGetManyLookupPipeline(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{"a": 1})
+	m.Mount("sub", NewMapPath(map[string]interface{}{"b": 2}))
+	r, err := m.GetMany([]string{"a", "sub/b"})
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, map[string]interface{}{"a": 1, "sub/b": 2}, r, "Mounted values returned")
+}
+
+/*
+ * -------
+ * SetMany
+ * -------
+ */
+
+func TestSetMany(t *testing.T) {
+	m := NewMapPath(mutateTest(), WithHistory(0))
+	assert.Nil(t, m.SetMany(map[string]interface{}{
+		"foo":       "baz",
+		"map/baz":   2,
+		"new/sub/a": 1,
+		"new":       map[string]interface{}{"b": 2},
+		"ints/0":    9,
+	}), "All values set")
+	assert.Equal(t, "baz", m.StringV("foo"), "Value replaced")
+	assert.Equal(t, 2, m.IntV("map/baz"), "Nested value replaced")
+	assert.Equal(t, map[string]interface{}{"b": 2, "sub": map[string]interface{}{"a": 1}}, m.MapV("new"), "Parents set before sub paths")
+	assert.Equal(t, []int{9, 2, 3}, m.IntsV("ints"), "Array element replaced")
+
+	assert.Nil(t, m.Undo(), "Undo")
+	assert.Equal(t, mutateTest(), map[string]interface{}(m.Root()), "Single modification reverted")
+}
+
+func TestSetManyErrors(t *testing.T) {
+	m := NewMapPath(mutateTest())
+	err := m.SetMany(map[string]interface{}{
+		"foo":        "baz",
+		"ints/5":     1,
+		"ints/0":     "one",
+		"scalar/sub": 1,
+		"new/a":      1,
+	})
+	errs, ok := err.(PathErrors)
+	if assert.True(t, ok, "Path errors returned") {
+		assert.Equal(t, 3, len(errs), "Error per failed path")
+		assert.IsType(t, NotFoundError(""), errs["ints/5"], "Missing index")
+		assert.IsType(t, &InvalidTypeError{}, errs["ints/0"], "Incompatible element type")
+		assert.NotNil(t, errs["scalar/sub"], "Below scalar")
+	}
+	assert.Equal(t, mutateTest(), map[string]interface{}(m.Root()), "Nothing applied")
+	assert.Equal(t, ErrEmptyReadOnly, Empty().SetMany(map[string]interface{}{"a": 1}), "Empty is read only")
+}
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 
@@ -48,7 +49,18 @@ type Branch map[string]interface{}
 
 // MapPath is the primary object type this package is about
 type MapPath struct {
-	root Branch
+	root      Branch
+	config    *options
+	computed  *computedValues
+	computing []string
+	mounts    map[string]*MapPath
+	fallback  *MapPath
+	index     *pathIndex
+	provider  FallbackProvider
+	changes   *changeListeners
+	prefix    string
+	childs    *childCache
+	cacheMu   sync.Mutex
 }
 
 /*
@@ -64,6 +76,14 @@ func (err NotFoundError) Error() string {
 	return "The path \"" + string(err) + "\" does not exist"
 }
 
+// NullValueError is returned if a type getter (eg Int) is used on a path which exists, but
+// contains a null value
+type NullValueError string
+
+func (err NullValueError) Error() string {
+	return "The path \"" + string(err) + "\" is null"
+}
+
 // InvalidTypeError is returned if a type getter (eg GetInt) is used but the
 // found type cannot be converted
 type InvalidTypeError struct {
@@ -82,6 +102,19 @@ func (err UnsupportedTypeError) Error() string {
 	return fmt.Sprintf("Type %s is not supported", string(err))
 }
 
+// RangeError is returned if a value is found and converted, but lies outside
+// of the accepted bounds
+type RangeError struct {
+	Path  string
+	Value interface{}
+	Min   interface{}
+	Max   interface{}
+}
+
+func (err *RangeError) Error() string {
+	return fmt.Sprintf("The value %v of path \"%s\" is out of range [%v, %v]", err.Value, err.Path, err.Min, err.Max)
+}
+
 /*
  * ------
  * MapPath methods
@@ -89,19 +122,36 @@ func (err UnsupportedTypeError) Error() string {
  */
 
 // NewMapPath creates is the primary constructor
-func NewMapPath(root map[string]interface{}) *MapPath {
-	return &MapPath{root: root}
+func NewMapPath(root map[string]interface{}, opts ...Option) *MapPath {
+	m := &MapPath{root: root, config: newOptions(opts)}
+	if m.config.indexed {
+		m.index = &pathIndex{}
+	}
+	m.config.history.attach(root)
+	return m
 }
 
-// Root returns underly root map
+// Root returns underly root map, nil for Empty
 func (this *MapPath) Root() map[string]interface{} {
+	if this == nil || this == empty {
+		return nil
+	}
 	return this.root
 }
 
 // Get returns object found with given path
 func (this *MapPath) Get(path string, fallback ...interface{}) (interface{}, error) {
-	val, found := this.getBranch(strings.Split(path, "/"), this.root)
-	if found {
+	val, found, err := this.lookup(path)
+	if this.auditing() {
+		if !found && err == nil && len(fallback) == 0 {
+			this.audit(path, found, NotFoundError(path))
+		} else {
+			this.audit(path, found, err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	} else if found {
 		return val, nil
 	} else if len(fallback) > 0 {
 		return fallback[0], nil
@@ -109,6 +159,11 @@ func (this *MapPath) Get(path string, fallback ...interface{}) (interface{}, err
 	return nil, NotFoundError(path)
 }
 
+// GetAs returns the value of path converted to the given type. Strings and numbers are converted and
+// parsed, bools are converted like Bool, arrays of the element types supported by Array like Array,
+// while all other arrays, maps, structs and pointers are bound like Bind. Types with a registered
+// parser (eg time.Duration, see RegisterScalarParser) are converted by the parser. Null values of
+// strings and numbers result in a NullValueError.
 func (this *MapPath) GetAs(path string, typ reflect.Type, fallback ...interface{}) (interface{}, error) {
 	val, err := this.Get(path, fallback...)
 	if err != nil {
@@ -118,7 +173,15 @@ func (this *MapPath) GetAs(path string, typ reflect.Type, fallback ...interface{
 	valRef := reflect.ValueOf(val)
 	valKind := valRef.Kind()
 
-	switch {
+	switch parser := scalarParserOf(typ); {
+		case parser != "" && val != nil && reflect.TypeOf(val) == typ:
+			return val, nil
+		case parser != "" && val == nil:
+			return nil, NullValueError(path)
+		case parser != "":
+			return parseScalar(parser, val)
+		case val == nil && (isOfKind(kind, kindsString) || isOfKind(kind, kindsInt) || isOfKind(kind, kindsFloat)):
+			return nil, NullValueError(path)
 		case isOfKind(kind, kindsString):
 			switch {
 				case isOfKind(valKind, kindsString):
@@ -126,15 +189,15 @@ func (this *MapPath) GetAs(path string, typ reflect.Type, fallback ...interface{
 				case isOfKind(valKind, kindsInt):
 					return fmt.Sprintf("%d", val), nil
 				case isOfKind(valKind, kindsFloat):
-					return fmt.Sprintf("%f", val), nil
+					return this.opts().formatFloat(valRef.Float()), nil
 				default:
 					return fmt.Sprintf("%v", val), nil
 			}
 		case isOfKind(kind, kindsInt):
 			switch {
 				case isOfKind(valKind, kindsString):
-					p, err := strconv.Atoi(val.(string))
-					return p, err
+					p, err := this.opts().parseInt(val.(string))
+					return int(p), err
 				case isOfKind(valKind, kindsInt):
 					return valRef.Convert(typ).Interface(), nil
 				case isOfKind(valKind, kindsFloat):
@@ -145,7 +208,7 @@ func (this *MapPath) GetAs(path string, typ reflect.Type, fallback ...interface{
 		case isOfKind(kind, kindsFloat):
 			switch {
 				case isOfKind(valKind, kindsString):
-					p, err := strconv.ParseFloat(val.(string), 64)
+					p, err := this.opts().parseFloat(val.(string))
 					return p, err
 				case isOfKind(valKind, kindsInt):
 					return valRef.Convert(typ).Interface(), nil
@@ -154,17 +217,125 @@ func (this *MapPath) GetAs(path string, typ reflect.Type, fallback ...interface{
 				default:
 					return 0.0, &InvalidTypeError{val, "float64"}
 			}
+		case val != nil && reflect.TypeOf(val) == typ:
+			return val, nil
+		case kind == reflect.Interface:
+			return val, nil
+		case kind == reflect.Slice && arrayCoercers[typ.Elem()] != nil:
+			res, _, err := this.Array(typ.Elem(), path)
+			if err != nil {
+				if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+					return fallback[0], nil
+				}
+				return nil, err
+			}
+			return res, nil
+		case kind == reflect.Bool, kind == reflect.Map, kind == reflect.Slice, kind == reflect.Struct, kind == reflect.Ptr:
+			target := reflect.New(typ).Elem()
+			if err := this.bindValue(target, path); err != nil {
+				if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+					return fallback[0], nil
+				}
+				return nil, err
+			}
+			return target.Interface(), nil
 		default:
 			return nil, &InvalidTypeError{val, strings.ToLower(kind.String())}
 	}
 }
 
+// lookup returns the value of path, which is either a computed value, read from a mounted MapPath,
+// read from the structure or from the fallback MapPath
+func (this *MapPath) lookup(path string) (interface{}, bool, error) {
+	if this == nil {
+		return nil, false, nil
+	}
+	if this.computed != nil {
+		if val, found, err := this.computed.get(this, path); found {
+			return val, true, err
+		}
+	}
+	if sub, rest, ok := this.mountOf(path); ok {
+		if rest == "" {
+			return map[string]interface{}(sub.root), true, nil
+		}
+		return sub.lookup(rest)
+	}
+	var val interface{}
+	var found bool
+	if !strings.ContainsAny(path, `/\"`) {
+		// single segment paths, the most common case for flat structures, are a plain map access
+		if val, found = this.root[path]; !found && this.opts().keyCasing {
+			val, found = matchKeyCase(this.root, path)
+		}
+		return this.looked(path, val, found)
+	}
+	parts, err := splitPath(path)
+	if err != nil {
+		return nil, false, err
+	}
+	if this.index != nil {
+		val, found = this.index.get(this, parts)
+	}
+	if !found {
+		// misses of the index are verified, as keys can have multiple spellings (eg array index "01")
+		val, found = this.getBranch(parts, this.root)
+	}
+	return this.looked(path, val, found)
+}
+
+// looked applies fallback and access tracking to the lookup result of path
+func (this *MapPath) looked(path string, val interface{}, found bool) (interface{}, bool, error) {
+	if found && this.opts().emptyAsMissing && isEmptyValue(val, this.opts().emptyContainers) {
+		val, found = nil, false
+	}
+	if !found && this.fallback != nil {
+		val, found, err := this.fallback.lookup(path)
+		if found || err != nil || this.provider == nil {
+			return val, found, err
+		}
+	}
+	if !found && this.provider != nil {
+		val, found = this.provider(path)
+		return val, found, nil
+	} else if found && this.opts().tracker != nil && !isMap(val) && !isSlice(val) {
+		this.track(path)
+	}
+	return val, found, nil
+}
+
+// IsNull checks whether the given path exists and contains a null value
+func (this *MapPath) IsNull(path string) bool {
+	val, found, err := this.lookup(path)
+	if this.auditing() {
+		this.audit(path, found, err)
+	}
+	return found && val == nil
+}
+
 // Has check whether the given path exists
 func (this *MapPath) Has(path string) bool {
-	_, ok := this.getBranch(strings.Split(path, "/"), this.root)
+	_, ok, err := this.lookup(path)
+	if this.auditing() {
+		this.audit(path, ok, err)
+	}
 	return ok
 }
 
+// BoolTri returns the bool value of path and whether it is set, distinguishing explicitly disabled
+// (false, true) from not configured (false, false). Missing paths and null values are not set. If the
+// value cannot be converted (see Bool) then an error is returned.
+func (this *MapPath) BoolTri(path string) (value bool, set bool, err error) {
+	value, err = this.Bool(path)
+	switch err.(type) {
+		case nil:
+			return value, true, nil
+		case NotFoundError, NullValueError:
+			return false, false, nil
+	}
+	return false, false, err
+}
+
 // GetInt returns int value of path. If value cannot be parsed or converted then an InvalidTypeError is returned
 func (this *MapPath) Bool(path string, fallback ...bool) (bool, error) {
 	var val interface{}
@@ -176,6 +347,8 @@ func (this *MapPath) Bool(path string, fallback ...bool) (bool, error) {
 	}
 	if err != nil {
 		return false, err
+	} else if val == nil {
+		return false, NullValueError(path)
 	}
 	switch reflect.TypeOf(val).Kind() {
 
@@ -238,6 +411,8 @@ func (this *MapPath) Int(path string, fallback ...int) (int, error) {
 	}
 	if err != nil {
 		return 0, err
+	} else if val == nil {
+		return 0, NullValueError(path)
 	}
 
 	switch reflect.TypeOf(val).Kind() {
@@ -250,15 +425,15 @@ func (this *MapPath) Int(path string, fallback ...int) (int, error) {
 			}
 
 		case reflect.String:
-			r, err := strconv.Atoi(val.(string))
+			r, err := this.opts().parseInt(val.(string))
 			if err != nil {
-				r, ferr := strconv.ParseFloat(val.(string), 64)
+				r, ferr := this.opts().parseFloat(val.(string))
 				if ferr == nil {
 					return int(r), nil
 				}
 				return 0, err
 			}
-			return r, nil
+			return int(r), nil
 
 		case reflect.Int:
 			return val.(int), nil
@@ -294,6 +469,8 @@ func (this *MapPath) Float(path string, fallback ...float64) (float64, error) {
 	}
 	if err != nil {
 		return 0.0, err
+	} else if val == nil {
+		return 0.0, NullValueError(path)
 	}
 	switch reflect.TypeOf(val).Kind() {
 
@@ -306,7 +483,7 @@ func (this *MapPath) Float(path string, fallback ...float64) (float64, error) {
 			}
 
 		case reflect.String:
-			r, err := strconv.ParseFloat(val.(string), 64)
+			r, err := this.opts().parseFloat(val.(string))
 			if err != nil {
 				return 0.0, err
 			}
@@ -337,6 +514,9 @@ func (this *MapPath) FloatV(path string, fallback ...float64) float64 {
 
 // GetString returns string value of path. If value cannot be converted then an InvalidTypeError is returned
 func (this *MapPath) String(path string, fallback ...string) (string, error) {
+	if this == nil {
+		this = empty
+	}
 	var val interface{}
 	var err error
 	if len(fallback) > 0 {
@@ -346,6 +526,8 @@ func (this *MapPath) String(path string, fallback ...string) (string, error) {
 	}
 	if err != nil {
 		return "", err
+	} else if val == nil {
+		return "", NullValueError(path)
 	}
 	switch reflect.TypeOf(val).Kind() {
 
@@ -357,10 +539,13 @@ func (this *MapPath) String(path string, fallback ...string) (string, error) {
 			}
 
 		case reflect.String:
-			return val.(string), nil
+			if this.opts().trimStrings {
+				return this.opts().truncateString(path, trimString(val.(string))), nil
+			}
+			return this.opts().truncateString(path, val.(string)), nil
 
 		case reflect.Float64:
-			return fmt.Sprintf("%.9f", val.(float64)), nil
+			return this.opts().formatFloat(val.(float64)), nil
 
 		case reflect.Int:
 			return fmt.Sprintf("%d", val.(int)), nil
@@ -385,6 +570,15 @@ func (this *MapPath) StringV(path string, fallback ...string) string {
 
 // GetMap returns the map value of path. If value is not a map then an InvalidTypeError is returned
 func (this *MapPath) Map(path string, fallback ...map[string]interface{}) (map[string]interface{}, error) {
+	m, err := this.mapOf(path, fallback...)
+	if err == nil && this.opts().tracker != nil {
+		this.track(path)
+	}
+	return m, err
+}
+
+// mapOf returns map value of path, without counting as read access of the whole map
+func (this *MapPath) mapOf(path string, fallback ...map[string]interface{}) (map[string]interface{}, error) {
 	var val interface{}
 	var err error
 	if len(fallback) > 0 {
@@ -394,17 +588,12 @@ func (this *MapPath) Map(path string, fallback ...map[string]interface{}) (map[s
 	}
 	if err != nil {
 		return nil, err
+	} else if val == nil {
+		return nil, NullValueError(path)
 	}
 
-	switch val.(type) {
-		case map[string]interface{}:
-			return val.(map[string]interface{}), nil
-		case map[interface{}]interface{}:
-			m := make(map[string]interface{})
-			for k, v := range val.(map[interface{}]interface{}) {
-				m[k.(string)] = v
-			}
-			return m, nil
+	if m, ok := toStringMap(val); ok {
+		return m, nil
 	}
 
 	return nil, &InvalidTypeError{val, "map"}
@@ -426,7 +615,7 @@ func (this *MapPath) MapV(path string, fallback ...map[string]interface{}) map[s
 // GetSub return a new MapPath object representing the sub structure, which needs to be a map. If the sub structure
 // is of any other type then an InvalidTypeError is returned
 func (this *MapPath) Child(path string, fallback ...*MapPath) (*MapPath, error) {
-	branch, err := this.Map(path)
+	branch, err := this.mapOf(path)
 	if err != nil {
 		if _, notFound := err.(NotFoundError); notFound && len(fallback) > 0 {
 			return fallback[0], nil
@@ -434,14 +623,30 @@ func (this *MapPath) Child(path string, fallback ...*MapPath) (*MapPath, error)
 		return nil, err
 	}
 
-	return NewMapPath(branch), nil
+	child := this.child(branch)
+	if this.fallback != nil {
+		if fallback, err := this.fallback.Child(path); err == nil {
+			child.fallback = fallback
+		}
+	}
+	if this.provider != nil {
+		child.provider = this.provider.within(path)
+	}
+	child.prefix = this.subPrefix(path)
+	return child, nil
 }
 
 // GetMapV returns *MapPath value of path. If value cannot be parsed or converted then fallback or nil is returned. Handy in single value context.
+// Returns Empty instead of nil with WithEmptyChilds or when called on Empty.
 func (this *MapPath) ChildV(path string, fallback ...*MapPath) *MapPath {
+	if this == nil {
+		this = empty
+	}
 	if val, err := this.Child(path, fallback...); err != nil {
 		if len(fallback) > 0 {
 			return fallback[0]
+		} else if this == empty || this.opts().emptyChilds {
+			return empty
 		} else {
 			return nil
 		}
@@ -451,171 +656,49 @@ func (this *MapPath) ChildV(path string, fallback ...*MapPath) *MapPath {
 }
 
 // GetArray returns nested array of provided type. Fallback values are not supported.
-// If the path value is not an array then an InvalidTypeError is returned.
+// If the path value is not an array then an InvalidTypeError is returned. The supported element types
+// are int, int64, uint64, float64, bool, string, json.Number, time.Time and map[string]interface{}.
+// Empty arrays result in an empty slice of the type. See ArrayInto to avoid the type assertion.
 // You should use the specialized methods (GetInts, GetStrings..) unless you know what you are doing.
 func (this *MapPath) Array(refType reflect.Type, path string) (interface{}, bool, error) {
 	val, err := this.Get(path)
 	if err != nil {
 		return nil, false, err
+	} else if val == nil {
+		return nil, false, NullValueError(path)
 	} else if reflect.Slice != reflect.TypeOf(val).Kind() {
 		return nil, false, &InvalidTypeError{val, "array"}
+	} else if this.opts().tracker != nil && refType.Kind() != reflect.Map {
+		this.track(path)
 	}
 
-	refVal := reflect.ValueOf(val)
-	if refVal.Len() == 0 {
-		return nil, false, nil
-	}
-
-	var result interface{}
-	switch refType.Kind() {
-		case reflect.Int:
-			result = make([]int, refVal.Len())
-			break
-		case reflect.Float64:
-			result = make([]float64, refVal.Len())
-			break
-		case reflect.String:
-			result = make([]string, refVal.Len())
-			break
-		case reflect.Map:
-			result = make([]map[string]interface{}, refVal.Len())
-			break
-		default:
-			return nil, false, UnsupportedTypeError(refType.Kind().String()+ "@1")
+	coerce, ok := arrayCoercers[refType]
+	if !ok {
+		return nil, false, UnsupportedTypeError(refType.String())
 	}
-	refResult := reflect.ValueOf(result)
-
+	refVal := reflect.ValueOf(val)
+	result := reflect.MakeSlice(reflect.SliceOf(refType), refVal.Len(), refVal.Len())
 	for i := 0; i < refVal.Len(); i++ {
-		itemRef := refVal.Index(i)
-		if itemRef.Kind() == reflect.Interface {
-			itemRef = reflect.ValueOf(itemRef.Interface())
-		}
-		if refType.Kind() == itemRef.Kind() && refType.ConvertibleTo(itemRef.Type()) {
-			refResult.Index(i).Set(itemRef)
-		} else {
-
-			// must convert or parse item
-			switch refType.Kind() {
-
-				// expecting []int
-				case reflect.Int:
-					switch itemRef.Kind() {
-						case reflect.Bool:
-							if refVal.Index(i).Bool() {
-								refResult.Index(i).Set(reflect.ValueOf(1))
-							} else {
-								refResult.Index(i).Set(reflect.ValueOf(0))
-							}
-							break
-						case reflect.Float64:
-							refResult.Index(i).Set(itemRef.Convert(refType))
-							break
-						case reflect.String:
-							v, eint := strconv.Atoi(itemRef.String())
-							if eint != nil {
-								f, _ := strconv.ParseFloat(itemRef.String(), 64)
-								v = int(f)
-							}
-							refResult.Index(i).Set(reflect.ValueOf(v))
-							break
-						default:
-							return nil, false, &InvalidTypeError{itemRef.Interface(), fmt.Sprintf("[%d]array<%s>@2", i, refType.Kind())}
-					}
-					break
-
-					// expecting []float64
-				case reflect.Float64:
-					switch itemRef.Kind() {
-						case reflect.Bool:
-							if refVal.Index(i).Bool() {
-								refResult.Index(i).Set(reflect.ValueOf(1.0))
-							} else {
-								refResult.Index(i).Set(reflect.ValueOf(0.0))
-							}
-							break
-						case reflect.Int:
-							refResult.Index(i).Set(itemRef.Convert(refType))
-							break
-						case reflect.String:
-							v, _ := strconv.ParseFloat(itemRef.String(), 64)
-							refResult.Index(i).Set(reflect.ValueOf(v))
-							break
-						default:
-							return nil, false, &InvalidTypeError{itemRef.Interface(), fmt.Sprintf("[%d]array<%s>@3", i, refType.Kind())}
-						}
-					break
-
-					// expecting []string
-				case reflect.String:
-					switch itemRef.Kind() {
-						case reflect.Bool:
-							if itemRef.Bool() {
-								refResult.Index(i).Set(reflect.ValueOf("true"))
-							} else {
-								refResult.Index(i).Set(reflect.ValueOf("false"))
-							}
-						break
-						case reflect.Int:
-							refResult.Index(i).Set(reflect.ValueOf(fmt.Sprintf("%d", itemRef.Int())))
-							break
-						case reflect.Float64:
-							refResult.Index(i).Set(reflect.ValueOf(fmt.Sprintf("%.9f", itemRef.Float())))
-							break
-						case reflect.String:
-							refResult.Index(i).Set(itemRef)
-							break
-						case reflect.Interface:
-							s, ok := itemRef.Interface().(string)
-							if !ok {
-								return nil, false, &InvalidTypeError{itemRef.Interface(), fmt.Sprintf("[%d]array<%s>@4 - interface", i)}
-							}
-							refResult.Index(i).Set(reflect.ValueOf(s))
-							break
-						default:
-							return nil, false, &InvalidTypeError{itemRef.Interface(), fmt.Sprintf("[%d]array<%s>@5 - %v", i, refType.Kind())}
-					}
-					break
-
-					// expecting []map[string]interface{}
-				case reflect.Map:
-					var mapVal map[string]interface{}
-					var ok bool
-					if mapVal, ok = refVal.Index(i).Interface().(map[string]interface{}); !ok {
-						if mapValRaw, ok := refVal.Index(i).Interface().(map[interface{}]interface{}); !ok {
-							return nil, false, &InvalidTypeError{itemRef.Interface(), fmt.Sprintf("[%d]array<%s>@6", i, refType.Kind())}
-						} else {
-							mapVal = make(map[string]interface{})
-							for k, v := range mapValRaw {
-								mapVal[fmt.Sprintf("%s", k)] = v
-							}
-						}
-					}
-					if mapVal != nil {
-						refResult.Index(i).Set(reflect.ValueOf(mapVal))
-					}
-					break
-
-					// oops
-				default:
-					return nil, false, &InvalidTypeError{itemRef.Interface(), fmt.Sprintf("[%d]array<%s>@7", i, refType.Kind())}
-			}
+		item := refVal.Index(i).Interface()
+		converted, ok := coerce(item, this.opts())
+		if !ok {
+			return nil, false, &InvalidTypeError{item, fmt.Sprintf("[%d]array<%s>", i, refType)}
 		}
+		result.Index(i).Set(reflect.ValueOf(converted))
 	}
 
-	return result, true, nil
+	return result.Interface(), true, nil
 }
 
 // GetInts returns an array of int values. Tries to convert (eg float) or parse (string) values. If the
 // path value cannot be parsed or converted than an InvalidTypeError is returned.
 func (this *MapPath) Ints(path string, fallback ...[]int) ([]int, error) {
-	res, found, err := this.Array(reflect.TypeOf(int(0)), path)
+	res, _, err := this.Array(reflect.TypeOf(int(0)), path)
 	if err != nil {
 		if _, ok := err.(NotFoundError); len(fallback) > 0 && ok {
 			return fallback[0], nil
 		}
 		return nil, err
-	} else if !found {
-		return []int{}, nil
 	}
 	return res.([]int), nil
 }
@@ -636,14 +719,12 @@ func (this *MapPath) IntsV(path string, fallback ...[]int) []int {
 // GetFloats returns an array of float64 values. Tries to convert (eg int) or parse (string) values. If the
 // path value cannot be parsed or converted than an InvalidTypeError is returned.
 func (this *MapPath) Floats(path string, fallback ...[]float64) ([]float64, error) {
-	res, found, err := this.Array(reflect.TypeOf(float64(0.0)), path)
+	res, _, err := this.Array(reflect.TypeOf(float64(0.0)), path)
 	if err != nil {
 		if _, ok := err.(NotFoundError); len(fallback) > 0 && ok {
 			return fallback[0], nil
 		}
 		return nil, err
-	} else if !found {
-		return []float64{}, nil
 	}
 	return res.([]float64), nil
 }
@@ -664,16 +745,24 @@ func (this *MapPath) FloatsV(path string, fallback ...[]float64) []float64 {
 // GetStrings returns an array of string values. If the path value is incomaptible (eg map array) then an InvalidTypeError
 // is returned
 func (this *MapPath) Strings(path string, fallback ...[]string) ([]string, error) {
-	res, found, err := this.Array(reflect.TypeOf(string("")), path)
+	if this == nil {
+		this = empty
+	}
+	res, _, err := this.Array(reflect.TypeOf(string("")), path)
 	if err != nil {
 		if _, ok := err.(NotFoundError); len(fallback) > 0 && ok {
 			return fallback[0], nil
 		}
 		return nil, err
-	} else if !found {
-		return []string{}, nil
 	}
-	return res.([]string), nil
+	strs := res.([]string)
+	if this.opts().trimStrings {
+		for i := range strs {
+			strs[i] = trimString(strs[i])
+		}
+	}
+	this.opts().truncateStrings(path, strs)
+	return strs, nil
 }
 
 // GetStringsV returns []string value of path. If value cannot be parsed or converted then fallback or nil is returned. Handy in single value context.
@@ -691,14 +780,12 @@ func (this *MapPath) StringsV(path string, fallback ...[]string) []string {
 
 // GetMaps returns a nested array of maps. If the path value is not an array of maps then an InvalidTypeError is returned.
 func (this *MapPath) Maps(path string, fallback ...[]map[string]interface{}) ([]map[string]interface{}, error) {
-	res, found, err := this.Array(reflect.TypeOf(map[string]interface{}{}), path)
+	res, _, err := this.Array(reflect.TypeOf(map[string]interface{}{}), path)
 	if err != nil {
 		if _, ok := err.(NotFoundError); len(fallback) > 0 && ok {
 			return fallback[0], nil
 		}
 		return nil, err
-	} else if !found {
-		return []map[string]interface{}{}, nil
 	}
 	return res.([]map[string]interface{}), nil
 }
@@ -717,19 +804,20 @@ func (this *MapPath) MapsV(path string, fallback ...[]map[string]interface{}) []
 }
 
 // GetSubs returns a nested array of sub structures. If the path value is not an array of maps then an InvalidTypeError is returned.
+// The sub structures are cached, so repeated calls for the same array return the same instances. The cache is
+// dropped on modifications through this MapPath (eg Set).
 func (this *MapPath) Childs(path string, fallback ...[]*MapPath) ([]*MapPath, error) {
-	res, found, err := this.Array(reflect.TypeOf(map[string]interface{}{}), path)
+	res, _, err := this.Array(reflect.TypeOf(map[string]interface{}{}), path)
 	if err != nil {
 		if _, ok := err.(NotFoundError); len(fallback) > 0 && ok {
 			return fallback[0], nil
 		}
 		return nil, err
-	} else if !found {
-		return []*MapPath{}, nil
 	}
 	subs := make([]*MapPath, len(res.([]map[string]interface{})))
 	for i, m := range res.([]map[string]interface{}) {
-		subs[i] = &MapPath{m}
+		subs[i] = this.cachedChild(m)
+		subs[i].prefix = this.subPrefix(fmt.Sprintf("%s/%d", path, i))
 	}
 	return subs, nil
 }
@@ -750,6 +838,9 @@ func (this *MapPath) ChildsV(path string, fallback ...[]*MapPath) []*MapPath {
 func (this *MapPath) getBranch(pathParts []string, current map[string]interface{}) (interface{}, bool) {
 	name := pathParts[0]
 	val, ok := current[name]
+	if !ok && this.opts().keyCasing {
+		val, ok = matchKeyCase(current, name)
+	}
 	if !ok {
 		return nil, false
 	}
@@ -768,15 +859,15 @@ func (this *MapPath) getArray(pathParts []string, current reflect.Value) (interf
 
 func (this *MapPath) getNext(pathParts []string, val interface{}) (interface{}, bool) {
 	if len(pathParts) > 1 {
+		if val == nil {
+			return nil, false
+		}
 		t := reflect.TypeOf(val)
 		switch t.Kind() {
 		case reflect.Map:
-			m, ok := val.(map[string]interface{})
+			m, ok := toStringMap(val)
 			if !ok {
-				m = make(map[string]interface{})
-				for k, v := range val.(map[interface{}]interface{}) {
-					m[fmt.Sprintf("%s", k)] = v
-				}
+				return nil, false
 			}
 			return this.getBranch(pathParts[1:], m)
 		case reflect.Slice:
@@ -788,3 +879,32 @@ func (this *MapPath) getNext(pathParts []string, val interface{}) (interface{},
 		return val, true
 	}
 }
+
+// toStringMap returns the given value as map[string]interface{}, if it is a map with string, scalar
+// (eg int) or interface keys. Non-string keys are stringified.
+func toStringMap(val interface{}) (map[string]interface{}, bool) {
+	switch m := val.(type) {
+		case map[string]interface{}:
+			return m, true
+		case Branch:
+			return m, true
+		case nil:
+			return nil, false
+	}
+
+	ref := reflect.ValueOf(val)
+	if ref.Kind() != reflect.Map {
+		return nil, false
+	}
+	keyKind := ref.Type().Key().Kind()
+	if !isOfKind(keyKind, kindsString) && !isOfKind(keyKind, kindsInt) && !isOfKind(keyKind, kindsFloat) &&
+		keyKind != reflect.Bool && keyKind != reflect.Interface {
+		return nil, false
+	}
+	r := make(map[string]interface{}, ref.Len())
+	iter := ref.MapRange()
+	for iter.Next() {
+		r[fmt.Sprintf("%v", iter.Key().Interface())] = iter.Value().Interface()
+	}
+	return r, true
+}
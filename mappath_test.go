@@ -422,6 +422,32 @@ func TestGetBoolSingleContext(t *testing.T) {
 	}
 }
 
+func TestBoolTri(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"enabled":  true,
+		"disabled": "no",
+		"null":     nil,
+		"invalid":  "maybe",
+	})
+	for _, test := range []struct {
+		path  string
+		value bool
+		set   bool
+		err   bool
+	}{
+		{"enabled", true, true, false},
+		{"disabled", false, true, false},
+		{"missing", false, false, false},
+		{"null", false, false, false},
+		{"invalid", false, false, true},
+	} {
+		value, set, err := m.BoolTri(test.path)
+		assert.Equal(t, test.value, value, "Value of "+test.path)
+		assert.Equal(t, test.set, set, "Set of "+test.path)
+		assert.Equal(t, test.err, err != nil, "Error of "+test.path)
+	}
+}
+
 /*
  * -------
  * Get: Int
@@ -664,7 +690,7 @@ var getStringValueTests = []struct {
 	{
 		path:     "scalar/realfloat",
 		err:      false,
-		expected: "123.456000000",
+		expected: "123.456",
 	},
 	// from parsable int string
 	{
@@ -1318,7 +1344,7 @@ var getStringsValueTests = []struct {
 	{
 		path:     "array/realfloats",
 		err:      false,
-		expected: []string{"1.010000000", "2.020000000", "3.030000000", "4.040000000"},
+		expected: []string{"1.01", "2.02", "3.03", "4.04"},
 	},
 	// from array of ints
 	{
@@ -1762,6 +1788,91 @@ func TestGetUnsupportedArrayValueReturnsError(t *testing.T) {
 	assert.False(t, ok, "Not been found")
 }
 
+/*
+ * -------
+ * Null values
+ * -------
+ */
+
+var nullTest = map[string]interface{}{
+	"null":  nil,
+	"zero":  0,
+	"empty": "",
+	"list":  []interface{}{nil},
+}
+
+func TestIsNull(t *testing.T) {
+	m := NewMapPath(nullTest)
+	assert.True(t, m.IsNull("null"), "Null value detected")
+	assert.True(t, m.IsNull("list/0"), "Null array item detected")
+	assert.False(t, m.IsNull("zero"), "Zero is not null")
+	assert.False(t, m.IsNull("empty"), "Empty string is not null")
+	assert.False(t, m.IsNull("missing"), "Missing is not null")
+	assert.True(t, m.Has("null"), "Null value exists")
+}
+
+func TestNullValueErrorOnTypedGetters(t *testing.T) {
+	m := NewMapPath(nullTest)
+	getters := map[string]func(string) error{
+		"Bool":   func(p string) error { _, e := m.Bool(p); return e },
+		"Int":    func(p string) error { _, e := m.Int(p); return e },
+		"Float":  func(p string) error { _, e := m.Float(p); return e },
+		"String": func(p string) error { _, e := m.String(p); return e },
+		"Map":    func(p string) error { _, e := m.Map(p); return e },
+		"Child":  func(p string) error { _, e := m.Child(p); return e },
+		"Ints":   func(p string) error { _, e := m.Ints(p); return e },
+		"Floats": func(p string) error { _, e := m.Floats(p); return e },
+		"Maps":   func(p string) error { _, e := m.Maps(p); return e },
+		"Childs": func(p string) error { _, e := m.Childs(p); return e },
+	}
+	for name, getter := range getters {
+		assert.Equal(t, NullValueError("null"), getter("null"), name+" returns null value error")
+		_, notFound := getter("missing").(NotFoundError)
+		assert.True(t, notFound, name+" returns not found error")
+	}
+	assert.Equal(t, "", m.StringV("null"), "V getter returns nil value")
+	assert.Equal(t, "The path \"null\" is null", NullValueError("null").Error(), "Error correctly formatted")
+}
+
+/*
+ * -------
+ * Scalar keyed maps
+ * -------
+ */
+
+var scalarKeyedTest = map[string]interface{}{
+	"ints": map[int]interface{}{
+		1: "one",
+		2: map[int]string{
+			3: "three",
+		},
+	},
+	"bools": map[bool]int{
+		true: 1,
+	},
+	"list": []map[int]interface{}{
+		map[int]interface{}{
+			10: "ten",
+		},
+	},
+	"structs": map[struct{}]int{
+		struct{}{}: 1,
+	},
+}
+
+func TestGetScalarKeyedMaps(t *testing.T) {
+	m := NewMapPath(scalarKeyedTest)
+	assert.Equal(t, "one", m.StringV("ints/1"), "Int keyed map traversed")
+	assert.Equal(t, "three", m.StringV("ints/2/3"), "Nested int keyed map traversed")
+	assert.Equal(t, 1, m.IntV("bools/true"), "Bool keyed map traversed")
+	assert.Equal(t, map[string]interface{}{"3": "three"}, m.MapV("ints/2"), "Map keys stringified")
+	assert.Equal(t, "ten", m.ChildsV("list")[0].StringV("10"), "Int keyed maps in array")
+	assert.Equal(t, []map[string]interface{}{{"10": "ten"}}, m.MapsV("list"), "Int keyed maps converted")
+	assert.False(t, m.Has("structs/x"), "Non scalar keys are not traversed")
+	_, err := m.Map("structs")
+	assert.NotNil(t, err, "Non scalar keyed map cannot be returned")
+}
+
 /*
  * -------
  * Error
@@ -1825,3 +1936,74 @@ func TestUnsupportedType(t *testing.T) {
 		assert.Equal(t, err.Error(), test[1], "Error correctly formatted")
 	}
 }
+
+/*
+ * -------
+ * Get: GetAs
+ * -------
+ */
+
+type getAsTestStruct struct {
+	Bam int
+}
+
+var getAsTests = []struct {
+	path   string
+	typ    reflect.Type
+	expect interface{}
+}{
+	{"bool/stringyes2", reflect.TypeOf(true), true},
+	{"array/stringints", reflect.TypeOf([]int{}), []int{1, 2, 3, 4}},
+	{"array/empty", reflect.TypeOf([]string{}), []string{}},
+	{"array/stringbools", reflect.TypeOf([]bool{}), []bool{true, true, false, false}},
+	{"array/realints", reflect.TypeOf([]int64{}), []int64{1, 2, 3, 4}},
+	{"foo/baz", reflect.TypeOf(map[string]int{}), map[string]int{"bam": 42}},
+	{"foo/baz", reflect.TypeOf(getAsTestStruct{}), getAsTestStruct{42}},
+	{"foo/baz", reflect.TypeOf(&getAsTestStruct{}), &getAsTestStruct{42}},
+	{"foo/bar", reflect.TypeOf((*interface{})(nil)).Elem(), "baz"},
+}
+
+func TestGetAs(t *testing.T) {
+	m := NewMapPath(defaultTest)
+	for _, test := range getAsTests {
+		r, err := m.GetAs(test.path, test.typ)
+		assert.Nil(t, err, "No error returned for "+test.path)
+		assert.Equal(t, test.expect, r, "Expected value returned for "+test.path)
+	}
+}
+
+func TestGetAsErrors(t *testing.T) {
+	m := NewMapPath(defaultTest)
+	r, err := m.GetAs("missing", reflect.TypeOf(true), false)
+	assert.Nil(t, err, "No error with fallback")
+	assert.Equal(t, false, r, "Fallback returned")
+	r, err = m.GetAs("missing", reflect.TypeOf([]int{}), []int{1})
+	assert.Nil(t, err, "No error with array fallback")
+	assert.Equal(t, []int{1}, r, "Array fallback returned")
+
+	_, err = m.GetAs("hello", reflect.TypeOf(true))
+	assert.NotNil(t, err, "Error on unparsable bool")
+	_, err = m.GetAs("hello", reflect.TypeOf(getAsTestStruct{}))
+	_, ok := err.(*InvalidTypeError)
+	assert.True(t, ok, "Invalid type error for struct from string")
+	_, err = m.GetAs("foo/baz", reflect.TypeOf(make(chan int)))
+	assert.NotNil(t, err, "Error on unsupported type")
+
+	m = NewMapPath(map[string]interface{}{"null": nil})
+	for _, typ := range []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(0), reflect.TypeOf(int8(0)), reflect.TypeOf(0.0)} {
+		_, err = m.GetAs("null", typ)
+		assert.Equal(t, NullValueError("null"), err, "Null refused as "+typ.String())
+	}
+	var cfg struct{ Null string }
+	assert.Equal(t, NullValueError("null"), m.Bind(&cfg), "Null refused by Bind")
+}
+
+func TestGetAsFloatFormat(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{"float": 1.5, "big": 1e21})
+	r, _ := m.GetAs("float", reflect.TypeOf(""))
+	assert.Equal(t, "1.5", r, "Shortest float format by default")
+	r, _ = m.GetAs("big", reflect.TypeOf(""))
+	assert.Equal(t, m.StringV("big"), r, "Float formatted like String")
+	r, _ = m.With(WithFloatFormat('f', 2)).GetAs("float", reflect.TypeOf(""))
+	assert.Equal(t, "1.50", r, "Float format applied")
+}
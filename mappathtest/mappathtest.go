@@ -0,0 +1,61 @@
+// Package mappathtest provides helpers to build MapPath inputs of tests from fixture files.
+package mappathtest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"github.com/ukautz/mappath"
+)
+
+// Load returns the MapPath of the fixture file, which is executed as text/template with vars before it
+// is parsed according to its extension (see mappath.FromFile), eg:
+//
+//	m := mappathtest.Load(t, "fixture.json", map[string]interface{}{"Port": 8080})
+//
+// Relative files, which do not exist as given, are looked up in the testdata directory. Missing template
+// vars, unreadable or invalid fixtures fail the test. The rendered fixture is written to a temporary
+// file, which is removed when the test finishes.
+func Load(t testing.TB, file string, vars interface{}, opts ...mappath.Option) *mappath.MapPath {
+	t.Helper()
+	if _, err := os.Stat(file); os.IsNotExist(err) && !filepath.IsAbs(file) {
+		file = filepath.Join("testdata", file)
+	}
+	in, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Cannot read fixture \"%s\": %s", file, err)
+		return nil
+	}
+	tmpl, err := template.New(filepath.Base(file)).Option("missingkey=error").Parse(string(in))
+	if err != nil {
+		t.Fatalf("Cannot parse template of fixture \"%s\": %s", file, err)
+		return nil
+	}
+	buf := new(bytes.Buffer)
+	if err = tmpl.Execute(buf, vars); err != nil {
+		t.Fatalf("Cannot execute template of fixture \"%s\": %s", file, err)
+		return nil
+	}
+
+	dir, err := ioutil.TempDir("", "mappathtest")
+	if err != nil {
+		t.Fatalf("Cannot render fixture \"%s\": %s", file, err)
+		return nil
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	rendered := filepath.Join(dir, filepath.Base(file))
+	if err = ioutil.WriteFile(rendered, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("Cannot render fixture \"%s\": %s", file, err)
+		return nil
+	}
+	m, err := mappath.FromFile(rendered, opts...)
+	if err != nil {
+		t.Fatalf("Cannot load fixture \"%s\": %s", file, err)
+		return nil
+	}
+	return m
+}
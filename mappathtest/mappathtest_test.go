@@ -0,0 +1,75 @@
+package mappathtest
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+/*
+ * -------
+ * Load
+ * -------
+ */
+
+// recorder records the failures of Load instead of failing the test
+type recorder struct {
+	testing.TB
+	failures []string
+	cleanups []func()
+}
+
+func (this *recorder) Helper() {}
+
+func (this *recorder) Fatalf(format string, args ...interface{}) {
+	this.failures = append(this.failures, fmt.Sprintf(format, args...))
+}
+
+func (this *recorder) Cleanup(fn func()) {
+	this.cleanups = append(this.cleanups, fn)
+}
+
+var fixtureVars = map[string]interface{}{"Name": "test", "Port": 8080}
+
+func TestLoad(t *testing.T) {
+	m := Load(t, "fixture.json", fixtureVars)
+	assert.Equal(t, "test", m.StringV("name"), "String var substituted")
+	assert.Equal(t, 8080, m.IntV("server/port"), "Number var substituted")
+
+	m = Load(t, "testdata/fixture.json", fixtureVars)
+	assert.Equal(t, "test", m.StringV("name"), "Fixture loaded with path")
+}
+
+func TestLoadCleanup(t *testing.T) {
+	r := &recorder{TB: t}
+	assert.NotNil(t, Load(r, "fixture.json", fixtureVars), "Fixture loaded")
+	assert.Empty(t, r.failures, "No failures")
+	assert.Equal(t, 1, len(r.cleanups), "Cleanup registered")
+	r.cleanups[0]()
+}
+
+var loadFailureTests = []struct {
+	file     string
+	vars     interface{}
+	expected string
+}{
+	{"missing.json", fixtureVars, `Cannot read fixture "testdata/missing.json"`},
+	{"fixture.json", map[string]interface{}{"Name": "test"}, `Cannot execute template of fixture "testdata/fixture.json"`},
+	{"invalid.json", nil, `Cannot load fixture "testdata/invalid.json"`},
+}
+
+func TestLoadFailures(t *testing.T) {
+	for _, test := range loadFailureTests {
+		r := &recorder{TB: t}
+		assert.Nil(t, Load(r, test.file, test.vars), fmt.Sprintf("No MapPath of %s", test.file))
+		if assert.Equal(t, 1, len(r.failures), fmt.Sprintf("Failure of %s", test.file)) {
+			assert.Contains(t, r.failures[0], test.expected, fmt.Sprintf("Failure message of %s", test.file))
+		}
+		for _, cleanup := range r.cleanups {
+			cleanup()
+		}
+	}
+	_, err := os.Stat("testdata/missing.json")
+	assert.True(t, os.IsNotExist(err), "Missing fixture not created")
+}
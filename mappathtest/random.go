@@ -0,0 +1,84 @@
+package mappathtest
+
+import (
+	"math/rand"
+	"strconv"
+
+	"github.com/ukautz/mappath"
+)
+
+// keyRunes are the characters of random keys, including those which must be escaped in paths
+const keyRunes = `abcdefghijklmnopqrstuvwxyz0123456789-_. /\"`
+
+// RandomTree returns a random structure, which is nested up to depth levels below the root, with up to
+// breadth keys or elements in each map or array, and the paths of all its values, parents before their
+// children. The values are of the types decoded from JSON: maps, arrays, strings, float64, bools and
+// null. Keys may contain characters which need escaping in paths (eg "/"), the paths are escaped
+// accordingly. The same rng state results in the same structure, eg:
+//
+//	tree, paths := mappathtest.RandomTree(rand.New(rand.NewSource(seed)), 3, 4)
+func RandomTree(rng *rand.Rand, depth, breadth int) (map[string]interface{}, []string) {
+	if breadth < 1 {
+		breadth = 1
+	}
+	paths := []string{}
+	tree := randomMap(rng, depth, breadth, nil, &paths)
+	return tree, paths
+}
+
+// randomMap returns a map with 1 to breadth random keys and adds the paths of its values
+func randomMap(rng *rand.Rand, depth, breadth int, keys []string, paths *[]string) map[string]interface{} {
+	result := map[string]interface{}{}
+	for n := 1 + rng.Intn(breadth); len(result) < n; {
+		key := randomKey(rng)
+		if _, exists := result[key]; !exists {
+			result[key] = randomValue(rng, depth, breadth, append(append([]string{}, keys...), key), paths)
+		}
+	}
+	return result
+}
+
+// randomArray returns an array with 0 to breadth random elements and adds the paths of its values
+func randomArray(rng *rand.Rand, depth, breadth int, keys []string, paths *[]string) []interface{} {
+	result := make([]interface{}, rng.Intn(breadth+1))
+	for i := range result {
+		result[i] = randomValue(rng, depth, breadth, append(append([]string{}, keys...), strconv.Itoa(i)), paths)
+	}
+	return result
+}
+
+// randomValue returns a random value at the path of keys, containers only if depth allows
+func randomValue(rng *rand.Rand, depth, breadth int, keys []string, paths *[]string) interface{} {
+	segments := make([]mappath.Segment, len(keys))
+	for i, key := range keys {
+		segments[i] = mappath.Segment{Key: key}
+	}
+	*paths = append(*paths, mappath.FormatPath(segments))
+
+	kinds := 4
+	if depth > 0 {
+		kinds = 6
+	}
+	switch rng.Intn(kinds) {
+	case 0:
+		return randomKey(rng)
+	case 1:
+		return float64(rng.Intn(2000) - 1000)
+	case 2:
+		return rng.Intn(2) == 1
+	case 3:
+		return nil
+	case 4:
+		return randomMap(rng, depth-1, breadth, keys, paths)
+	}
+	return randomArray(rng, depth-1, breadth, keys, paths)
+}
+
+// randomKey returns a random string of 1 to 8 characters
+func randomKey(rng *rand.Rand) string {
+	key := make([]byte, 1+rng.Intn(8))
+	for i := range key {
+		key[i] = keyRunes[rng.Intn(len(keyRunes))]
+	}
+	return string(key)
+}
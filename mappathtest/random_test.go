@@ -0,0 +1,55 @@
+package mappathtest
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukautz/mappath"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+/*
+ * -------
+ * RandomTree
+ * -------
+ */
+
+func TestRandomTree(t *testing.T) {
+	for seed := int64(0); seed < 50; seed++ {
+		tree, paths := RandomTree(rand.New(rand.NewSource(seed)), 3, 4)
+		again, _ := RandomTree(rand.New(rand.NewSource(seed)), 3, 4)
+		assert.Equal(t, tree, again, fmt.Sprintf("Same tree of seed %d", seed))
+		assert.NotEmpty(t, tree, fmt.Sprintf("Keys in root of seed %d", seed))
+
+		m := mappath.NewMapPath(tree)
+		count := 0
+		assert.Nil(t, m.Walk(func(string, interface{}) error {
+			count++
+			return nil
+		}), "Walked")
+		assert.Equal(t, count, len(paths), fmt.Sprintf("Path of every value of seed %d", seed))
+		for _, path := range paths {
+			segments, err := mappath.ParsePath(path)
+			assert.Nil(t, err, "Path parses "+path)
+			assert.True(t, len(segments) <= 4, "Depth limited "+path)
+			assert.True(t, m.Has(path), "Path exists "+path)
+		}
+
+		doc, err := m.ToJson()
+		assert.Nil(t, err, "Encoded as JSON")
+		decoded, err := mappath.FromJson(doc)
+		assert.Nil(t, err, "Decoded from JSON")
+		assert.Equal(t, tree, decoded.Root(), fmt.Sprintf("JSON types of seed %d", seed))
+	}
+}
+
+func TestRandomTreeFlat(t *testing.T) {
+	tree, paths := RandomTree(rand.New(rand.NewSource(1)), 0, 0)
+	assert.Equal(t, 1, len(tree), "Breadth of at least one")
+	assert.Equal(t, 1, len(paths), "Single path")
+	for _, val := range tree {
+		kind := fmt.Sprintf("%T", val)
+		assert.False(t, strings.HasPrefix(kind, "map") || strings.HasPrefix(kind, "[]"), "No containers without depth")
+	}
+}
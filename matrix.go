@@ -0,0 +1,109 @@
+package mappath
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Tensor is a rectangular, n-dimensional array of float64 values. The values are
+// stored flat in row-major order.
+type Tensor struct {
+	Shape  []int
+	Values []float64
+}
+
+// At returns the value at the given indices, one per dimension. Returns false if
+// the amount of indices does not match the dimensions or any index is out of bounds.
+func (this *Tensor) At(idx ...int) (float64, bool) {
+	if len(idx) != len(this.Shape) {
+		return 0.0, false
+	}
+	offset := 0
+	for d, i := range idx {
+		if i < 0 || i >= this.Shape[d] {
+			return 0.0, false
+		}
+		offset = offset*this.Shape[d] + i
+	}
+	return this.Values[offset], true
+}
+
+// Tensor returns the nested array found at path as a Tensor with the given amount of
+// dimensions. All nested arrays on the same level must have the same length, otherwise
+// an error is returned. Values are converted (eg int) or parsed (string) into float64, like Floats does.
+func (this *MapPath) Tensor(path string, dims int) (*Tensor, error) {
+	if dims < 1 {
+		return nil, fmt.Errorf("Tensor of path \"%s\" needs at least one dimension", path)
+	}
+	val, err := this.Get(path)
+	if err != nil {
+		return nil, err
+	} else if val == nil {
+		return nil, NullValueError(path)
+	}
+
+	o := this.opts()
+	tensor := &Tensor{Shape: make([]int, dims), Values: []float64{}}
+	seen := make([]bool, dims)
+	var fill func(val interface{}, depth int) error
+	fill = func(val interface{}, depth int) error {
+		ref := reflect.ValueOf(val)
+		if val == nil || ref.Kind() != reflect.Slice {
+			return &InvalidTypeError{val, fmt.Sprintf("%dd array", dims-depth)}
+		}
+		if !seen[depth] {
+			seen[depth] = true
+			tensor.Shape[depth] = ref.Len()
+		} else if tensor.Shape[depth] != ref.Len() {
+			return fmt.Errorf("Array of path \"%s\" is not rectangular: expected length %d in dimension %d, got %d", path, tensor.Shape[depth], depth+1, ref.Len())
+		}
+		for i := 0; i < ref.Len(); i++ {
+			item := ref.Index(i).Interface()
+			if depth+1 < dims {
+				if err := fill(item, depth+1); err != nil {
+					return err
+				}
+			} else if f, ok := coerceFloat(item, o); !ok {
+				return &InvalidTypeError{item, "float64"}
+			} else {
+				tensor.Values = append(tensor.Values, f.(float64))
+			}
+		}
+		return nil
+	}
+	if err := fill(val, 0); err != nil {
+		return nil, err
+	}
+
+	return tensor, nil
+}
+
+// Floats2D returns a two dimensional, rectangular array of float64 values. Tries to convert (eg int)
+// or parse (string) values. If the path value is not a rectangular 2d array then an error is returned.
+func (this *MapPath) Floats2D(path string, fallback ...[][]float64) ([][]float64, error) {
+	tensor, err := this.Tensor(path, 2)
+	if err != nil {
+		if _, ok := err.(NotFoundError); len(fallback) > 0 && ok {
+			return fallback[0], nil
+		}
+		return nil, err
+	}
+	rows, cols := tensor.Shape[0], tensor.Shape[1]
+	result := make([][]float64, rows)
+	for i := 0; i < rows; i++ {
+		result[i] = tensor.Values[i*cols : (i+1)*cols : (i+1)*cols]
+	}
+	return result, nil
+}
+
+// Floats2DV returns [][]float64 value of path. If value cannot be parsed or converted then fallback or nil is returned. Handy in single value context.
+func (this *MapPath) Floats2DV(path string, fallback ...[][]float64) [][]float64 {
+	if val, err := this.Floats2D(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return nil
+	} else {
+		return val
+	}
+}
@@ -0,0 +1,81 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Get: Floats2D / Tensor
+ * -------
+ */
+
+var matrixTest = map[string]interface{}{
+	"matrix": []interface{}{
+		[]interface{}{1, 2.5, "3"},
+		[]float64{4, 5, 6},
+	},
+	"ragged": []interface{}{
+		[]int{1, 2},
+		[]int{3},
+	},
+	"flat":    []int{1, 2, 3},
+	"invalid": []interface{}{[]interface{}{"x"}},
+}
+
+func TestGetFloats2DValue(t *testing.T) {
+	m := NewMapPath(matrixTest)
+	r, e := m.Floats2D("matrix")
+	assert.Nil(t, e, "NO error returned")
+	assert.Equal(t, [][]float64{{1, 2.5, 3}, {4, 5, 6}}, r, "Expected value returned")
+	r[0] = append(r[0], 7)
+	assert.Equal(t, []float64{4, 5, 6}, r[1], "Appending to a row leaves the next row untouched")
+
+	for _, path := range []string{"ragged", "flat", "invalid", "missing"} {
+		r, e := m.Floats2D(path)
+		assert.NotNil(t, e, "Error returned for "+path)
+		assert.Nil(t, r, "Nil value returned for "+path)
+		assert.Nil(t, m.Floats2DV(path), "Nil single value returned for "+path)
+	}
+}
+
+func TestGetFloats2DOptions(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"literals": []interface{}{[]interface{}{"0x10", "1_000"}},
+	})
+	_, e := m.Floats2D("literals")
+	assert.NotNil(t, e, "Literals refused by default")
+	r, e := m.With(WithNumericLiterals()).Floats2D("literals")
+	assert.Nil(t, e, "No error with literals")
+	assert.Equal(t, [][]float64{{16, 1000}}, r, "Literals parsed")
+}
+
+func TestGetFloats2DFallback(t *testing.T) {
+	m := NewMapPath(matrixTest)
+	f := [][]float64{{1}}
+	r, e := m.Floats2D("missing", f)
+	assert.Nil(t, e, "No error when fallback used on invalid path")
+	assert.Equal(t, f, r, "Fallback is returned")
+}
+
+func TestGetTensorValue(t *testing.T) {
+	m := NewMapPath(defaultTest)
+	r, e := m.Tensor("3d-array", 3)
+	assert.Nil(t, e, "NO error returned")
+	assert.Equal(t, []int{2, 2, 3}, r.Shape, "Shape detected")
+	assert.Equal(t, []float64{1, 2, 3, 4, 5, 6, 11, 12, 13, 14, 15, 16}, r.Values, "Values flattened")
+
+	v, ok := r.At(1, 1, 2)
+	assert.True(t, ok, "Value found")
+	assert.Equal(t, 16.0, v, "Correct value returned")
+	_, ok = r.At(2, 0, 0)
+	assert.False(t, ok, "Out of bounds index")
+	_, ok = r.At(0, 0)
+	assert.False(t, ok, "Wrong amount of indices")
+
+	_, e = m.Tensor("3d-array", 4)
+	assert.NotNil(t, e, "Too many dimensions")
+	_, e = m.Tensor("3d-array", 0)
+	assert.NotNil(t, e, "Too few dimensions")
+}
@@ -0,0 +1,120 @@
+package mappath
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// MediaType is a parsed MIME type like "text/html; charset=utf-8", see MapPath.MediaType
+type MediaType struct {
+	// Type is the top level type in lower case, eg "text", or "*"
+	Type string
+	// Subtype in lower case, eg "html", or "*"
+	Subtype string
+	// Params are the parameters with lower case names, eg {"charset": "utf-8"}
+	Params map[string]string
+}
+
+// String returns the media type with its parameters, see mime.FormatMediaType
+func (this MediaType) String() string {
+	return mime.FormatMediaType(this.Type+"/"+this.Subtype, this.Params)
+}
+
+// Matches checks whether the media type (eg "image/png", parameters are ignored) is covered by this
+// one, which can contain wildcards, eg "image/*" or "*/*"
+func (this MediaType) Matches(mediaType string) bool {
+	other, err := parseMediaType(mediaType)
+	if err != nil {
+		return false
+	}
+	return (this.Type == "*" || this.Type == other.Type) && (this.Subtype == "*" || this.Subtype == other.Subtype)
+}
+
+// Extensions returns the file extensions (eg ".png") known for the media type, see mime.ExtensionsByType
+func (this MediaType) Extensions() []string {
+	exts, _ := mime.ExtensionsByType(this.Type + "/" + this.Subtype)
+	return exts
+}
+
+// MediaType returns the parsed MIME type of path, eg "application/json" or "text/plain; charset=utf-8".
+// Wildcards ("image/*", "*/*") are allowed. Invalid media types result in an InvalidTypeError.
+func (this *MapPath) MediaType(path string, fallback ...MediaType) (MediaType, error) {
+	val, err := this.Get(path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return MediaType{}, err
+	} else if val == nil {
+		return MediaType{}, NullValueError(path)
+	}
+	str, ok := val.(string)
+	if !ok {
+		return MediaType{}, &InvalidTypeError{val, "media type"}
+	}
+	mediaType, err := parseMediaType(str)
+	if err != nil {
+		return MediaType{}, &InvalidTypeError{val, "media type"}
+	}
+	return mediaType, nil
+}
+
+// MediaTypeV returns the parsed MIME type of path. If value cannot be parsed then fallback or the zero media type is returned. Handy in single value context.
+func (this *MapPath) MediaTypeV(path string, fallback ...MediaType) MediaType {
+	if val, err := this.MediaType(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return MediaType{}
+	} else {
+		return val
+	}
+}
+
+// MediaTypes returns the array of parsed MIME types of path, eg allowed upload types, see MediaType
+func (this *MapPath) MediaTypes(path string, fallback ...[]MediaType) ([]MediaType, error) {
+	val, err := this.arrayValue(path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return nil, err
+	}
+	result := make([]MediaType, val.Len())
+	for i := range result {
+		item := val.Index(i).Interface()
+		str, ok := item.(string)
+		if !ok {
+			return nil, &InvalidTypeError{item, fmt.Sprintf("[%d]array<media type>", i)}
+		} else if result[i], err = parseMediaType(str); err != nil {
+			return nil, &InvalidTypeError{item, fmt.Sprintf("[%d]array<media type>", i)}
+		}
+	}
+	return result, nil
+}
+
+// MediaTypesV returns the array of parsed MIME types of path. If value cannot be parsed then fallback or nil is returned. Handy in single value context.
+func (this *MapPath) MediaTypesV(path string, fallback ...[]MediaType) []MediaType {
+	if val, err := this.MediaTypes(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return nil
+	} else {
+		return val
+	}
+}
+
+// parseMediaType parses the media type, which must consist of type and subtype
+func parseMediaType(str string) (MediaType, error) {
+	full, params, err := mime.ParseMediaType(str)
+	if err != nil {
+		return MediaType{}, err
+	}
+	parts := strings.SplitN(full, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" || parts[0] == "*" && parts[1] != "*" {
+		return MediaType{}, fmt.Errorf("Invalid media type \"%s\"", str)
+	}
+	return MediaType{Type: parts[0], Subtype: parts[1], Params: params}, nil
+}
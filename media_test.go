@@ -0,0 +1,63 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Media types
+ * -------
+ */
+
+var mediaTypeTests = []struct {
+	val      interface{}
+	expected string
+}{
+	{"application/json", "application/json"},
+	{"Text/HTML; Charset=UTF-8", "text/html; charset=UTF-8"},
+	{"image/*", "image/*"},
+	{"*/*", "*/*"},
+	{"application/vnd.api+json", "application/vnd.api+json"},
+	{"json", ""},
+	{"text/", ""},
+	{"*/html", ""},
+	{"text/html; charset", ""},
+	{"", ""},
+	{42, ""},
+}
+
+func TestMediaType(t *testing.T) {
+	for _, test := range mediaTypeTests {
+		m := NewMapPath(map[string]interface{}{"type": test.val})
+		mediaType, err := m.MediaType("type")
+		assert.Equal(t, test.expected == "", err != nil, "Error of %v", test.val)
+		if err == nil {
+			assert.Equal(t, test.expected, mediaType.String(), "Media type of %v", test.val)
+		}
+	}
+
+	m := NewMapPath(map[string]interface{}{"type": "text/plain; charset=utf-8"})
+	assert.Equal(t, MediaType{Type: "text", Subtype: "plain", Params: map[string]string{"charset": "utf-8"}}, m.MediaTypeV("type"), "Parts of media type")
+	assert.Contains(t, MediaType{Type: "application", Subtype: "pdf"}.Extensions(), ".pdf", "Extensions of media type")
+	assert.Equal(t, MediaType{Type: "a", Subtype: "b"}, m.MediaTypeV("missing", MediaType{Type: "a", Subtype: "b"}), "Fallback used")
+}
+
+func TestMediaTypes(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"allowed": []interface{}{"image/*", "application/pdf"},
+		"invalid": []interface{}{"image/png", "png"},
+	})
+	allowed, err := m.MediaTypes("allowed")
+	assert.Nil(t, err, "No error on valid media types")
+	assert.Len(t, allowed, 2, "All media types parsed")
+	assert.True(t, allowed[0].Matches("image/png"), "Wildcard matches")
+	assert.True(t, allowed[1].Matches("Application/PDF; version=1.7"), "Exact type matches")
+	assert.False(t, allowed[0].Matches("text/plain"), "Other type does not match")
+	assert.False(t, allowed[1].Matches("garbage"), "Invalid type does not match")
+
+	_, err = m.MediaTypes("invalid")
+	assert.IsType(t, &InvalidTypeError{}, err, "Invalid element")
+	assert.Nil(t, m.MediaTypesV("missing"), "Nil on missing")
+}
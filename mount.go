@@ -0,0 +1,50 @@
+package mappath
+
+import (
+	"strings"
+)
+
+// Mount attaches the sub MapPath under prefix, without copying it. Lookups of prefix and any path
+// below (eg Get("prefix/foo")) are served by sub, shadowing values of the structure itself. If
+// multiple mounts match a path then the one with the longest prefix is used. Mounts are only
+// considered by lookups of this MapPath (and MapPaths derived with With), not by iterations (eg Walk)
+// or modifications (eg Set).
+func (this *MapPath) Mount(prefix string, sub *MapPath) {
+	if this == nil || this == empty {
+		return
+	}
+	if this.mounts == nil {
+		this.mounts = make(map[string]*MapPath)
+	}
+	this.mounts[strings.Trim(prefix, "/")] = sub
+}
+
+// Unmount removes the MapPath mounted under prefix. Returns false if nothing was mounted.
+func (this *MapPath) Unmount(prefix string) bool {
+	if this == nil {
+		this = empty
+	}
+	prefix = strings.Trim(prefix, "/")
+	if _, ok := this.mounts[prefix]; !ok {
+		return false
+	}
+	delete(this.mounts, prefix)
+	return true
+}
+
+// mountOf returns the MapPath mounted at the longest prefix of path and the remaining path
+func (this *MapPath) mountOf(path string) (*MapPath, string, bool) {
+	var sub *MapPath
+	var rest string
+	longest := -1
+	for prefix, m := range this.mounts {
+		if len(prefix) <= longest {
+			continue
+		} else if path == prefix {
+			sub, rest, longest = m, "", len(prefix)
+		} else if strings.HasPrefix(path, prefix+"/") {
+			sub, rest, longest = m, path[len(prefix)+1:], len(prefix)
+		}
+	}
+	return sub, rest, sub != nil
+}
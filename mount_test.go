@@ -0,0 +1,38 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Mount
+ * -------
+ */
+
+func TestMount(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"name":    "app",
+		"modules": map[string]interface{}{"db": "shadowed"},
+	})
+	db := NewMapPath(map[string]interface{}{"host": "localhost", "pool": map[string]interface{}{"size": 10}})
+	cache := NewMapPath(map[string]interface{}{"size": 99})
+	m.Mount("modules/db", db)
+	m.Mount("modules/db/pool", cache)
+
+	assert.Equal(t, "localhost", m.StringV("modules/db/host"), "Mounted value found")
+	assert.Equal(t, 99, m.IntV("modules/db/pool/size"), "Longest mount prefix wins")
+	assert.Equal(t, "localhost", m.ChildV("modules/db").StringV("host"), "Mount point as sub structure")
+	assert.Equal(t, "app", m.StringV("name"), "Own values found")
+	assert.False(t, m.Has("modules/db/missing"), "Missing mounted value")
+
+	assert.Nil(t, db.Set("host", "remote"), "Mounted MapPath modified")
+	assert.Equal(t, "remote", m.StringV("modules/db/host"), "Mount is not copied")
+
+	assert.True(t, m.Unmount("modules/db/pool"), "Unmounted")
+	assert.Equal(t, 10, m.IntV("modules/db/pool/size"), "Outer mount used after unmount")
+	assert.True(t, m.Unmount("/modules/db/"), "Unmounted with slashes")
+	assert.Equal(t, "shadowed", m.StringV("modules/db"), "Own value used after unmount")
+	assert.False(t, m.Unmount("modules/db"), "Nothing left to unmount")
+}
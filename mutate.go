@@ -0,0 +1,532 @@
+package mappath
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Set stores value at path. Missing map branches on the way are created. Array elements can be
+// replaced, but missing indices result in a NotFoundError. If the value cannot be stored in the
+// parent structure (eg a string in an []int) then an InvalidTypeError is returned.
+func (this *MapPath) Set(path string, value interface{}) error {
+	if this == nil || this == empty {
+		return ErrEmptyReadOnly
+	}
+	defer this.changed()
+	change, err := set(this.root, path, value)
+	if err != nil {
+		return err
+	}
+	this.record(change)
+	return nil
+}
+
+// set stores value at path of root, see Set, and returns the change to record
+func set(root Branch, path string, value interface{}) (Change, error) {
+	parts, err := splitPath(path)
+	if err != nil {
+		return Change{}, err
+	}
+	var current interface{} = root
+	created := ""
+	for i, part := range parts[:len(parts)-1] {
+		next, ok := childOf(current, part)
+		if !ok {
+			if current == nil {
+				// null on the way cannot be descended into, like scalars
+				return Change{}, &InvalidTypeError{current, "map or array"}
+			} else if reflect.TypeOf(current).Kind() != reflect.Map {
+				return Change{}, NotFoundError(formatKeys(parts[:i+1]))
+			}
+			next = map[string]interface{}{}
+			if err := assignChild(current, part, next); err != nil {
+				return Change{}, err
+			}
+			if created == "" {
+				created = formatKeys(parts[:i+1])
+			}
+		}
+		current = next
+	}
+	old, existed := childOf(current, parts[len(parts)-1])
+	if err := assignChild(current, parts[len(parts)-1], value); err != nil {
+		return Change{}, err
+	}
+	if created == "" && !existed {
+		created = formatKeys(parts)
+	}
+	return Change{Op: ChangeSet, Path: formatKeys(parts), Old: old, New: value, Existed: existed, created: created}, nil
+}
+
+// Append appends the values to the array at path. A missing (or null) array is created as []interface{},
+// like Set creates missing maps on the way. Values must be assignable to the element type of the array
+// (eg int for []int), otherwise an InvalidTypeError is returned and the array is left untouched.
+func (this *MapPath) Append(path string, values ...interface{}) error {
+	if this == nil || this == empty {
+		return ErrEmptyReadOnly
+	}
+	current, err := this.Get(path)
+	if _, ok := err.(NotFoundError); ok || err == nil && current == nil {
+		return this.Set(path, append([]interface{}{}, values...))
+	} else if err != nil {
+		return err
+	}
+	ref := reflect.ValueOf(current)
+	if ref.Kind() != reflect.Slice {
+		return &InvalidTypeError{current, "array"}
+	}
+	result := reflect.MakeSlice(ref.Type(), ref.Len(), ref.Len()+len(values))
+	reflect.Copy(result, ref)
+	for _, value := range values {
+		v, err := assignableValue(value, ref.Type().Elem())
+		if err != nil {
+			return err
+		}
+		result = reflect.Append(result, v)
+	}
+	return this.Set(path, result.Interface())
+}
+
+// InsertAt inserts value into the array at path before the element at index, so that value has the
+// index afterwards. An index equal to the length of the array appends. Returns a NotFoundError if the
+// path does not exist, an InvalidTypeError if it is no array or value does not fit the element type
+// of the array and a RangeError if index lies outside of [0, length].
+func (this *MapPath) InsertAt(path string, index int, value interface{}) error {
+	if this == nil || this == empty {
+		return ErrEmptyReadOnly
+	}
+	ref, err := this.arrayAt(path)
+	if err != nil {
+		return err
+	} else if index < 0 || index > ref.Len() {
+		return &RangeError{path, index, 0, ref.Len()}
+	}
+	v, err := assignableValue(value, ref.Type().Elem())
+	if err != nil {
+		return err
+	}
+	result := reflect.MakeSlice(ref.Type(), 0, ref.Len()+1)
+	result = reflect.AppendSlice(result, ref.Slice(0, index))
+	result = reflect.Append(result, v)
+	result = reflect.AppendSlice(result, ref.Slice(index, ref.Len()))
+	return this.Set(path, result.Interface())
+}
+
+// RemoveAt removes the element at index from the array at path, the following elements move up.
+// Returns a NotFoundError if the path or the element does not exist and an InvalidTypeError if the
+// path is no array.
+func (this *MapPath) RemoveAt(path string, index int) error {
+	if this == nil || this == empty {
+		return ErrEmptyReadOnly
+	}
+	ref, err := this.arrayAt(path)
+	if err != nil {
+		return err
+	} else if index < 0 || index >= ref.Len() {
+		return NotFoundError(fmt.Sprintf("%s/%d", strings.TrimSuffix(path, "/"), index))
+	}
+	return this.Set(path, spliceOut(ref.Interface(), strconv.Itoa(index)))
+}
+
+// arrayAt returns the array at path
+func (this *MapPath) arrayAt(path string) (reflect.Value, error) {
+	val, err := this.Get(path)
+	if err != nil {
+		return reflect.Value{}, err
+	} else if val == nil {
+		return reflect.Value{}, NullValueError(path)
+	} else if !isSlice(val) {
+		return reflect.Value{}, &InvalidTypeError{val, "array"}
+	}
+	return reflect.ValueOf(val), nil
+}
+
+// Delete removes the map key or array element at path. Array elements are spliced out, so that the
+// following elements move up. Returns a NotFoundError if the path does not exist.
+func (this *MapPath) Delete(path string) error {
+	return this.delete(path, false)
+}
+
+// DeleteAndPrune removes the map key or array element at path, like Delete, and afterwards all parent
+// maps and arrays (but the root), which became empty.
+func (this *MapPath) DeleteAndPrune(path string) error {
+	return this.delete(path, true)
+}
+
+func (this *MapPath) delete(path string, prune bool) error {
+	if this == nil || this == empty {
+		return ErrEmptyReadOnly
+	}
+	changes, err := remove(this.root, path, prune)
+	if len(changes) > 0 {
+		defer this.changed()
+		this.record(changes...)
+	}
+	return err
+}
+
+// remove removes the map key or array element at path of root, see Delete, and returns the changes to record
+func remove(root Branch, path string, prune bool) ([]Change, error) {
+	parts, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+	containers := []interface{}{root}
+	for _, part := range parts {
+		next, ok := childOf(containers[len(containers)-1], part)
+		if !ok {
+			return nil, NotFoundError(path)
+		}
+		containers = append(containers, next)
+	}
+
+	changes := []Change{}
+	for depth := len(parts) - 1; depth >= 0; depth-- {
+		container, key, old := containers[depth], parts[depth], containers[depth+1]
+		if isMap(container) {
+			removeChild(container, key)
+			changes = append(changes, Change{Op: ChangeDelete, Path: formatKeys(parts[:depth+1]), Old: old, Existed: true})
+		} else {
+			// the array is replaced with a shorter copy, which is recorded as modification of the array
+			spliced := spliceOut(container, key)
+			if err := assignChild(containers[depth-1], parts[depth-1], spliced); err != nil {
+				return changes, err
+			}
+			changes = append(changes, Change{Op: ChangeSet, Path: formatKeys(parts[:depth]), Old: container, New: spliced, Existed: true})
+			containers[depth] = spliced
+		}
+		if !prune || depth == 0 || reflect.ValueOf(containers[depth]).Len() > 0 {
+			break
+		}
+	}
+	return changes, nil
+}
+
+// spliceOut returns a copy of the array without the element at the index key
+func spliceOut(array interface{}, key string) interface{} {
+	ref := reflect.ValueOf(array)
+	idx, _ := strconv.Atoi(key)
+	result := reflect.MakeSlice(ref.Type(), 0, ref.Len()-1)
+	result = reflect.AppendSlice(result, ref.Slice(0, idx))
+	result = reflect.AppendSlice(result, ref.Slice(idx+1, ref.Len()))
+	return result.Interface()
+}
+
+// childOf returns the direct child with the given key (map) or index (array) of container
+func childOf(container interface{}, key string) (interface{}, bool) {
+	ref := reflect.ValueOf(container)
+	switch ref.Kind() {
+	case reflect.Map:
+		if k, ok := mapKey(ref, key); ok {
+			if v := ref.MapIndex(k); v.IsValid() {
+				return v.Interface(), true
+			}
+		}
+	case reflect.Slice:
+		if idx, err := strconv.Atoi(key); err == nil && idx >= 0 && idx < ref.Len() {
+			return ref.Index(idx).Interface(), true
+		}
+	}
+	return nil, false
+}
+
+// assignChild sets the direct child with the given key (map) or index (array) of container
+func assignChild(container interface{}, key string, value interface{}) error {
+	ref := reflect.ValueOf(container)
+	switch ref.Kind() {
+	case reflect.Map:
+		k, ok := mapKey(ref, key)
+		if !ok {
+			return &InvalidTypeError{key, ref.Type().Key().String()}
+		}
+		v, err := assignableValue(value, ref.Type().Elem())
+		if err != nil {
+			return err
+		}
+		ref.SetMapIndex(k, v)
+		return nil
+	case reflect.Slice:
+		idx, err := strconv.Atoi(key)
+		if err != nil || idx < 0 || idx >= ref.Len() {
+			return NotFoundError(key)
+		}
+		v, err := assignableValue(value, ref.Type().Elem())
+		if err != nil {
+			return err
+		}
+		ref.Index(idx).Set(v)
+		return nil
+	}
+	return &InvalidTypeError{container, "map or array"}
+}
+
+// mapKey returns the key value of the given map matching the string key. Existing keys of
+// non-string maps are matched by their string representation.
+func mapKey(m reflect.Value, key string) (reflect.Value, bool) {
+	keyType := m.Type().Key()
+	if keyType.Kind() == reflect.String {
+		return reflect.ValueOf(key).Convert(keyType), true
+	}
+	iter := m.MapRange()
+	for iter.Next() {
+		if fmt.Sprintf("%v", iter.Key().Interface()) == key {
+			return iter.Key(), true
+		}
+	}
+	switch {
+	case keyType.Kind() == reflect.Interface:
+		return reflect.ValueOf(key), true
+	case isOfKind(keyType.Kind(), kindsInt):
+		if i, err := strconv.ParseInt(key, 10, 64); err == nil {
+			return reflect.ValueOf(i).Convert(keyType), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// assignableValue returns value as reflect.Value, which can be assigned to typ
+func assignableValue(value interface{}, typ reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		switch typ.Kind() {
+		case reflect.Interface, reflect.Map, reflect.Slice, reflect.Ptr:
+			return reflect.Zero(typ), nil
+		}
+		return reflect.Value{}, &InvalidTypeError{value, typ.String()}
+	}
+	v := reflect.ValueOf(value)
+	if !v.Type().AssignableTo(typ) {
+		return reflect.Value{}, &InvalidTypeError{value, typ.String()}
+	}
+	return v, nil
+}
+
+// Apply calls fn for every leaf (any value which is neither map nor array) matching the glob expression
+// (see Paths) and replaces the leaf with the returned value. Stops on the first error, which is returned.
+// Leaves processed before the error keep their new values.
+func (this *MapPath) Apply(glob string, fn func(interface{}) (interface{}, error)) error {
+	if this == nil {
+		this = empty
+	}
+	leaves := map[string]interface{}{}
+	paths := []string{}
+	this.glob(glob, func(path string, val interface{}) {
+		if val != nil {
+			if kind := reflect.TypeOf(val).Kind(); kind == reflect.Map || kind == reflect.Slice {
+				return
+			}
+		}
+		leaves[path] = val
+		paths = append(paths, path)
+	})
+	for _, path := range paths {
+		val, err := fn(leaves[path])
+		if err != nil {
+			return err
+		} else if err := this.Set(path, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Move moves the value at src to dst, which is set like Set does, and removes src like Delete does, as a
+// single modification. Returns a NotFoundError if src does not exist and an error if dst lies within src.
+// Mind that moving array elements shifts the following elements.
+func (this *MapPath) Move(src, dst string) error {
+	return this.relocate(src, dst, true)
+}
+
+// Copy sets dst, like Set does, to a deep copy of the value at src, so that modifications of either do
+// not affect the other. Returns a NotFoundError if src does not exist.
+func (this *MapPath) Copy(src, dst string) error {
+	return this.relocate(src, dst, false)
+}
+
+func (this *MapPath) relocate(src, dst string, move bool) error {
+	if this == nil || this == empty {
+		return ErrEmptyReadOnly
+	}
+	srcKeys, err := splitPath(src)
+	if err != nil {
+		return err
+	}
+	dstKeys, err := splitPath(dst)
+	if err != nil {
+		return err
+	}
+	var val interface{} = this.root
+	for _, key := range srcKeys {
+		var ok bool
+		if val, ok = childOf(val, key); !ok {
+			return NotFoundError(src)
+		}
+	}
+	if move && hasKeyPrefix(dstKeys, srcKeys) {
+		return fmt.Errorf("Cannot move \"%s\" into itself at \"%s\"", src, dst)
+	} else if !move {
+		this.auditTree(src, val)
+		val = deepCopy(val)
+	}
+
+	defer this.changed()
+	changes := []Change{}
+	defer func() { this.record(changes...) }()
+	change, err := set(this.root, dst, val)
+	if err != nil {
+		return err
+	}
+	changes = append(changes, change)
+	if move && !hasKeyPrefix(srcKeys, dstKeys) {
+		// src is gone already, if it was within the replaced dst
+		removed, err := remove(this.root, src, false)
+		changes = append(changes, removed...)
+		return err
+	}
+	return nil
+}
+
+// hasKeyPrefix returns whether the path of keys equals or lies within the path of prefix
+func hasKeyPrefix(keys, prefix []string) bool {
+	if len(keys) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if keys[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Rename renames the last key of path to newKey, keeping the value, eg Rename("db/hostname", "host")
+// moves the value to "db/host". Returns a NotFoundError if path does not exist, an InvalidTypeError if
+// its parent is no map and an error if newKey already exists in the parent map.
+func (this *MapPath) Rename(path, newKey string) error {
+	if this == nil || this == empty {
+		return ErrEmptyReadOnly
+	}
+	parts, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	var parent interface{} = this.root
+	for _, part := range parts[:len(parts)-1] {
+		var ok bool
+		if parent, ok = childOf(parent, part); !ok {
+			return NotFoundError(path)
+		}
+	}
+	oldKey := parts[len(parts)-1]
+	val, ok := childOf(parent, oldKey)
+	if !ok {
+		return NotFoundError(path)
+	} else if !isMap(parent) {
+		return &InvalidTypeError{parent, "map"}
+	} else if oldKey == newKey {
+		return nil
+	} else if _, exists := childOf(parent, newKey); exists {
+		return fmt.Errorf("Cannot rename \"%s\" to existing key \"%s\"", path, newKey)
+	}
+
+	defer this.changed()
+	if err := assignChild(parent, newKey, val); err != nil {
+		return err
+	}
+	removeChild(parent, oldKey)
+	newPath := formatKeys(append(parts[:len(parts)-1:len(parts)-1], newKey))
+	this.record(
+		Change{Op: ChangeDelete, Path: formatKeys(parts), Old: val, Existed: true},
+		Change{Op: ChangeSet, Path: newPath, New: val, created: newPath})
+	return nil
+}
+
+// RenameKey renames the key oldKey to newKey in every map matching the glob expression (see Paths),
+// keeping the value. An empty glob addresses the root map. Maps without oldKey are skipped. If newKey
+// already exists in any of the maps then an error is returned before anything is renamed.
+func (this *MapPath) RenameKey(glob, oldKey, newKey string) error {
+	if this == nil {
+		this = empty
+	}
+	defer this.changed()
+	containers := []interface{}{}
+	paths := []string{}
+	if glob == "" {
+		containers = append(containers, this.root)
+		paths = append(paths, "")
+	} else {
+		this.glob(glob, func(path string, val interface{}) {
+			if val != nil && reflect.TypeOf(val).Kind() == reflect.Map {
+				containers = append(containers, val)
+				paths = append(paths, path+"/")
+			}
+		})
+	}
+
+	renames := []int{}
+	for i, container := range containers {
+		if _, ok := childOf(container, oldKey); !ok {
+			continue
+		} else if _, exists := childOf(container, newKey); exists {
+			return fmt.Errorf("Cannot rename \"%s\" to existing key \"%s\"", oldKey, newKey)
+		}
+		renames = append(renames, i)
+	}
+	changes := []Change{}
+	defer func() { this.record(changes...) }()
+	for _, i := range renames {
+		val, _ := childOf(containers[i], oldKey)
+		if err := assignChild(containers[i], newKey, val); err != nil {
+			return err
+		}
+		removeChild(containers[i], oldKey)
+		changes = append(changes,
+			Change{Op: ChangeDelete, Path: paths[i] + escapeKey(oldKey), Old: val, Existed: true},
+			Change{Op: ChangeSet, Path: paths[i] + escapeKey(newKey), New: val, created: paths[i] + escapeKey(newKey)})
+	}
+	return nil
+}
+
+// removeChild removes the key from the map container
+func removeChild(container interface{}, key string) {
+	ref := reflect.ValueOf(container)
+	if k, ok := mapKey(ref, key); ok {
+		ref.SetMapIndex(k, reflect.Value{})
+	}
+}
+
+// mergeInto deep merges the map src into the map dst: maps existing in both are merged recursively,
+// all other values of src (including arrays) replace the ones of dst
+func mergeInto(dst, src interface{}) error {
+	keys, values := childrenOf(src)
+	for i, key := range keys {
+		existing, ok := childOf(dst, key)
+		if ok && isMap(existing) && isMap(values[i]) {
+			if err := mergeInto(existing, values[i]); err != nil {
+				return err
+			}
+		} else if err := assignChild(dst, key, values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isMap(val interface{}) bool {
+	return val != nil && reflect.TypeOf(val).Kind() == reflect.Map
+}
+
+func isSlice(val interface{}) bool {
+	return val != nil && reflect.TypeOf(val).Kind() == reflect.Slice
+}
+
+// isEmptyValue returns whether val is an empty string or, with containers, an empty map or array
+func isEmptyValue(val interface{}, containers bool) bool {
+	if s, ok := val.(string); ok {
+		return s == ""
+	} else if containers && (isMap(val) || isSlice(val)) {
+		return reflect.ValueOf(val).Len() == 0
+	}
+	return false
+}
@@ -0,0 +1,333 @@
+package mappath
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * Set
+ * -------
+ */
+
+func mutateTest() map[string]interface{} {
+	return map[string]interface{}{
+		"foo": "bar",
+		"map": map[string]interface{}{
+			"baz": 1,
+		},
+		"ints":   []int{1, 2, 3},
+		"list":   []interface{}{map[string]interface{}{"name": "a"}},
+		"yaml":   map[interface{}]interface{}{"foo": "bar", 1: "one"},
+		"scalar": 123,
+	}
+}
+
+var setTests = []struct {
+	path  string
+	value interface{}
+}{
+	{"foo", "baz"},
+	{"new", 42},
+	{"map/baz", 2},
+	{"map/new/deep/er", true},
+	{"ints/1", 20},
+	{"list/0/name", "b"},
+	{"list/0/new", []string{"x"}},
+	{"yaml/foo", "baz"},
+	{"yaml/1", "uno"},
+	{"yaml/new/deep", "x"},
+}
+
+func TestSet(t *testing.T) {
+	for _, test := range setTests {
+		m := NewMapPath(mutateTest())
+		err := m.Set(test.path, test.value)
+		assert.Nil(t, err, "No error for "+test.path)
+		r, err := m.Get(test.path)
+		assert.Nil(t, err, "Value found at "+test.path)
+		assert.Equal(t, test.value, r, "Value stored at "+test.path)
+	}
+}
+
+func TestSetErrors(t *testing.T) {
+	m := NewMapPath(mutateTest())
+	_, ok := m.Set("ints/5", 1).(NotFoundError)
+	assert.True(t, ok, "Missing array index")
+	_, ok = m.Set("list/1/name", "x").(NotFoundError)
+	assert.True(t, ok, "Missing array index on the way")
+	_, ok = m.Set("ints/0", "foo").(*InvalidTypeError)
+	assert.True(t, ok, "Incompatible array element type")
+	_, ok = m.Set("scalar/foo", "x").(*InvalidTypeError)
+	assert.True(t, ok, "Cannot descend into scalar")
+	assert.Equal(t, 123, m.IntV("scalar"), "Scalar untouched")
+
+	m = NewMapPath(map[string]interface{}{"a": map[string]interface{}{"b": nil}})
+	_, ok = m.Set("a/b/c/d", 1).(*InvalidTypeError)
+	assert.True(t, ok, "Cannot descend into null")
+	_, ok = m.Set("a/b/c", 1).(*InvalidTypeError)
+	assert.True(t, ok, "Cannot assign into null")
+	assert.Equal(t, map[string]interface{}{"b": nil}, m.Root()["a"], "Null untouched")
+}
+
+/*
+ * -------
+ * Apply
+ * -------
+ */
+
+func TestApply(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"servers": []interface{}{
+			map[string]interface{}{"host": " WEB-1 ", "port": 80},
+			map[string]interface{}{"host": "DB-1\n", "port": 5432},
+		},
+		"names": []string{" a", "b "},
+		"title": " foo ",
+	})
+	trim := func(val interface{}) (interface{}, error) {
+		if s, ok := val.(string); ok {
+			return strings.ToLower(strings.TrimSpace(s)), nil
+		}
+		return val, nil
+	}
+	assert.Nil(t, m.Apply("servers/*/host", trim), "No error returned")
+	assert.Equal(t, "web-1", m.StringV("servers/0/host"), "First leaf rewritten")
+	assert.Equal(t, "db-1", m.StringV("servers/1/host"), "Second leaf rewritten")
+	assert.Equal(t, " foo ", m.StringV("title"), "Other leaves untouched")
+
+	assert.Nil(t, m.Apply("**", trim), "No error returned")
+	assert.Equal(t, []string{"a", "b"}, m.StringsV("names"), "Typed array elements rewritten")
+	assert.Equal(t, "foo", m.StringV("title"), "All leaves rewritten")
+	assert.Equal(t, 80, m.IntV("servers/0/port"), "Non string leaves kept")
+}
+
+func TestApplyErrors(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"ints": []int{1, 2},
+	})
+	err := m.Apply("ints/*", func(val interface{}) (interface{}, error) {
+		return "x", nil
+	})
+	_, ok := err.(*InvalidTypeError)
+	assert.True(t, ok, "Incompatible value returns error")
+
+	stop := errors.New("stop")
+	err = m.Apply("ints/*", func(val interface{}) (interface{}, error) {
+		return nil, stop
+	})
+	assert.Equal(t, stop, err, "Callback error returned")
+}
+
+/*
+ * -------
+ * RenameKey
+ * -------
+ */
+
+func TestRenameKey(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"web":  map[string]interface{}{"addr": "10.0.0.1"},
+		"db":   map[string]interface{}{"addr": "10.0.0.2", "port": 5432},
+		"yaml": map[interface{}]interface{}{"addr": "10.0.0.3"},
+		"none": map[string]interface{}{"port": 1},
+		"addr": "root",
+	})
+	assert.Nil(t, m.RenameKey("*", "addr", "address"), "No error returned")
+	assert.Equal(t, "10.0.0.1", m.StringV("web/address"), "Key renamed")
+	assert.Equal(t, "10.0.0.2", m.StringV("db/address"), "Key renamed")
+	assert.Equal(t, "10.0.0.3", m.StringV("yaml/address"), "Key renamed in interface keyed map")
+	assert.False(t, m.Has("web/addr"), "Old key removed")
+	assert.Equal(t, 5432, m.IntV("db/port"), "Other keys kept")
+	assert.False(t, m.Has("none/address"), "Maps without key skipped")
+	assert.Equal(t, "root", m.StringV("addr"), "Root untouched")
+
+	assert.Nil(t, m.RenameKey("", "addr", "address"), "No error on root rename")
+	assert.Equal(t, "root", m.StringV("address"), "Root key renamed")
+}
+
+func TestRenameKeyConflict(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"a": map[string]interface{}{"old": 1},
+		"b": map[string]interface{}{"old": 2, "new": 3},
+	})
+	assert.NotNil(t, m.RenameKey("*", "old", "new"), "Error on existing key")
+	assert.Equal(t, 1, m.IntV("a/old"), "Nothing renamed on conflict")
+	assert.Equal(t, 3, m.IntV("b/new"), "Existing key kept")
+}
+
+/*
+ * -------
+ * Append
+ * -------
+ */
+
+func TestAppend(t *testing.T) {
+	m := NewMapPath(mutateTest())
+	assert.Nil(t, m.Append("ints", 4, 5), "Values appended to typed array")
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, m.Root()["ints"], "Typed array extended")
+	assert.Nil(t, m.Append("list/0/tags", "a"), "Missing array created")
+	assert.Nil(t, m.Append("list/0/tags", "b", 3), "Values appended to created array")
+	assert.Equal(t, []interface{}{"a", "b", 3}, m.Root()["list"].([]interface{})[0].(map[string]interface{})["tags"], "Array created")
+	assert.Nil(t, m.Append("list", map[string]interface{}{"name": "b"}), "Map appended")
+	assert.Equal(t, "b", m.StringV("list/1/name"), "Appended map accessible")
+
+	assert.IsType(t, &InvalidTypeError{}, m.Append("ints", "six"), "Incompatible element type")
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, m.Root()["ints"], "Array untouched on error")
+	assert.IsType(t, &InvalidTypeError{}, m.Append("foo", 1), "No array")
+	assert.Equal(t, ErrEmptyReadOnly, Empty().Append("foo", 1), "Empty is read only")
+}
+
+/*
+ * -------
+ * InsertAt / RemoveAt
+ * -------
+ */
+
+func TestInsertAt(t *testing.T) {
+	m := NewMapPath(mutateTest())
+	assert.Nil(t, m.InsertAt("ints", 0, 0), "Inserted at start")
+	assert.Nil(t, m.InsertAt("ints", 2, 9), "Inserted in the middle")
+	assert.Nil(t, m.InsertAt("ints", 5, 4), "Inserted at end")
+	assert.Equal(t, []int{0, 1, 9, 2, 3, 4}, m.IntsV("ints"), "Elements inserted")
+
+	assert.Equal(t, &RangeError{"ints", 7, 0, 6}, m.InsertAt("ints", 7, 1), "Index after end")
+	assert.Equal(t, &RangeError{"ints", -1, 0, 6}, m.InsertAt("ints", -1, 1), "Negative index")
+	assert.IsType(t, &InvalidTypeError{}, m.InsertAt("ints", 0, "one"), "Incompatible element type")
+	assert.IsType(t, &InvalidTypeError{}, m.InsertAt("foo", 0, 1), "No array")
+	assert.Equal(t, NotFoundError("missing"), m.InsertAt("missing", 0, 1), "Missing path")
+	assert.Equal(t, ErrEmptyReadOnly, Empty().InsertAt("ints", 0, 1), "Empty is read only")
+}
+
+func TestRemoveAt(t *testing.T) {
+	m := NewMapPath(mutateTest())
+	assert.Nil(t, m.RemoveAt("ints", 1), "Element removed")
+	assert.Equal(t, []int{1, 3}, m.IntsV("ints"), "Following elements moved up")
+	assert.Nil(t, m.RemoveAt("ints", 1), "Last element removed")
+	assert.Equal(t, []int{1}, m.IntsV("ints"), "Array shortened")
+
+	assert.Equal(t, NotFoundError("ints/1"), m.RemoveAt("ints", 1), "Index after end")
+	assert.Equal(t, NotFoundError("ints/-1"), m.RemoveAt("ints", -1), "Negative index")
+	assert.IsType(t, &InvalidTypeError{}, m.RemoveAt("foo", 0), "No array")
+	assert.Equal(t, NotFoundError("missing"), m.RemoveAt("missing", 0), "Missing path")
+	assert.Equal(t, ErrEmptyReadOnly, Empty().RemoveAt("ints", 0), "Empty is read only")
+}
+
+/*
+ * -------
+ * Move / Copy
+ * -------
+ */
+
+func TestMove(t *testing.T) {
+	m := NewMapPath(mutateTest(), WithHistory(0))
+	assert.Nil(t, m.Move("map", "section/moved"), "Section moved")
+	assert.Equal(t, 1, m.IntV("section/moved/baz"), "Value at destination")
+	assert.False(t, m.Has("map"), "Source removed")
+	assert.Nil(t, m.Undo(), "Undo")
+	assert.Equal(t, mutateTest(), map[string]interface{}(m.Root()), "Move reverted as unit")
+
+	assert.Nil(t, m.Move("list/0/name", "list/0"), "Moved into parent")
+	assert.Equal(t, "a", m.StringV("list/0"), "Parent replaced")
+	assert.Nil(t, m.Move("ints/0", "first"), "Array element moved")
+	assert.Equal(t, []int{2, 3}, m.IntsV("ints"), "Element removed")
+	assert.Equal(t, 1, m.IntV("first"), "Element at destination")
+
+	assert.NotNil(t, m.Move("foo", "foo/sub"), "Move into itself")
+	assert.Equal(t, NotFoundError("missing"), m.Move("missing", "foo"), "Missing source")
+	assert.IsType(t, &InvalidTypeError{}, m.Move("foo", "ints/0"), "Incompatible destination")
+	assert.Equal(t, "bar", m.StringV("foo"), "Source kept on error")
+	assert.Equal(t, ErrEmptyReadOnly, Empty().Move("foo", "bar"), "Empty is read only")
+}
+
+func TestCopy(t *testing.T) {
+	m := NewMapPath(mutateTest())
+	assert.Nil(t, m.Copy("map", "copied"), "Section copied")
+	assert.Nil(t, m.Set("copied/baz", 2), "Copy modified")
+	assert.Equal(t, 1, m.IntV("map/baz"), "Source not aliased")
+	assert.Equal(t, 2, m.IntV("copied/baz"), "Copy modified")
+
+	assert.Nil(t, m.Copy("map", "map/self"), "Copied into itself")
+	assert.Equal(t, 1, m.IntV("map/self/baz"), "Copy within source")
+	assert.Equal(t, NotFoundError("missing"), m.Copy("missing", "foo"), "Missing source")
+	assert.Equal(t, ErrEmptyReadOnly, Empty().Copy("foo", "bar"), "Empty is read only")
+}
+
+/*
+ * -------
+ * Rename
+ * -------
+ */
+
+func TestRename(t *testing.T) {
+	m := NewMapPath(mutateTest(), WithHistory(0))
+	assert.Nil(t, m.Rename("map/baz", "bam"), "Key renamed")
+	assert.Equal(t, map[string]interface{}{"bam": 1}, m.MapV("map"), "Value kept under new key")
+	assert.Nil(t, m.Undo(), "Undo")
+	assert.Equal(t, mutateTest(), map[string]interface{}(m.Root()), "Rename reverted as unit")
+
+	assert.Nil(t, m.Rename("foo", "a/b"), "Root key renamed")
+	assert.Equal(t, "bar", m.StringV(`a\/b`), "Renamed to key with slash")
+	assert.Nil(t, m.Rename("yaml/1", "2"), "Key of non-string map renamed")
+	assert.Equal(t, "one", m.StringV("yaml/2"), "Value of non-string map kept")
+	assert.Nil(t, m.Rename("map/baz", "baz"), "Same key")
+
+	m.Set("map/other", 2)
+	assert.EqualError(t, m.Rename("map/baz", "other"), `Cannot rename "map/baz" to existing key "other"`, "Existing key")
+	assert.Equal(t, 1, m.IntV("map/baz"), "Value kept on error")
+	assert.Equal(t, NotFoundError("map/missing"), m.Rename("map/missing", "x"), "Missing key")
+	assert.Equal(t, NotFoundError("missing/baz"), m.Rename("missing/baz", "x"), "Missing parent")
+	assert.IsType(t, &InvalidTypeError{}, m.Rename("ints/0", "x"), "Array element")
+	assert.Equal(t, ErrEmptyReadOnly, Empty().Rename("foo", "bar"), "Empty is read only")
+}
+
+/*
+ * -------
+ * Delete
+ * -------
+ */
+
+func TestDelete(t *testing.T) {
+	m := NewMapPath(mutateTest())
+	assert.Nil(t, m.Delete("foo"), "Root key deleted")
+	assert.False(t, m.Has("foo"), "Root key removed")
+	assert.Nil(t, m.Delete("ints/1"), "Array element deleted")
+	assert.Equal(t, []int{1, 3}, m.Root()["ints"], "Array element spliced out")
+	assert.Nil(t, m.Delete("yaml/1"), "Key of interface keyed map deleted")
+	assert.Equal(t, map[interface{}]interface{}{"foo": "bar"}, m.Root()["yaml"], "Interface key removed")
+	assert.Nil(t, m.Delete("list/0/name"), "Key of map in array deleted")
+	assert.Equal(t, []interface{}{map[string]interface{}{}}, m.Root()["list"], "Empty map kept")
+
+	assert.IsType(t, NotFoundError(""), m.Delete("missing"), "Missing key")
+	assert.IsType(t, NotFoundError(""), m.Delete("ints/5"), "Missing index")
+	assert.IsType(t, NotFoundError(""), m.Delete("scalar/foo"), "Below scalar")
+	assert.Equal(t, ErrEmptyReadOnly, Empty().Delete("foo"), "Empty is read only")
+}
+
+func TestDeleteAndPrune(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"a":    map[string]interface{}{"b": map[string]interface{}{"c": 1}},
+		"list": []interface{}{map[string]interface{}{"name": "x"}},
+		"keep": map[string]interface{}{"x": 1, "y": map[string]interface{}{"z": 1}},
+	}, WithHistory(0), WithChangelog(0))
+	assert.Nil(t, m.DeleteAndPrune("a/b/c"), "Nested key deleted")
+	assert.False(t, m.Has("a"), "Empty parents pruned")
+	assert.Nil(t, m.DeleteAndPrune("list/0/name"), "Key in array deleted")
+	assert.False(t, m.Has("list"), "Empty array pruned")
+	assert.Nil(t, m.DeleteAndPrune("keep/y/z"), "Key deleted")
+	assert.Equal(t, map[string]interface{}{"x": 1}, m.Root()["keep"], "Non empty parent kept")
+	assert.Len(t, m.Changes(time.Time{}), 8, "Deletions recorded")
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, m.Undo(), "Deletion reverted")
+	}
+	assert.Equal(t, map[string]interface{}{
+		"a":    map[string]interface{}{"b": map[string]interface{}{"c": 1}},
+		"list": []interface{}{map[string]interface{}{"name": "x"}},
+		"keep": map[string]interface{}{"x": 1, "y": map[string]interface{}{"z": 1}},
+	}, m.Root(), "Pruned parents restored")
+}
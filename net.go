@@ -0,0 +1,77 @@
+package mappath
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// HostAndPort returns host and port of a service endpoint found at path. The value can either
+// be a "host:port" string or a map with "host" and "port" keys. The port must be within 1 and 65535.
+func (this *MapPath) HostAndPort(path string) (string, int, error) {
+	val, err := this.Get(path)
+	if err != nil {
+		return "", 0, err
+	} else if val == nil {
+		return "", 0, NullValueError(path)
+	}
+
+	var host string
+	var port int
+	switch v := val.(type) {
+	case string:
+		var portStr string
+		host, portStr, err = net.SplitHostPort(v)
+		if err != nil {
+			return "", 0, fmt.Errorf("Cannot parse \"%s\" of path \"%s\" as host:port: %s", v, path, err)
+		}
+		if port, err = strconv.Atoi(portStr); err != nil {
+			return "", 0, fmt.Errorf("Cannot parse port \"%s\" of path \"%s\"", portStr, path)
+		}
+	default:
+		child, err := this.Child(path)
+		if err != nil {
+			return "", 0, &InvalidTypeError{val, "host:port"}
+		}
+		if host, err = child.String("host"); err != nil {
+			return "", 0, err
+		}
+		if port, err = child.Int("port"); err != nil {
+			return "", 0, err
+		}
+	}
+
+	if host == "" {
+		return "", 0, fmt.Errorf("Missing host in endpoint of path \"%s\"", path)
+	} else if port < 1 || port > 65535 {
+		return "", 0, &RangeError{path, port, 1, 65535}
+	}
+
+	return host, port, nil
+}
+
+// HostPort returns the validated "host:port" string of a service endpoint found at path. See HostAndPort
+// for the accepted formats.
+func (this *MapPath) HostPort(path string, fallback ...string) (string, error) {
+	host, port, err := this.HostAndPort(path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return "", err
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port)), nil
+}
+
+// HostPortV returns "host:port" string of path. If value cannot be parsed or converted then fallback
+// or "" is returned. Handy in single value context.
+func (this *MapPath) HostPortV(path string, fallback ...string) string {
+	if val, err := this.HostPort(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return ""
+	} else {
+		return val
+	}
+}
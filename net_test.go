@@ -0,0 +1,98 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Get: HostPort
+ * -------
+ */
+
+var hostPortTest = map[string]interface{}{
+	"string":  "localhost:8080",
+	"ipv6":    "[::1]:443",
+	"noport":  "localhost",
+	"badport": "localhost:99999",
+	"map": map[string]interface{}{
+		"host": "example.com",
+		"port": 5432,
+	},
+	"map-string-port": map[string]interface{}{
+		"host": "example.com",
+		"port": "5432",
+	},
+	"map-nohost": map[string]interface{}{
+		"port": 5432,
+	},
+	"map-emptyhost": map[string]interface{}{
+		"host": "",
+		"port": 5432,
+	},
+	"number": 123,
+}
+
+var getHostPortTests = []struct {
+	path     string
+	err      bool
+	expected string
+}{
+	{"string", false, "localhost:8080"},
+	{"ipv6", false, "[::1]:443"},
+	{"map", false, "example.com:5432"},
+	{"map-string-port", false, "example.com:5432"},
+	{"noport", true, ""},
+	{"badport", true, ""},
+	{"map-nohost", true, ""},
+	{"map-emptyhost", true, ""},
+	{"number", true, ""},
+	{"missing", true, ""},
+}
+
+func TestGetHostPortValue(t *testing.T) {
+	m := NewMapPath(hostPortTest)
+	for _, test := range getHostPortTests {
+		r, e := m.HostPort(test.path)
+		if test.err {
+			assert.NotNil(t, e, "Error returned OK for "+test.path)
+		} else {
+			assert.Nil(t, e, "NO error returned for "+test.path)
+		}
+		assert.Equal(t, test.expected, r, "Expected value returned")
+		assert.Equal(t, test.expected, m.HostPortV(test.path), "Expected single value returned")
+	}
+}
+
+func TestGetHostAndPortValue(t *testing.T) {
+	m := NewMapPath(hostPortTest)
+	host, port, err := m.HostAndPort("map")
+	assert.Nil(t, err, "NO error returned")
+	assert.Equal(t, "example.com", host, "Host returned")
+	assert.Equal(t, 5432, port, "Port returned")
+
+	_, _, err = m.HostAndPort("badport")
+	_, ok := err.(*RangeError)
+	assert.True(t, ok, "Range error returned on invalid port")
+}
+
+func TestGetHostAndPortJson(t *testing.T) {
+	doc := []byte(`{"db": {"host": "example.com", "port": 5432}, "str": {"host": "example.com", "port": "5432"}}`)
+	for _, opts := range [][]Option{nil, {WithFloatFormat('f', 2)}, {WithFloatFormat('e', -1)}} {
+		m, _ := FromJson(doc, opts...)
+		for _, path := range []string{"db", "str"} {
+			host, port, err := m.HostAndPort(path)
+			assert.Nil(t, err, "No error returned for "+path)
+			assert.Equal(t, "example.com", host, "Host returned for "+path)
+			assert.Equal(t, 5432, port, "Port returned for "+path)
+		}
+	}
+}
+
+func TestGetHostPortFallback(t *testing.T) {
+	m := NewMapPath(hostPortTest)
+	r, e := m.HostPort("missing", "localhost:80")
+	assert.Nil(t, e, "No error when fallback used on invalid path")
+	assert.Equal(t, "localhost:80", r, "Fallback is returned")
+}
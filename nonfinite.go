@@ -0,0 +1,102 @@
+package mappath
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// ErrNonFinite is the cause of the *strconv.NumError returned by the float getters for "NaN" or "Inf"
+// strings, if they are refused with WithNonFiniteStrings(false)
+var ErrNonFinite = errors.New("non-finite value")
+
+// NonFiniteError is returned by ToJson for NaN or infinite floats, which JSON cannot represent, see
+// WithNonFiniteJson
+type NonFiniteError struct {
+	Path  string
+	Value float64
+}
+
+func (err *NonFiniteError) Error() string {
+	return fmt.Sprintf("The value %v of path \"%s\" cannot be encoded as JSON", err.Value, err.Path)
+}
+
+// NonFiniteMode determines how ToJson encodes NaN and infinite floats, see WithNonFiniteJson
+type NonFiniteMode int
+
+const (
+	// NonFiniteReject makes ToJson return a NonFiniteError, the default
+	NonFiniteReject NonFiniteMode = iota
+	// NonFiniteString encodes the floats as strings "NaN", "+Inf" and "-Inf", which the float getters parse
+	NonFiniteString
+	// NonFiniteNull encodes the floats as null
+	NonFiniteNull
+)
+
+// WithNonFiniteStrings sets whether the float getters (eg Float, Floats, Float32) accept the strings
+// "NaN", "Inf", "+Inf", "-Inf" and "Infinity" (in any case). They are accepted by default, as by
+// strconv.ParseFloat. Refused strings result in a *strconv.NumError with the cause ErrNonFinite.
+func WithNonFiniteStrings(accept bool) Option {
+	return func(o *options) {
+		o.refuseNonFinite = !accept
+	}
+}
+
+// WithNonFiniteJson sets how ToJson encodes NaN and infinite floats, which JSON cannot represent
+func WithNonFiniteJson(mode NonFiniteMode) Option {
+	return func(o *options) {
+		o.nonFiniteJson = mode
+	}
+}
+
+// checkFinite refuses non-finite floats parsed from s, see WithNonFiniteStrings
+func (this *options) checkFinite(s string, f float64, err error) (float64, error) {
+	if err == nil && this.refuseNonFinite && (math.IsNaN(f) || math.IsInf(f, 0)) {
+		return 0, &strconv.NumError{Func: "ParseFloat", Num: s, Err: ErrNonFinite}
+	}
+	return f, err
+}
+
+// encodableJson returns val with all non-finite floats replaced according to the mode, or a
+// NonFiniteError. Only maps and arrays containing replaced floats are copied.
+func encodableJson(val interface{}, path []string, mode NonFiniteMode) (interface{}, bool, error) {
+	if val == nil {
+		return nil, false, nil
+	}
+	ref := reflect.ValueOf(val)
+	switch ref.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f := ref.Float()
+		if !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return val, false, nil
+		} else if mode == NonFiniteString {
+			return strconv.FormatFloat(f, 'g', -1, 64), true, nil
+		} else if mode == NonFiniteNull {
+			return nil, true, nil
+		}
+		return nil, false, &NonFiniteError{formatKeys(path), f}
+	case reflect.Map, reflect.Slice:
+		keys, values := childrenOf(val)
+		changed := false
+		for i, key := range keys {
+			v, c, err := encodableJson(values[i], append(path, key), mode)
+			if err != nil {
+				return nil, false, err
+			}
+			values[i], changed = v, changed || c
+		}
+		if !changed {
+			return val, false, nil
+		} else if ref.Kind() == reflect.Slice {
+			return values, true, nil
+		}
+		result := make(map[string]interface{}, len(keys))
+		for i, key := range keys {
+			result[key] = values[i]
+		}
+		return result, true, nil
+	}
+	return val, false, nil
+}
@@ -0,0 +1,69 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"strconv"
+	"testing"
+)
+
+/*
+ * -------
+ * Non-finite floats
+ * -------
+ */
+
+func TestWithNonFiniteStrings(t *testing.T) {
+	data := map[string]interface{}{
+		"nan":    "NaN",
+		"inf":    "-Inf",
+		"finite": "1.5",
+		"floats": []interface{}{"1", "+Inf"},
+	}
+	m := NewMapPath(data)
+	assert.True(t, math.IsNaN(m.FloatV("nan")), "NaN accepted by default")
+	assert.True(t, math.IsInf(m.FloatV("inf"), -1), "Inf accepted by default")
+	assert.True(t, math.IsInf(m.FloatsV("floats")[1], 1), "Inf element accepted by default")
+
+	m = NewMapPath(data, WithNonFiniteStrings(false))
+	_, err := m.Float("nan")
+	if assert.IsType(t, &strconv.NumError{}, err, "NaN refused") {
+		assert.Equal(t, ErrNonFinite, err.(*strconv.NumError).Err, "Cause of refusal")
+	}
+	_, err = m.Float32("inf")
+	assert.NotNil(t, err, "Inf refused by Float32")
+	_, err = m.Floats("floats")
+	assert.IsType(t, &InvalidTypeError{}, err, "Inf element refused")
+	assert.Equal(t, 1.5, m.FloatV("finite"), "Finite values accepted")
+	_, err = NewMapPath(data, WithNonFiniteStrings(false), WithNumericLiterals()).Float("nan")
+	assert.NotNil(t, err, "NaN refused with literals")
+}
+
+func TestWithNonFiniteJson(t *testing.T) {
+	data := map[string]interface{}{
+		"ok": 1.5,
+		"nested": map[string]interface{}{
+			"list": []interface{}{1.0, math.Inf(-1)},
+			"nan":  math.NaN(),
+		},
+	}
+	_, err := NewMapPath(data).ToJson()
+	assert.Equal(t, &NonFiniteError{"nested/list/1", math.Inf(-1)}, err, "Refused by default")
+	assert.EqualError(t, err, `The value -Inf of path "nested/list/1" cannot be encoded as JSON`, "Error message")
+
+	m := NewMapPath(data, WithNonFiniteJson(NonFiniteString))
+	out, err := m.ToJson()
+	assert.Nil(t, err, "No error encoding as strings")
+	decoded, _ := FromJson(out)
+	assert.Equal(t, "-Inf", decoded.StringV("nested/list/1"), "Inf encoded as string")
+	assert.Equal(t, "NaN", decoded.StringV("nested/nan"), "NaN encoded as string")
+	assert.True(t, math.IsInf(decoded.FloatV("nested/list/1"), -1), "String parsed as float")
+	assert.Equal(t, 1.5, decoded.FloatV("ok"), "Finite values kept")
+	assert.True(t, math.IsNaN(m.FloatV("nested/nan")), "Structure untouched")
+
+	out, err = NewMapPath(data, WithNonFiniteJson(NonFiniteNull)).ToJson()
+	assert.Nil(t, err, "No error encoding as null")
+	decoded, _ = FromJson(out)
+	assert.True(t, decoded.IsNull("nested/list/1"), "Inf encoded as null")
+	assert.Equal(t, 1.0, decoded.FloatV("nested/list/0"), "Sibling kept")
+}
@@ -0,0 +1,527 @@
+package mappath
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// IntInRange returns int value of path, which must be within min and max (inclusive). If the
+// value is outside of the bounds or NaN then a RangeError is returned
+func (this *MapPath) IntInRange(path string, min, max int, fallback ...int) (int, error) {
+	val, err := this.Int(path, fallback...)
+	if err != nil {
+		return 0, err
+	} else if this.isNaN(path) {
+		return 0, &RangeError{path, math.NaN(), min, max}
+	} else if val < min || val > max {
+		return 0, &RangeError{path, val, min, max}
+	}
+	return val, nil
+}
+
+// IntInRangeV returns int value of path within min and max. If value cannot be parsed, converted
+// or is out of range then fallback or 0 is returned. Handy in single value context.
+func (this *MapPath) IntInRangeV(path string, min, max int, fallback ...int) int {
+	if val, err := this.IntInRange(path, min, max, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return 0
+	} else {
+		return val
+	}
+}
+
+// FloatInRange returns float64 value of path, which must be within min and max (inclusive). If
+// the value is outside of the bounds or NaN then a RangeError is returned
+func (this *MapPath) FloatInRange(path string, min, max float64, fallback ...float64) (float64, error) {
+	val, err := this.Float(path, fallback...)
+	if err != nil {
+		return 0.0, err
+	} else if math.IsNaN(val) || val < min || val > max {
+		return 0.0, &RangeError{path, val, min, max}
+	}
+	return val, nil
+}
+
+// isNaN checks whether the value of path is NaN, which converts to an arbitrary int
+func (this *MapPath) isNaN(path string) bool {
+	val, _, _ := this.lookup(path)
+	switch v := val.(type) {
+	case float64:
+		return math.IsNaN(v)
+	case float32:
+		return math.IsNaN(float64(v))
+	case string:
+		f, err := this.opts().parseFloat(v)
+		return err == nil && math.IsNaN(f)
+	}
+	return false
+}
+
+// FloatInRangeV returns float64 value of path within min and max. If value cannot be parsed,
+// converted or is out of range then fallback or 0.0 is returned. Handy in single value context.
+func (this *MapPath) FloatInRangeV(path string, min, max float64, fallback ...float64) float64 {
+	if val, err := this.FloatInRange(path, min, max, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return 0.0
+	} else {
+		return val
+	}
+}
+
+// Int8 returns int8 value of path. If value cannot be parsed or converted then an InvalidTypeError is returned,
+// if it does not fit into int8 then a RangeError is returned
+func (this *MapPath) Int8(path string, fallback ...int8) (int8, error) {
+	val, err := this.intBetween(path, math.MinInt8, math.MaxInt8)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return 0, err
+	}
+	return int8(val), nil
+}
+
+// Int8V returns int8 value of path. If value cannot be parsed, converted or does not fit then fallback or 0 is returned. Handy in single value context.
+func (this *MapPath) Int8V(path string, fallback ...int8) int8 {
+	if val, err := this.Int8(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return 0
+	} else {
+		return val
+	}
+}
+
+// Int16 returns int16 value of path. If value cannot be parsed or converted then an InvalidTypeError is returned,
+// if it does not fit into int16 then a RangeError is returned
+func (this *MapPath) Int16(path string, fallback ...int16) (int16, error) {
+	val, err := this.intBetween(path, math.MinInt16, math.MaxInt16)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return 0, err
+	}
+	return int16(val), nil
+}
+
+// Int16V returns int16 value of path. If value cannot be parsed, converted or does not fit then fallback or 0 is returned. Handy in single value context.
+func (this *MapPath) Int16V(path string, fallback ...int16) int16 {
+	if val, err := this.Int16(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return 0
+	} else {
+		return val
+	}
+}
+
+// Int32 returns int32 value of path. If value cannot be parsed or converted then an InvalidTypeError is returned,
+// if it does not fit into int32 then a RangeError is returned
+func (this *MapPath) Int32(path string, fallback ...int32) (int32, error) {
+	val, err := this.intBetween(path, math.MinInt32, math.MaxInt32)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return 0, err
+	}
+	return int32(val), nil
+}
+
+// Int32V returns int32 value of path. If value cannot be parsed, converted or does not fit then fallback or 0 is returned. Handy in single value context.
+func (this *MapPath) Int32V(path string, fallback ...int32) int32 {
+	if val, err := this.Int32(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return 0
+	} else {
+		return val
+	}
+}
+
+// Int64 returns int64 value of path. If value cannot be parsed or converted then an InvalidTypeError is returned,
+// if it does not fit into int64 then a RangeError is returned
+func (this *MapPath) Int64(path string, fallback ...int64) (int64, error) {
+	val, err := this.intBetween(path, math.MinInt64, math.MaxInt64)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return 0, err
+	}
+	return int64(val), nil
+}
+
+// Int64V returns int64 value of path. If value cannot be parsed, converted or does not fit then fallback or 0 is returned. Handy in single value context.
+func (this *MapPath) Int64V(path string, fallback ...int64) int64 {
+	if val, err := this.Int64(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return 0
+	} else {
+		return val
+	}
+}
+
+// Uint returns uint value of path. If value cannot be parsed or converted then an InvalidTypeError is returned,
+// if it does not fit into uint then a RangeError is returned
+func (this *MapPath) Uint(path string, fallback ...uint) (uint, error) {
+	val, err := this.uintBetween(path, 0, uint64(^uint(0)))
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return 0, err
+	}
+	return uint(val), nil
+}
+
+// UintV returns uint value of path. If value cannot be parsed, converted or does not fit then fallback or 0 is returned. Handy in single value context.
+func (this *MapPath) UintV(path string, fallback ...uint) uint {
+	if val, err := this.Uint(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return 0
+	} else {
+		return val
+	}
+}
+
+// Uint8 returns uint8 value of path. If value cannot be parsed or converted then an InvalidTypeError is returned,
+// if it does not fit into uint8 then a RangeError is returned
+func (this *MapPath) Uint8(path string, fallback ...uint8) (uint8, error) {
+	val, err := this.uintBetween(path, 0, math.MaxUint8)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return 0, err
+	}
+	return uint8(val), nil
+}
+
+// Uint8V returns uint8 value of path. If value cannot be parsed, converted or does not fit then fallback or 0 is returned. Handy in single value context.
+func (this *MapPath) Uint8V(path string, fallback ...uint8) uint8 {
+	if val, err := this.Uint8(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return 0
+	} else {
+		return val
+	}
+}
+
+// Uint16 returns uint16 value of path. If value cannot be parsed or converted then an InvalidTypeError is returned,
+// if it does not fit into uint16 then a RangeError is returned
+func (this *MapPath) Uint16(path string, fallback ...uint16) (uint16, error) {
+	val, err := this.uintBetween(path, 0, math.MaxUint16)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return 0, err
+	}
+	return uint16(val), nil
+}
+
+// Uint16V returns uint16 value of path. If value cannot be parsed, converted or does not fit then fallback or 0 is returned. Handy in single value context.
+func (this *MapPath) Uint16V(path string, fallback ...uint16) uint16 {
+	if val, err := this.Uint16(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return 0
+	} else {
+		return val
+	}
+}
+
+// Uint32 returns uint32 value of path. If value cannot be parsed or converted then an InvalidTypeError is returned,
+// if it does not fit into uint32 then a RangeError is returned
+func (this *MapPath) Uint32(path string, fallback ...uint32) (uint32, error) {
+	val, err := this.uintBetween(path, 0, math.MaxUint32)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return 0, err
+	}
+	return uint32(val), nil
+}
+
+// Uint32V returns uint32 value of path. If value cannot be parsed, converted or does not fit then fallback or 0 is returned. Handy in single value context.
+func (this *MapPath) Uint32V(path string, fallback ...uint32) uint32 {
+	if val, err := this.Uint32(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return 0
+	} else {
+		return val
+	}
+}
+
+// Uint64 returns uint64 value of path. If value cannot be parsed or converted then an InvalidTypeError is returned,
+// if it does not fit into uint64 then a RangeError is returned
+func (this *MapPath) Uint64(path string, fallback ...uint64) (uint64, error) {
+	val, err := this.uintBetween(path, 0, math.MaxUint64)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return 0, err
+	}
+	return uint64(val), nil
+}
+
+// Uint64V returns uint64 value of path. If value cannot be parsed, converted or does not fit then fallback or 0 is returned. Handy in single value context.
+func (this *MapPath) Uint64V(path string, fallback ...uint64) uint64 {
+	if val, err := this.Uint64(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return 0
+	} else {
+		return val
+	}
+}
+
+// Float32 returns float32 value of path. If value cannot be parsed or converted then an InvalidTypeError is
+// returned, if it exceeds the float32 range then a RangeError is returned
+func (this *MapPath) Float32(path string, fallback ...float32) (float32, error) {
+	val, err := this.Float(path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return 0.0, err
+	} else if math.Abs(val) > math.MaxFloat32 && !math.IsInf(val, 0) {
+		return 0.0, &RangeError{path, val, -math.MaxFloat32, math.MaxFloat32}
+	}
+	return float32(val), nil
+}
+
+// Float32V returns float32 value of path. If value cannot be parsed, converted or does not fit then fallback or 0.0 is returned. Handy in single value context.
+func (this *MapPath) Float32V(path string, fallback ...float32) float32 {
+	if val, err := this.Float32(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return 0.0
+	} else {
+		return val
+	}
+}
+
+// intBetween returns the exact integer value of path, which must be within min and max
+func (this *MapPath) intBetween(path string, min, max int64) (int64, error) {
+	val, err := this.Get(path)
+	if err != nil {
+		return 0, err
+	} else if val == nil {
+		return 0, NullValueError(path)
+	}
+	ref := reflect.ValueOf(val)
+	switch kind := ref.Kind(); {
+	case kind == reflect.Int, kind == reflect.Int8, kind == reflect.Int16, kind == reflect.Int32, kind == reflect.Int64:
+		if i := ref.Int(); i >= min && i <= max {
+			return i, nil
+		}
+	case isOfKind(kind, kindsInt):
+		if u := ref.Uint(); u <= math.MaxInt64 && int64(u) <= max {
+			return int64(u), nil
+		}
+	default:
+		if s, ok := val.(string); ok {
+			if i, err := this.opts().parseInt(s); err == nil {
+				if i >= min && i <= max {
+					return i, nil
+				}
+				return 0, &RangeError{path, val, min, max}
+			}
+		}
+		f, err := exactInteger(val, this.opts())
+		if err != nil {
+			return 0, err
+		} else if f >= float64(min) && f <= float64(max) && f < math.MaxInt64 {
+			return int64(f), nil
+		}
+	}
+	return 0, &RangeError{path, val, min, max}
+}
+
+// uintBetween returns the exact unsigned integer value of path, which must be within min and max
+func (this *MapPath) uintBetween(path string, min, max uint64) (uint64, error) {
+	val, err := this.Get(path)
+	if err != nil {
+		return 0, err
+	} else if val == nil {
+		return 0, NullValueError(path)
+	}
+	ref := reflect.ValueOf(val)
+	switch kind := ref.Kind(); {
+	case kind == reflect.Int, kind == reflect.Int8, kind == reflect.Int16, kind == reflect.Int32, kind == reflect.Int64:
+		if i := ref.Int(); i >= 0 && uint64(i) >= min && uint64(i) <= max {
+			return uint64(i), nil
+		}
+	case isOfKind(kind, kindsInt):
+		if u := ref.Uint(); u >= min && u <= max {
+			return u, nil
+		}
+	default:
+		if s, ok := val.(string); ok {
+			if u, err := this.opts().parseUint(s); err == nil {
+				if u >= min && u <= max {
+					return u, nil
+				}
+				return 0, &RangeError{path, val, min, max}
+			}
+		}
+		f, err := exactInteger(val, this.opts())
+		if err != nil {
+			return 0, err
+		} else if f >= float64(min) && f <= float64(max) && f < math.MaxUint64 {
+			return uint64(f), nil
+		}
+	}
+	return 0, &RangeError{path, val, min, max}
+}
+
+// exactInteger returns the float64 representation of a float, bool or string value, which must not
+// have a fractional part
+func exactInteger(val interface{}, o *options) (float64, error) {
+	var f float64
+	var err error
+	if s, ok := val.(string); ok {
+		f, err = o.parseFloat(s)
+	} else {
+		f, err = toFloat(val)
+	}
+	if err != nil {
+		return 0, &InvalidTypeError{val, "int"}
+	} else if f != math.Trunc(f) || math.IsNaN(f) {
+		return 0, &InvalidTypeError{val, "int"}
+	}
+	return f, nil
+}
+
+// toFloat converts a single scalar value into float64, by either converting numeric or bool values
+// or parsing strings
+func toFloat(val interface{}) (float64, error) {
+	if val == nil {
+		return 0.0, &InvalidTypeError{val, "float64"}
+	}
+	ref := reflect.ValueOf(val)
+	switch kind := ref.Kind(); {
+	case kind == reflect.Bool:
+		if ref.Bool() {
+			return 1.0, nil
+		}
+		return 0.0, nil
+	case kind == reflect.String:
+		return strconv.ParseFloat(ref.String(), 64)
+	case isOfKind(kind, kindsInt), isOfKind(kind, kindsFloat):
+		return ref.Convert(reflect.TypeOf(float64(0.0))).Float(), nil
+	}
+	return 0.0, &InvalidTypeError{val, "float64"}
+}
+
+// parseInt parses the decimal integer string, or any numeric literal with WithNumericLiterals
+func (this *options) parseInt(s string) (int64, error) {
+	if this.literals {
+		if i, ok := parseIntLiteral(s); ok {
+			return i, nil
+		}
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// parseUint parses the decimal unsigned integer string, or any numeric literal with WithNumericLiterals
+func (this *options) parseUint(s string) (uint64, error) {
+	if this.literals {
+		if hasBasePrefix(s) {
+			if u, err := strconv.ParseUint(s, 0, 64); err == nil {
+				return u, nil
+			}
+		} else if i, ok := parseIntLiteral(s); ok && i >= 0 {
+			return uint64(i), nil
+		}
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// parseFloat parses the float string, or any numeric literal with WithNumericLiterals. Non-finite
+// values are refused with WithNonFiniteStrings(false).
+func (this *options) parseFloat(s string) (float64, error) {
+	if this.literals {
+		if hasBasePrefix(s) {
+			if i, err := strconv.ParseInt(s, 0, 64); err == nil {
+				return float64(i), nil
+			}
+		} else if validUnderscores(s) {
+			if f, err := strconv.ParseFloat(strings.Replace(s, "_", "", -1), 64); err == nil {
+				return this.checkFinite(s, f, nil)
+			}
+		}
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	return this.checkFinite(s, f, err)
+}
+
+// parseIntLiteral parses integer literals like "1_000", "0x1F" or "1e6"
+func parseIntLiteral(s string) (int64, bool) {
+	if hasBasePrefix(s) {
+		i, err := strconv.ParseInt(s, 0, 64)
+		return i, err == nil
+	} else if !validUnderscores(s) {
+		return 0, false
+	}
+	s = strings.Replace(s, "_", "", -1)
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i, true
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// hasBasePrefix checks whether the (signed) number string starts with "0b", "0o" or "0x"
+func hasBasePrefix(s string) bool {
+	s = strings.TrimLeft(s, "+-")
+	if len(s) < 3 || s[0] != '0' {
+		return false
+	}
+	switch s[1] {
+	case 'b', 'B', 'o', 'O', 'x', 'X':
+		return true
+	}
+	return false
+}
+
+// validUnderscores checks whether all underscores of the number string are between two digits
+func validUnderscores(s string) bool {
+	isDigit := func(i int) bool {
+		return i >= 0 && i < len(s) && s[i] >= '0' && s[i] <= '9'
+	}
+	for i := range s {
+		if s[i] == '_' && (!isDigit(i-1) || !isDigit(i+1)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,261 @@
+package mappath
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+/*
+ * -------
+ * Get: IntInRange / FloatInRange
+ * -------
+ */
+
+var getIntInRangeTests = []struct {
+	path     string
+	min      int
+	max      int
+	err      bool
+	expected int
+}{
+	{
+		path:     "scalar/realint",
+		min:      1,
+		max:      65535,
+		err:      false,
+		expected: 123,
+	},
+	{
+		path:     "scalar/stringint",
+		min:      123,
+		max:      123,
+		err:      false,
+		expected: 123,
+	},
+	{
+		path:     "scalar/realint",
+		min:      0,
+		max:      100,
+		err:      true,
+		expected: 0,
+	},
+	{
+		path:     "scalar/realint",
+		min:      200,
+		max:      300,
+		err:      true,
+		expected: 0,
+	},
+}
+
+func TestGetIntInRangeValue(t *testing.T) {
+	m := NewMapPath(defaultTest)
+	for _, test := range getIntInRangeTests {
+		r, e := m.IntInRange(test.path, test.min, test.max)
+		if test.err {
+			assert.NotNil(t, e, "Error returned OK")
+			_, ok := e.(*RangeError)
+			assert.True(t, ok, "Correct error responded")
+		} else {
+			assert.Nil(t, e, "NO error returned")
+		}
+		assert.Equal(t, test.expected, r, "Expected value returned")
+		assert.Equal(t, test.expected, m.IntInRangeV(test.path, test.min, test.max), "Expected single value returned")
+	}
+}
+
+func TestGetIntInRangeFallback(t *testing.T) {
+	m := NewMapPath(defaultTest)
+	r, e := m.IntInRange("x/y/z", 1, 10, 5)
+	assert.Nil(t, e, "No error when fallback used on invalid path")
+	assert.Equal(t, 5, r, "Fallback is returned")
+	assert.Equal(t, 7, m.IntInRangeV("scalar/realint", 1, 10, 7), "Fallback is returned when out of range")
+}
+
+func TestGetFloatInRangeValue(t *testing.T) {
+	m := NewMapPath(defaultTest)
+	r, e := m.FloatInRange("scalar/realfloat", 0.0, 200.0)
+	assert.Nil(t, e, "NO error returned")
+	assert.Equal(t, 123.456, r, "Expected value returned")
+
+	r, e = m.FloatInRange("scalar/stringfloat", 0.0, 1.0)
+	assert.NotNil(t, e, "Error returned")
+	assert.Equal(t, 0.0, r, "Nil value returned")
+	assert.Equal(t, "The value 123.456 of path \"scalar/stringfloat\" is out of range [0, 1]", e.Error(), "Error correctly formatted")
+	assert.Equal(t, 0.5, m.FloatInRangeV("scalar/stringfloat", 0.0, 1.0, 0.5), "Fallback is returned when out of range")
+}
+
+func TestGetInRangeNaN(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{"nan": math.NaN(), "string": "NaN"})
+	for _, path := range []string{"nan", "string"} {
+		_, e := m.FloatInRange(path, 0.0, 1.0)
+		_, ok := e.(*RangeError)
+		assert.True(t, ok, "RangeError returned for NaN float of "+path)
+		_, e = m.IntInRange(path, 0, 1)
+		_, ok = e.(*RangeError)
+		assert.True(t, ok, "RangeError returned for NaN int of "+path)
+	}
+}
+
+/*
+ * -------
+ * Get: numeric widths
+ * -------
+ */
+
+var widthTest = map[string]interface{}{
+	"small":     100,
+	"large":     70000,
+	"negative":  -1,
+	"float":     12.0,
+	"fraction":  12.5,
+	"string":    "-128",
+	"bigstring": "18446744073709551615",
+	"uint64":    uint64(18446744073709551615),
+	"int64":     int64(-9223372036854775808),
+	"hugefloat": 1e300,
+	"text":      "foo",
+	"bool":      true,
+}
+
+func TestGetIntWidths(t *testing.T) {
+	m := NewMapPath(widthTest)
+
+	i8, err := m.Int8("small")
+	assert.Nil(t, err, "Int8 fits")
+	assert.Equal(t, int8(100), i8, "Int8 value returned")
+	i8, err = m.Int8("string")
+	assert.Nil(t, err, "Int8 parsed from string")
+	assert.Equal(t, int8(-128), i8, "Int8 minimum parsed")
+	_, err = m.Int8("large")
+	_, isRange := err.(*RangeError)
+	assert.True(t, isRange, "Int8 overflow returns range error")
+
+	i16, err := m.Int16("float")
+	assert.Nil(t, err, "Int16 converted from integral float")
+	assert.Equal(t, int16(12), i16, "Int16 value returned")
+	_, err = m.Int16("fraction")
+	_, isType := err.(*InvalidTypeError)
+	assert.True(t, isType, "Fractions are not truncated")
+	_, err = m.Int16("text")
+	_, isType = err.(*InvalidTypeError)
+	assert.True(t, isType, "Unparsable string")
+
+	i32, err := m.Int32("large")
+	assert.Nil(t, err, "Int32 fits")
+	assert.Equal(t, int32(70000), i32, "Int32 value returned")
+	_, err = m.Int32("hugefloat")
+	_, isRange = err.(*RangeError)
+	assert.True(t, isRange, "Int32 overflow from float")
+	_, err = m.Int32("uint64")
+	_, isRange = err.(*RangeError)
+	assert.True(t, isRange, "Int32 overflow from uint64")
+
+	i64, err := m.Int64("int64")
+	assert.Nil(t, err, "Int64 fits")
+	assert.Equal(t, int64(-9223372036854775808), i64, "Int64 minimum returned")
+	assert.Equal(t, int64(1), m.Int64V("bool"), "Int64 converted from bool")
+}
+
+func TestGetUintWidths(t *testing.T) {
+	m := NewMapPath(widthTest)
+
+	u, err := m.Uint("small")
+	assert.Nil(t, err, "Uint fits")
+	assert.Equal(t, uint(100), u, "Uint value returned")
+	_, err = m.Uint("negative")
+	_, isRange := err.(*RangeError)
+	assert.True(t, isRange, "Negative values do not fit")
+
+	u64, err := m.Uint64("bigstring")
+	assert.Nil(t, err, "Uint64 parsed from string")
+	assert.Equal(t, uint64(18446744073709551615), u64, "Uint64 maximum parsed")
+	assert.Equal(t, uint64(18446744073709551615), m.Uint64V("uint64"), "Uint64 maximum read")
+
+	_, err = m.Uint8("large")
+	_, isRange = err.(*RangeError)
+	assert.True(t, isRange, "Uint8 overflow")
+	_, err = m.Uint16("bigstring")
+	_, isRange = err.(*RangeError)
+	assert.True(t, isRange, "Uint16 overflow from string")
+	assert.Equal(t, uint32(70000), m.Uint32V("large"), "Uint32 fits")
+}
+
+func TestGetWidthFallbacks(t *testing.T) {
+	m := NewMapPath(widthTest)
+	r, err := m.Int8("missing", 5)
+	assert.Nil(t, err, "No error when fallback used on invalid path")
+	assert.Equal(t, int8(5), r, "Fallback is returned")
+	assert.Equal(t, int8(7), m.Int8V("large", 7), "Fallback is returned on overflow")
+	assert.Equal(t, uint(0), m.UintV("negative"), "Nil value is returned on overflow")
+	_, err = m.Int8("missing")
+	_, notFound := err.(NotFoundError)
+	assert.True(t, notFound, "Not found error without fallback")
+}
+
+func TestGetFloat32(t *testing.T) {
+	m := NewMapPath(widthTest)
+	f, err := m.Float32("fraction")
+	assert.Nil(t, err, "Float32 fits")
+	assert.Equal(t, float32(12.5), f, "Float32 value returned")
+	_, err = m.Float32("hugefloat")
+	_, isRange := err.(*RangeError)
+	assert.True(t, isRange, "Float32 overflow")
+	assert.Equal(t, float32(1.5), m.Float32V("hugefloat", 1.5), "Fallback returned on overflow")
+}
+
+/*
+ * -------
+ * Numeric literals
+ * -------
+ */
+
+var numericLiteralTests = []struct {
+	val   string
+	i     int64
+	f     float64
+	valid bool
+}{
+	{"1_000_000", 1000000, 1000000, true},
+	{"1e6", 1000000, 1000000, true},
+	{"0x1F", 31, 31, true},
+	{"-0x1f", -31, -31, true},
+	{"0o17", 15, 15, true},
+	{"0b101", 5, 5, true},
+	{"010", 10, 10, true},
+	{"1_000.5", 0, 1000.5, false},
+	{"1__000", 0, 0, false},
+	{"_1000", 0, 0, false},
+	{"0xZZ", 0, 0, false},
+}
+
+func TestWithNumericLiterals(t *testing.T) {
+	root := map[string]interface{}{"list": []interface{}{}}
+	for i, test := range numericLiteralTests {
+		root[fmt.Sprintf("v%d", i)] = test.val
+		if test.valid {
+			root["list"] = append(root["list"].([]interface{}), test.val)
+		}
+	}
+	m := NewMapPath(root, WithNumericLiterals())
+	for i, test := range numericLiteralTests {
+		path := fmt.Sprintf("v%d", i)
+		v, err := m.Int64(path)
+		assert.Equal(t, test.valid, err == nil, fmt.Sprintf("Int64 of %s", test.val))
+		assert.Equal(t, test.i, v, fmt.Sprintf("Int64 value of %s", test.val))
+		if test.valid {
+			assert.Equal(t, int(test.i), m.IntV(path), fmt.Sprintf("Int value of %s", test.val))
+		}
+		assert.Equal(t, test.f, m.FloatV(path), fmt.Sprintf("Float value of %s", test.val))
+	}
+	assert.Equal(t, []int{1000000, 1000000, 31, -31, 15, 5, 10}, m.IntsV("list"), "Ints of literals")
+	assert.Equal(t, uint64(31), m.Uint64V("v2"), "Uint64 of hex literal")
+
+	plain := NewMapPath(root)
+	_, err := plain.Int("v2")
+	assert.NotNil(t, err, "Hex refused by default")
+	_, err = plain.Float("v2")
+	assert.NotNil(t, err, "Hex refused by default")
+}
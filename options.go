@@ -0,0 +1,233 @@
+package mappath
+
+import (
+	"math/rand"
+	"strconv"
+)
+
+// Option configures the behavior of a MapPath. Options are passed to NewMapPath or With and are
+// inherited by all sub structures (eg Child).
+type Option func(*options)
+
+type options struct {
+	strictDecimal   bool
+	sortedKeys      bool
+	valueKey        string
+	maxBytes        int
+	maxDepth        int
+	strictKeys      bool
+	clock           Clock
+	rand            *rand.Rand
+	tracker         *accessTracker
+	audit           *auditHook
+	indexed         bool
+	emptyChilds     bool
+	emptyAsMissing  bool
+	emptyContainers bool
+	trimStrings     bool
+	changelog       *changelog
+	actor           string
+	history         *history
+	floatFormat     byte
+	floatPrec       int
+	literals        bool
+	sha256          string
+	verifier        Verifier
+	refuseNonFinite bool
+	nonFiniteJson   NonFiniteMode
+	maxStringBytes  int
+	truncated       func(path string, size int)
+	keyCasing       bool
+	xmlAttrKey      string
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// defaultOptions are the options of MapPaths not created by NewMapPath, eg a zero value MapPath{}
+var defaultOptions = newOptions(nil)
+
+// opts returns the options of the MapPath, or the defaults if it has none
+func (this *MapPath) opts() *options {
+	if this.config == nil {
+		return defaultOptions
+	}
+	return this.config
+}
+
+// With returns a new MapPath on the same underlying root with the given options applied on top
+// of the options of the current MapPath. Called on Empty (or nil) it returns a new empty MapPath.
+func (this *MapPath) With(opts ...Option) *MapPath {
+	root := Branch{}
+	if this == nil || this == empty {
+		this = empty
+	} else {
+		root = this.root
+	}
+	o := *this.opts()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	m := &MapPath{root: root, config: &o, computed: this.computed, mounts: this.mounts, fallback: this.fallback, index: this.index, provider: this.provider, changes: this.changes}
+	if o.indexed && m.index == nil {
+		m.index = &pathIndex{}
+	}
+	o.history.attach(root)
+	return m
+}
+
+// child returns a new MapPath of the sub structure root, which inherits the options
+func (this *MapPath) child(root map[string]interface{}) *MapPath {
+	return &MapPath{root: root, config: this.config, changes: this.changes}
+}
+
+// detached returns a new MapPath of the copied structure root, which inherits the options, but neither
+// records its changes in the changelog or history nor notifies the listeners of this MapPath
+func (this *MapPath) detached(root map[string]interface{}) *MapPath {
+	o := *this.opts()
+	o.changelog, o.history = nil, nil
+	m := &MapPath{root: root, config: &o}
+	if o.indexed {
+		m.index = &pathIndex{}
+	}
+	return m
+}
+
+// WithFloatFormat sets how String and Strings convert float values, with the format (eg 'f', 'e' or 'g')
+// and precision of strconv.FormatFloat. The default is 'g' with the smallest precision representing the
+// value exactly (-1), eg "1.01" or "1e+21". Use WithFloatFormat('f', 9) for the format of v1.
+func WithFloatFormat(format byte, prec int) Option {
+	return func(o *options) {
+		o.floatFormat = format
+		o.floatPrec = prec
+	}
+}
+
+// WithNumericLiterals makes the numeric getters (eg Int, Float, Ints) accept strings in the notation of
+// numeric literals: digits separated by underscores ("1_000_000"), binary, octal and hexadecimal integers
+// with prefix ("0b101", "0o17", "0x1F") and integers in scientific notation ("1e6"). Decimal strings with
+// leading zeros (eg "010") remain decimal.
+func WithNumericLiterals() Option {
+	return func(o *options) {
+		o.literals = true
+	}
+}
+
+// formatFloat converts the float into a string, see WithFloatFormat
+func (this *options) formatFloat(f float64) string {
+	if this.floatFormat == 0 {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return strconv.FormatFloat(f, this.floatFormat, this.floatPrec, 64)
+}
+
+// WithStrictDecimal makes the Decimal getter refuse float64 values, which might have lost
+// precision already
+func WithStrictDecimal() Option {
+	return func(o *options) {
+		o.strictDecimal = true
+	}
+}
+
+// WithSortedKeys makes Walk and Diff visit map keys in sorted order, so that anything generated from the
+// iteration is reproducible. ToCanonicalJson is sorted regardless.
+func WithSortedKeys() Option {
+	return func(o *options) {
+		o.sortedKeys = true
+	}
+}
+
+// WithValueKey sets the key under which FromJsonValue wraps non object documents, instead of ValueKey
+func WithValueKey(key string) Option {
+	return func(o *options) {
+		o.valueKey = key
+	}
+}
+
+// WithMaxBytes makes loaders (eg FromJson, FromURL) refuse documents larger than n bytes with a LimitError
+func WithMaxBytes(n int) Option {
+	return func(o *options) {
+		o.maxBytes = n
+	}
+}
+
+// WithMaxDepth makes loaders refuse documents with objects and arrays nested deeper than d levels
+// with a LimitError. The root object is level 1.
+func WithMaxDepth(d int) Option {
+	return func(o *options) {
+		o.maxDepth = d
+	}
+}
+
+// WithStrictKeys makes loaders refuse documents in which an object contains the same key more than
+// once with a DuplicateKeysError, instead of silently using the last value
+func WithStrictKeys() Option {
+	return func(o *options) {
+		o.strictKeys = true
+	}
+}
+
+// WithClock sets the Clock used by the time arithmetic helpers (eg TimeSince), instead of the system time
+func WithClock(clock Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithRand sets the source of random numbers used by PickWeighted, eg a seeded one for reproducible
+// tests. Mind that a *rand.Rand must not be used concurrently.
+func WithRand(r *rand.Rand) Option {
+	return func(o *options) {
+		o.rand = r
+	}
+}
+
+// WithAccessTracking records which paths are read, so that UnusedPaths can report the ones which never were
+func WithAccessTracking() Option {
+	return func(o *options) {
+		o.tracker = &accessTracker{accessed: make(map[accessKey]bool)}
+	}
+}
+
+// WithPathIndex makes the MapPath index all paths of the structure in a trie on the first lookup,
+// so that subsequent lookups only cost one map access per segment. Meant for read heavy use of
+// static structures: modifications through the MapPath (eg Set) drop the index, which is then
+// rebuilt on the next lookup, while modifications through sub structures or of the underlying
+// map are not detected. Sub structures (eg Child) are not indexed.
+func WithPathIndex() Option {
+	return func(o *options) {
+		o.indexed = true
+	}
+}
+
+// WithEmptyAsMissing makes empty strings count as missing, so that fallbacks apply and Has returns false,
+// eg for upstream systems sending "" instead of omitting keys. Use With for single calls:
+//
+//	port := m.With(WithEmptyAsMissing()).IntV("port", 8080)
+func WithEmptyAsMissing() Option {
+	return func(o *options) {
+		o.emptyAsMissing = true
+	}
+}
+
+// WithEmptyValuesAsMissing makes empty strings, as well as empty maps and arrays, count as missing, see
+// WithEmptyAsMissing
+func WithEmptyValuesAsMissing() Option {
+	return func(o *options) {
+		o.emptyAsMissing = true
+		o.emptyContainers = true
+	}
+}
+
+// WithTrimStrings makes String and Strings remove surrounding whitespace and quotes from string values,
+// see StringTrimmed
+func WithTrimStrings() Option {
+	return func(o *options) {
+		o.trimStrings = true
+	}
+}
@@ -0,0 +1,60 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Empty as missing
+ * -------
+ */
+
+func TestWithEmptyAsMissing(t *testing.T) {
+	root := map[string]interface{}{
+		"host":    "",
+		"port":    "",
+		"name":    "app",
+		"tags":    []interface{}{},
+		"labels":  map[string]interface{}{},
+		"servers": []interface{}{map[string]interface{}{"host": ""}},
+	}
+	m := NewMapPath(root)
+	assert.Equal(t, "", m.StringV("host", "localhost"), "Empty string used by default")
+
+	e := m.With(WithEmptyAsMissing())
+	assert.Equal(t, "localhost", e.StringV("host", "localhost"), "Fallback used for empty string")
+	assert.Equal(t, 8080, e.IntV("port", 8080), "Fallback used for empty string of int")
+	assert.Equal(t, "app", e.StringV("name", "x"), "Non empty string used")
+	assert.False(t, e.Has("host"), "Empty string missing")
+	_, err := e.String("host")
+	assert.IsType(t, NotFoundError(""), err, "Not found error")
+	assert.Equal(t, "localhost", e.ChildsV("servers")[0].StringV("host", "localhost"), "Sub structures inherit")
+	assert.True(t, e.Has("tags"), "Empty array exists")
+	values, _ := e.GetMany([]string{"host", "name"})
+	assert.Equal(t, map[string]interface{}{"name": "app"}, values, "GetMany skips empty string")
+
+	e = m.With(WithEmptyValuesAsMissing())
+	assert.False(t, e.Has("tags"), "Empty array missing")
+	assert.False(t, e.Has("labels"), "Empty map missing")
+	assert.Equal(t, []string{"a"}, e.StringsV("tags", []string{"a"}), "Fallback used for empty array")
+	assert.False(t, e.Has("host"), "Empty string missing")
+}
+
+func TestWithFloatFormat(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"ratio":  1.01,
+		"large":  1e21,
+		"floats": []interface{}{0.5, 2.0, float32(1.5)},
+	})
+	assert.Equal(t, "1.01", m.StringV("ratio"), "Shortest representation by default")
+	assert.Equal(t, "1e+21", m.StringV("large"), "Exponent for large values by default")
+	assert.Equal(t, []string{"0.5", "2", "1.5"}, m.StringsV("floats"), "Array elements formatted alike")
+
+	f := m.With(WithFloatFormat('f', 2))
+	assert.Equal(t, "1.01", f.StringV("ratio"), "Fixed precision")
+	assert.Equal(t, "1000000000000000000000.00", f.StringV("large"), "No exponent")
+	assert.Equal(t, []string{"0.50", "2.00", "1.50"}, f.StringsV("floats"), "Array elements with fixed precision")
+	assert.Equal(t, "1.010000000", m.With(WithFloatFormat('f', 9)).StringV("ratio"), "Format of v1")
+}
@@ -0,0 +1,55 @@
+package mappath
+
+// OutlineEntry is a value of the structure listed by Outline
+type OutlineEntry struct {
+	// Path of the value, with escaped keys
+	Path string
+	// Key is the last key of the path (unescaped), or the index of array elements
+	Key string
+	// Depth is the number of keys of the path, 1 for the keys of the root
+	Depth int
+	// Kind of the value, eg KindMap
+	Kind Kind
+	// Children is the number of keys of maps or elements of arrays, 0 for all other values
+	Children int
+}
+
+// Outline lists the values of the structure down to maxDepth levels (all for 0 or less), parents
+// before their children, with map keys in sorted order. Maps and arrays below maxDepth are listed with
+// their number of children, but without them. Meant for building tables of contents, eg of a config
+// explorer:
+//
+//	for _, entry := range m.Outline(2) {
+//		fmt.Printf("%s%s (%s, %d)\n", strings.Repeat("  ", entry.Depth-1), entry.Key, entry.Kind, entry.Children)
+//	}
+func (this *MapPath) Outline(maxDepth int) []OutlineEntry {
+	if this == nil {
+		this = empty
+	}
+	entries := []OutlineEntry{}
+	outline(map[string]interface{}(this.root), nil, maxDepth, &entries)
+	if this.auditing() {
+		for _, entry := range entries {
+			this.auditMatching(entry.Path, true, nil, false)
+		}
+	}
+	return entries
+}
+
+// outline adds the entries of the children of current, which has the path of keys
+func outline(current interface{}, keys []string, maxDepth int, entries *[]OutlineEntry) {
+	if maxDepth > 0 && len(keys) >= maxDepth {
+		return
+	}
+	names, values := childrenOf(current)
+	for i, name := range names {
+		path := append(append([]string{}, keys...), name)
+		entry := OutlineEntry{Path: formatKeys(path), Key: name, Depth: len(path), Kind: kindOf(values[i])}
+		if entry.Kind == KindMap || entry.Kind == KindArray {
+			children, _ := childrenOf(values[i])
+			entry.Children = len(children)
+		}
+		*entries = append(*entries, entry)
+		outline(values[i], path, maxDepth, entries)
+	}
+}
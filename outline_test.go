@@ -0,0 +1,44 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Outline
+ * -------
+ */
+
+func outlineTest() *MapPath {
+	return NewMapPath(map[string]interface{}{
+		"name": "app",
+		"db": map[string]interface{}{
+			"hosts": []interface{}{"a", "b"},
+			"port":  5432,
+		},
+		"a/b":   true,
+		"empty": nil,
+	})
+}
+
+func TestOutline(t *testing.T) {
+	assert.Equal(t, []OutlineEntry{
+		{Path: `a\/b`, Key: "a/b", Depth: 1, Kind: KindBool},
+		{Path: "db", Key: "db", Depth: 1, Kind: KindMap, Children: 2},
+		{Path: "db/hosts", Key: "hosts", Depth: 2, Kind: KindArray, Children: 2},
+		{Path: "db/hosts/0", Key: "0", Depth: 3, Kind: KindString},
+		{Path: "db/hosts/1", Key: "1", Depth: 3, Kind: KindString},
+		{Path: "db/port", Key: "port", Depth: 2, Kind: KindNumber},
+		{Path: "empty", Key: "empty", Depth: 1, Kind: KindNull},
+		{Path: "name", Key: "name", Depth: 1, Kind: KindString},
+	}, outlineTest().Outline(0), "Full outline")
+
+	entries := outlineTest().Outline(1)
+	assert.Equal(t, 4, len(entries), "Keys of root only")
+	assert.Equal(t, OutlineEntry{Path: "db", Key: "db", Depth: 1, Kind: KindMap, Children: 2}, entries[1], "Children counted beyond depth")
+	assert.Equal(t, 6, len(outlineTest().Outline(2)), "Two levels")
+	assert.Equal(t, "hosts", outlineTest().ChildV("db").Outline(1)[0].Path, "Outline of sub structure")
+	assert.Empty(t, Empty().Outline(0), "Outline of empty")
+}
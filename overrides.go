@@ -0,0 +1,86 @@
+package mappath
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// WithOverrides returns a view of the structure in which the values of the flat, path keyed overrides
+// replace the values of the structure, eg to tweak single values of a large test fixture:
+//
+//	m := fixture.WithOverrides(map[string]interface{}{"db/host": "localhost", "servers/0/port": 8080})
+//
+// Only the maps and arrays on the way to the overridden paths are copied, everything else is shared with
+// the structure, which is never modified. Missing maps on the way are created, like Set does, while
+// overrides of array indices which do not exist, of values which cannot be assigned (eg a string
+// into a []int) or with invalid paths are ignored. Mind that modifying
+// the view (eg with Set) below paths without override modifies the shared structure. The view does
+// not record changes in the changelog or the history of the structure.
+func (this *MapPath) WithOverrides(overrides map[string]interface{}) *MapPath {
+	if this == nil {
+		this = empty
+	}
+	paths := make([]string, 0, len(overrides))
+	for path := range overrides {
+		paths = append(paths, path)
+	}
+	// parents first, so that overrides of sub paths apply within overridden maps
+	sort.Strings(paths)
+
+	root := copyContainer(map[string]interface{}(this.root))
+	for _, path := range paths {
+		keys, err := splitPath(strings.Trim(path, "/"))
+		if err != nil || len(keys) == 0 {
+			continue
+		}
+		if result, ok := overrideAt(root, keys, overrides[path]); ok {
+			root = result
+		}
+	}
+
+	o := *this.opts()
+	o.changelog, o.history = nil, nil
+	m := &MapPath{root: root.(map[string]interface{}), config: &o, computed: this.computed, mounts: this.mounts, fallback: this.fallback, provider: this.provider, prefix: this.prefix}
+	if o.indexed {
+		m.index = &pathIndex{}
+	}
+	return m
+}
+
+// overrideAt returns a copy of container in which the value at the path of keys is replaced. Maps and
+// arrays on the way are copied, missing or non container values on the way are replaced by maps.
+func overrideAt(container interface{}, keys []string, value interface{}) (interface{}, bool) {
+	if len(keys) == 0 {
+		return value, true
+	}
+	if !isMap(container) && !isSlice(container) {
+		container = map[string]interface{}{}
+	}
+	result := copyContainer(container)
+	child, _ := childOf(result, keys[0])
+	child, ok := overrideAt(child, keys[1:], value)
+	if !ok || assignChild(result, keys[0], child) != nil {
+		return container, false
+	}
+	return result, true
+}
+
+// copyContainer returns a shallow copy of the map or array
+func copyContainer(container interface{}) interface{} {
+	ref := reflect.ValueOf(container)
+	switch ref.Kind() {
+	case reflect.Map:
+		result := reflect.MakeMapWithSize(ref.Type(), ref.Len())
+		iter := ref.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(iter.Key(), iter.Value())
+		}
+		return result.Interface()
+	case reflect.Slice:
+		result := reflect.MakeSlice(ref.Type(), ref.Len(), ref.Len())
+		reflect.Copy(result, ref)
+		return result.Interface()
+	}
+	return container
+}
@@ -0,0 +1,68 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * Overrides
+ * -------
+ */
+
+func overridesFixture() map[string]interface{} {
+	return map[string]interface{}{
+		"name": "fixture",
+		"db":   map[string]interface{}{"host": "db.example.com", "port": 5432},
+		"servers": []interface{}{
+			map[string]interface{}{"host": "a", "port": 80},
+			map[string]interface{}{"host": "b", "port": 80},
+		},
+		"ports": []int{80, 443},
+		"other": map[string]interface{}{"untouched": true},
+	}
+}
+
+func TestWithOverrides(t *testing.T) {
+	fixture := overridesFixture()
+	m := NewMapPath(fixture)
+	o := m.WithOverrides(map[string]interface{}{
+		"db/host":        "localhost",
+		"servers/1/port": 8080,
+		"ports/0":        8000,
+		"new/key":        "created",
+		"name/sub":       "replaced",
+		"servers/5/port": 1,
+		"ports/1":        "invalid",
+		`"unclosed`:      1,
+	})
+
+	assert.Equal(t, "localhost", o.StringV("db/host"), "Override wins")
+	assert.Equal(t, 5432, o.IntV("db/port"), "Sibling of override kept")
+	assert.Equal(t, map[string]interface{}{"host": "localhost", "port": 5432}, o.MapV("db"), "Map contains override")
+	assert.Equal(t, 8080, o.IntV("servers/1/port"), "Override in array")
+	assert.Equal(t, "b", o.ChildV("servers/1").StringV("host"), "Child contains siblings")
+	assert.Equal(t, []int{8000, 443}, o.IntsV("ports"), "Override in typed array")
+	assert.Equal(t, "created", o.StringV("new/key"), "Missing maps created")
+	assert.Equal(t, "replaced", o.StringV("name/sub"), "Scalar replaced by map")
+	assert.Equal(t, 2, len(o.MapsV("servers")), "Missing index ignored")
+
+	assert.Equal(t, overridesFixture(), fixture, "Fixture untouched")
+	assert.Equal(t, "db.example.com", m.StringV("db/host"), "Original unchanged")
+	o.ChildV("db").Set("host", "changed")
+	assert.Equal(t, "db.example.com", m.StringV("db/host"), "Copied map modified")
+	assert.True(t, o.MapV("other")["untouched"].(bool), "Other branches shared")
+
+	assert.Equal(t, "fixture", Empty().WithOverrides(nil).StringV("name", "fixture"), "Overrides of empty")
+	assert.Equal(t, 1, Empty().WithOverrides(map[string]interface{}{"a/b": 1}).IntV("a/b"), "Overrides on empty")
+}
+
+func TestWithOverridesChangelog(t *testing.T) {
+	m := NewMapPath(overridesFixture(), WithChangelog(0))
+	o := m.WithOverrides(map[string]interface{}{"name": "view"})
+	assert.Nil(t, o.Set("db/port", 1), "View modified")
+	assert.Equal(t, "fixture", m.StringV("name"), "Original name")
+	assert.Empty(t, m.Changes(time.Time{}), "No changes recorded by the view")
+}
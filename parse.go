@@ -0,0 +1,114 @@
+package mappath
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Segment is a single key (map) or index (array) of a path
+type Segment struct {
+	// Key is the unescaped key or index
+	Key string
+
+	// Quoted is true if the segment was written in double quotes
+	Quoted bool
+}
+
+// PathError is returned if a path is malformed, see ParsePath
+type PathError struct {
+	Path   string
+	Pos    int
+	Reason string
+}
+
+func (err *PathError) Error() string {
+	return fmt.Sprintf("Invalid path \"%s\" at position %d: %s", err.Path, err.Pos, err.Reason)
+}
+
+// ParsePath parses a path into its segments. Segments are separated by "/". The characters "/", "\"
+// and `"` within keys must be escaped with a backslash, eg `a\/b` is the single key "a/b". Alternatively
+// a whole segment can be put in double quotes, within which "/" needs no escaping, eg
+// `labels/"app.kubernetes.io/name"`. Any other escape sequence, a trailing backslash, an unterminated
+// quote or an unescaped quote within a segment results in a PathError. All getters parse paths with ParsePath.
+func ParsePath(path string) ([]Segment, error) {
+	segments := []Segment{}
+	key := new(strings.Builder)
+	quoted, inQuotes := false, false
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; {
+		case c == '/' && !inQuotes:
+			segments = append(segments, Segment{Key: key.String(), Quoted: quoted})
+			key.Reset()
+			quoted = false
+		case c == '\\':
+			if i+1 >= len(path) {
+				return nil, &PathError{path, i, "trailing escape character"}
+			} else if next := path[i+1]; next != '/' && next != '\\' && next != '"' {
+				return nil, &PathError{path, i, fmt.Sprintf("invalid escape sequence \"\\%c\"", next)}
+			}
+			i++
+			key.WriteByte(path[i])
+		case c == '"' && inQuotes:
+			if i+1 < len(path) && path[i+1] != '/' {
+				return nil, &PathError{path, i + 1, "quoted segment must be followed by \"/\""}
+			}
+			inQuotes = false
+		case c == '"':
+			if quoted || key.Len() > 0 {
+				return nil, &PathError{path, i, "unescaped quote within segment"}
+			}
+			quoted, inQuotes = true, true
+		default:
+			key.WriteByte(c)
+		}
+	}
+	if inQuotes {
+		return nil, &PathError{path, len(path), "unterminated quote"}
+	}
+	return append(segments, Segment{Key: key.String(), Quoted: quoted}), nil
+}
+
+// FormatPath returns the path of the segments, with keys escaped as needed. It is the inverse of ParsePath.
+func FormatPath(segments []Segment) string {
+	keys := make([]string, len(segments))
+	for i, segment := range segments {
+		if segment.Quoted {
+			keys[i] = `"` + quoteEscaper.Replace(segment.Key) + `"`
+		} else {
+			keys[i] = escapeKey(segment.Key)
+		}
+	}
+	return strings.Join(keys, "/")
+}
+
+var keyEscaper = strings.NewReplacer(`\`, `\\`, `/`, `\/`, `"`, `\"`)
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func escapeKey(key string) string {
+	return keyEscaper.Replace(key)
+}
+
+// splitPath returns the keys of the path
+func splitPath(path string) ([]string, error) {
+	if !strings.ContainsAny(path, `\"`) {
+		return strings.Split(path, "/"), nil
+	}
+	segments, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(segments))
+	for i, segment := range segments {
+		keys[i] = segment.Key
+	}
+	return keys, nil
+}
+
+// formatKeys returns the path of the keys, see FormatPath
+func formatKeys(keys []string) string {
+	escaped := make([]string, len(keys))
+	for i, key := range keys {
+		escaped[i] = escapeKey(key)
+	}
+	return strings.Join(escaped, "/")
+}
@@ -0,0 +1,32 @@
+//go:build go1.18
+// +build go1.18
+
+package mappath
+
+import (
+	"testing"
+)
+
+func FuzzParsePath(f *testing.F) {
+	for _, test := range parsePathTests {
+		f.Add(test.path)
+	}
+	f.Fuzz(func(t *testing.T, path string) {
+		segments, err := ParsePath(path)
+		if err != nil {
+			return
+		}
+		formatted := FormatPath(segments)
+		again, err := ParsePath(formatted)
+		if err != nil {
+			t.Fatalf("Formatted path %q of %q does not parse: %s", formatted, path, err)
+		} else if len(again) != len(segments) {
+			t.Fatalf("Formatted path %q of %q has %d instead of %d segments", formatted, path, len(again), len(segments))
+		}
+		for i := range again {
+			if again[i] != segments[i] {
+				t.Fatalf("Segment %d of %q changed from %q to %q", i, path, segments[i].Key, again[i].Key)
+			}
+		}
+	})
+}
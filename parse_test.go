@@ -0,0 +1,70 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * ParsePath
+ * -------
+ */
+
+var parsePathTests = []struct {
+	path   string
+	err    bool
+	expect []string
+}{
+	{"foo", false, []string{"foo"}},
+	{"foo/bar/0", false, []string{"foo", "bar", "0"}},
+	{"", false, []string{""}},
+	{"a//b", false, []string{"a", "", "b"}},
+	{`a\/b/c`, false, []string{"a/b", "c"}},
+	{`a\\/b`, false, []string{`a\`, "b"}},
+	{`say \"hi\"`, false, []string{`say "hi"`}},
+	{`labels/"app.kubernetes.io/name"`, false, []string{"labels", "app.kubernetes.io/name"}},
+	{`"a/b"/"c"`, false, []string{"a/b", "c"}},
+	{`"say \"hi\" \\o/"`, false, []string{`say "hi" \o/`}},
+	{`""`, false, []string{""}},
+	{`a/"b`, true, nil},
+	{`a/"b"c`, true, nil},
+	{`a/b"c"`, true, nil},
+	{`a\`, true, nil},
+	{`a\n`, true, nil},
+}
+
+func TestParsePath(t *testing.T) {
+	for _, test := range parsePathTests {
+		segments, err := ParsePath(test.path)
+		if test.err {
+			_, ok := err.(*PathError)
+			assert.True(t, ok, "Path error returned for "+test.path)
+			continue
+		}
+		assert.Nil(t, err, "No error returned for "+test.path)
+		keys := []string{}
+		for _, segment := range segments {
+			keys = append(keys, segment.Key)
+		}
+		assert.Equal(t, test.expect, keys, "Expected segments of "+test.path)
+		assert.Equal(t, test.path, FormatPath(segments), "Path formatted back "+test.path)
+	}
+	_, err := ParsePath(`ab\x`)
+	assert.Equal(t, "Invalid path \"ab\\x\" at position 2: invalid escape sequence \"\\x\"", err.Error(), "Error correctly formatted")
+}
+
+func TestGetEscapedPath(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"urls": map[string]interface{}{"http://x": "ok"},
+	})
+	assert.Equal(t, "ok", m.StringV(`urls/http:\/\/x`), "Key with slashes found")
+	_, err := m.Get(`urls/bad\`)
+	_, ok := err.(*PathError)
+	assert.True(t, ok, "Path error returned for malformed path")
+	assert.False(t, m.Has(`urls/bad\`), "Malformed path does not exist")
+
+	assert.Equal(t, "ok", m.StringV(`urls/"http://x"`), "Key with slashes found by quoted segment")
+	assert.Nil(t, m.Set(`urls/a\/b`, 1), "Set with escaped key")
+	assert.Equal(t, 1, m.MapV("urls")["a/b"], "Escaped key stored")
+}
@@ -0,0 +1,65 @@
+package mappath
+
+import (
+	"sort"
+	"strings"
+)
+
+// Partition splits the structure into disjoint deep copies of the sub structures at the given prefixes,
+// eg to hand module specific parts of a monolithic configuration to the modules. The result contains a
+// MapPath for each prefix, which is empty if the prefix does not address a map, and the remainder of the
+// structure without any of the partitions under the empty key. Nested prefixes (eg "db" and
+// "db/replica") are removed from the outer partition. Sub structures within arrays cannot be partitioned.
+// Modifications of the partitions are neither recorded in the changelog nor can they be undone.
+//
+//	parts := m.Partition("db", "http")
+//	db, http, rest := parts["db"], parts["http"], parts[""]
+func (this *MapPath) Partition(prefixes ...string) map[string]*MapPath {
+	if this == nil {
+		this = empty
+	}
+	this.auditTree("", map[string]interface{}(this.root))
+	rest := deepCopy(map[string]interface{}(this.root)).(map[string]interface{})
+	if rest == nil {
+		rest = map[string]interface{}{}
+	}
+	keysOf := map[string][]string{}
+	sorted := []string{}
+	for _, prefix := range prefixes {
+		prefix = strings.Trim(prefix, "/")
+		keys, err := splitPath(prefix)
+		if err != nil || prefix == "" {
+			continue
+		}
+		if _, ok := keysOf[prefix]; !ok {
+			keysOf[prefix] = keys
+			sorted = append(sorted, prefix)
+		}
+	}
+	// deepest first, so that nested partitions are removed before the outer ones are extracted
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(keysOf[sorted[i]]) > len(keysOf[sorted[j]])
+	})
+
+	result := map[string]*MapPath{}
+	for _, prefix := range sorted {
+		keys := keysOf[prefix]
+		result[prefix] = this.detached(map[string]interface{}{})
+		var parent interface{} = rest
+		for _, key := range keys[:len(keys)-1] {
+			if parent, _ = childOf(parent, key); !isMap(parent) {
+				break
+			}
+		}
+		if !isMap(parent) {
+			continue
+		}
+		val, _ := childOf(parent, keys[len(keys)-1])
+		if m, ok := toStringMap(val); ok {
+			result[prefix] = this.detached(m)
+			removeChild(parent, keys[len(keys)-1])
+		}
+	}
+	result[""] = this.detached(rest)
+	return result
+}
@@ -0,0 +1,59 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * Partition
+ * -------
+ */
+
+func TestPartition(t *testing.T) {
+	orig := map[string]interface{}{
+		"name": "app",
+		"db": map[string]interface{}{
+			"host":    "localhost",
+			"replica": map[string]interface{}{"host": "replica"},
+		},
+		"http":    map[string]string{"addr": ":80"},
+		"servers": []interface{}{map[string]interface{}{"host": "a"}},
+	}
+	m := NewMapPath(orig, WithTrimStrings())
+	parts := m.Partition("db", "/http/", "db/replica", "servers/0", "missing", "name")
+
+	assert.Len(t, parts, 7, "Partition of every prefix and remainder")
+	assert.Equal(t, map[string]interface{}{"host": "localhost"}, parts["db"].Root(), "Nested partition removed")
+	assert.Equal(t, map[string]interface{}{"host": "replica"}, parts["db/replica"].Root(), "Nested partition")
+	assert.Equal(t, map[string]interface{}{"addr": ":80"}, parts["http"].Root(), "Typed map converted")
+	assert.Equal(t, map[string]interface{}{}, parts["missing"].Root(), "Missing prefix")
+	assert.Equal(t, map[string]interface{}{}, parts["name"].Root(), "Prefix of non map")
+	assert.Equal(t, map[string]interface{}{}, parts["servers/0"].Root(), "Prefix within array")
+	assert.Equal(t, map[string]interface{}{
+		"name":    "app",
+		"servers": []interface{}{map[string]interface{}{"host": "a"}},
+	}, parts[""].Root(), "Remainder")
+	assert.True(t, parts["db"].opts().trimStrings, "Options inherited")
+
+	assert.Nil(t, parts["db"].Set("host", "changed"), "Partition modified")
+	assert.Equal(t, "localhost", m.StringV("db/host"), "Partitions are copies")
+	assert.True(t, m.Has("db/replica"), "Original structure untouched")
+
+	parts = (*MapPath)(nil).Partition("db")
+	assert.Equal(t, map[string]interface{}{}, parts[""].Root(), "Remainder of nil")
+}
+
+func TestPartitionDetached(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{"a": 1, "db": map[string]interface{}{"host": "x"}}, WithHistory(0), WithChangelog(0))
+	m.Set("a", 5)
+	parts := m.Partition("db")
+	assert.Nil(t, parts["db"].Set("host", "y"), "Partition modifiable")
+	assert.Nil(t, parts[""].Set("a", 2), "Remainder modifiable")
+	assert.Equal(t, 1, len(m.Changes(time.Time{})), "Modifications of partitions not recorded")
+	assert.Nil(t, m.Undo(), "No error on undo")
+	assert.Equal(t, 1, m.IntV("a"), "Modification of structure undone")
+	assert.Equal(t, "x", m.StringV("db/host"), "Structure untouched")
+}
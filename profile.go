@@ -0,0 +1,22 @@
+package mappath
+
+// ProfilesKey is the key of the profiles section, see Profile
+const ProfilesKey = "profiles"
+
+// Profile applies the profile "profiles/<name>" to the structure in place: the profile is deep merged
+// over the structure (maps are merged, all other values replaced) and the whole profiles section is
+// removed afterwards. The name is a single key, "/" within it is not a path separator. If the profile does
+// not exist then a NotFoundError is returned and nothing is changed.
+func (this *MapPath) Profile(name string) error {
+	if this == nil {
+		this = empty
+	}
+	defer this.changed()
+	profile, err := this.Map(ProfilesKey + "/" + escapeKey(name))
+	if err != nil {
+		return err
+	}
+	defer this.recordReplace(this.snapshot())
+	delete(this.root, ProfilesKey)
+	return mergeInto(map[string]interface{}(this.root), profile)
+}
@@ -0,0 +1,56 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Profile
+ * -------
+ */
+
+func profileTest() map[string]interface{} {
+	return map[string]interface{}{
+		"log": map[string]interface{}{"level": "info", "format": "json"},
+		"db":  map[string]interface{}{"hosts": []interface{}{"a", "b"}},
+		"profiles": map[string]interface{}{
+			"dev": map[string]interface{}{
+				"log":   map[string]interface{}{"level": "debug"},
+				"db":    map[string]interface{}{"hosts": []interface{}{"localhost"}},
+				"extra": map[string]interface{}{"port": 8080},
+			},
+		},
+	}
+}
+
+func TestProfile(t *testing.T) {
+	m := NewMapPath(profileTest())
+	assert.Nil(t, m.Profile("dev"), "No error returned")
+	assert.Equal(t, "debug", m.StringV("log/level"), "Profile value used")
+	assert.Equal(t, "json", m.StringV("log/format"), "Base value kept")
+	assert.Equal(t, []string{"localhost"}, m.StringsV("db/hosts"), "Arrays replaced")
+	assert.Equal(t, 8080, m.IntV("extra/port"), "Profile only sections added")
+	assert.False(t, m.Has("profiles"), "Profiles section removed")
+}
+
+func TestProfileEscaped(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"profiles": map[string]interface{}{
+			"eu/dev": map[string]interface{}{"x": 1},
+			"eu":     map[string]interface{}{"dev": map[string]interface{}{"x": 2}},
+		},
+	})
+	assert.Nil(t, m.Profile("eu/dev"), "No error returned")
+	assert.Equal(t, 1, m.IntV("x"), "Name used as single key")
+}
+
+func TestProfileMissing(t *testing.T) {
+	m := NewMapPath(profileTest())
+	err := m.Profile("prod")
+	_, ok := err.(NotFoundError)
+	assert.True(t, ok, "Not found error returned")
+	assert.True(t, m.Has("profiles/dev"), "Nothing changed")
+	assert.Equal(t, "info", m.StringV("log/level"), "Base untouched")
+}
@@ -0,0 +1,45 @@
+package mappath
+
+import (
+	"os"
+	"strings"
+)
+
+// FallbackProvider returns the value of a path missing in the structure, see SetFallbackProvider
+type FallbackProvider func(path string) (interface{}, bool)
+
+// SetFallbackProvider sets the provider which is consulted by all getters (and Has) for paths missing in the
+// structure, before a NotFoundError is returned. Sub structures (see Child) consult the provider with their
+// full path. Fallback values given to getters are only used if the provider does not provide a value either.
+// A nil provider removes the current one.
+func (this *MapPath) SetFallbackProvider(fn func(path string) (interface{}, bool)) {
+	if this == nil || this == empty {
+		return
+	}
+	this.provider = fn
+}
+
+// within returns the provider for the sub structure of path
+func (this FallbackProvider) within(path string) FallbackProvider {
+	prefix := strings.Trim(path, "/") + "/"
+	return func(path string) (interface{}, bool) {
+		return this(prefix + path)
+	}
+}
+
+// EnvFallbackProvider returns a FallbackProvider reading environment variables: the name of the variable
+// is the upper case path with all characters other than letters and digits replaced by underscores,
+// preceded by the prefix, eg "db/host" with the prefix "APP_" becomes "APP_DB_HOST". Values are strings.
+func EnvFallbackProvider(prefix string) FallbackProvider {
+	return func(path string) (interface{}, bool) {
+		name := strings.Map(func(r rune) rune {
+			if r >= 'a' && r <= 'z' {
+				return r - 'a' + 'A'
+			} else if r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+				return r
+			}
+			return '_'
+		}, path)
+		return os.LookupEnv(prefix + name)
+	}
+}
@@ -0,0 +1,64 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+/*
+ * -------
+ * Fallback provider
+ * -------
+ */
+
+func TestSetFallbackProvider(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"name": "app",
+		"db":   map[string]interface{}{"host": "localhost"},
+	})
+	requested := []string{}
+	m.SetFallbackProvider(func(path string) (interface{}, bool) {
+		requested = append(requested, path)
+		if path == "db/port" || path == "workers" {
+			return 5432, true
+		}
+		return nil, false
+	})
+
+	assert.Equal(t, "app", m.StringV("name"), "Own value preferred")
+	assert.Equal(t, 5432, m.IntV("db/port"), "Provided value used")
+	assert.True(t, m.Has("workers"), "Provided value exists")
+	assert.Equal(t, 5432, m.ChildV("db").IntV("port"), "Sub structure consults provider with full path")
+	assert.Equal(t, "x", m.StringV("missing", "x"), "Getter fallback used last")
+	_, err := m.String("missing")
+	assert.IsType(t, NotFoundError(""), err, "Not found if not provided")
+	assert.Equal(t, []string{"db/port", "workers", "db/port", "missing", "missing"}, requested, "Provider consulted for missing paths only")
+
+	values, err := m.GetMany([]string{"name", "workers"})
+	assert.Nil(t, err, "No error on GetMany")
+	assert.Equal(t, map[string]interface{}{"name": "app", "workers": 5432}, values, "GetMany consults provider")
+
+	m.SetFallbackProvider(nil)
+	assert.False(t, m.Has("workers"), "Provider removed")
+}
+
+func TestSetFallbackProviderWithTenant(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"defaults": map[string]interface{}{"color": "blue"},
+		"tenants":  map[string]interface{}{"acme": map[string]interface{}{"name": "Acme"}},
+	})
+	tenant := m.Tenant("acme")
+	tenant.SetFallbackProvider(func(path string) (interface{}, bool) { return "provided", true })
+	assert.Equal(t, "blue", tenant.StringV("color"), "Tenant defaults preferred")
+	assert.Equal(t, "provided", tenant.StringV("size"), "Provider after defaults")
+}
+
+func TestEnvFallbackProvider(t *testing.T) {
+	os.Setenv("MAPPATH_TEST_DB_HOST_NAME", "remote")
+	defer os.Unsetenv("MAPPATH_TEST_DB_HOST_NAME")
+	m := NewMapPath(map[string]interface{}{})
+	m.SetFallbackProvider(EnvFallbackProvider("MAPPATH_TEST_"))
+	assert.Equal(t, "remote", m.StringV("db/host-name"), "Environment variable used")
+	assert.False(t, m.Has("db/port"), "Missing environment variable")
+}
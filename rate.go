@@ -0,0 +1,70 @@
+package mappath
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rateUnits are the window units of rate strings, see Rate
+var rateUnits = map[string]time.Duration{
+	"ms":   time.Millisecond,
+	"s":    time.Second,
+	"sec":  time.Second,
+	"m":    time.Minute,
+	"min":  time.Minute,
+	"h":    time.Hour,
+	"hour": time.Hour,
+	"d":    24 * time.Hour,
+	"day":  24 * time.Hour,
+}
+
+// Rate returns the rate limit of path as the amount of events allowed within a time window, for the
+// construction of rate limiters. The value is either a string like "100/s", "5000/m", "10/h", "1/d" or
+// "100/30s" (window as understood by time.ParseDuration), or a map with the keys limit and window (see
+// Duration), eg {"limit": 100, "window": "1m"}. The limit must not be negative and the window must be
+// positive, otherwise a RangeError is returned.
+func (this *MapPath) Rate(path string) (limit int, window time.Duration, err error) {
+	if this == nil {
+		this = empty
+	}
+	val, err := this.Get(path)
+	if err != nil {
+		return 0, 0, err
+	} else if val == nil {
+		return 0, 0, NullValueError(path)
+	}
+
+	if str, ok := val.(string); ok {
+		parts := strings.SplitN(strings.TrimSpace(str), "/", 2)
+		if len(parts) != 2 {
+			return 0, 0, &InvalidTypeError{val, "rate"}
+		}
+		if limit, err = strconv.Atoi(strings.TrimSpace(parts[0])); err != nil {
+			return 0, 0, &InvalidTypeError{val, "rate"}
+		}
+		unit := strings.ToLower(strings.TrimSpace(parts[1]))
+		if d, ok := rateUnits[unit]; ok {
+			window = d
+		} else if window, err = time.ParseDuration(unit); err != nil {
+			return 0, 0, &InvalidTypeError{val, "rate"}
+		}
+	} else if isMap(val) {
+		child, _ := this.Child(path)
+		if limit, err = child.Int("limit"); err != nil {
+			return 0, 0, err
+		} else if window, err = child.Duration("window"); err != nil {
+			return 0, 0, err
+		}
+	} else {
+		return 0, 0, &InvalidTypeError{val, "rate"}
+	}
+
+	if limit < 0 {
+		return 0, 0, &RangeError{path, limit, 0, maxInt}
+	} else if window <= 0 {
+		return 0, 0, &RangeError{path, window, "1ns", time.Duration(math.MaxInt64)}
+	}
+	return limit, window, nil
+}
@@ -0,0 +1,49 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * Rate
+ * -------
+ */
+
+var rateTests = []struct {
+	val    interface{}
+	limit  int
+	window time.Duration
+	err    bool
+}{
+	{"100/s", 100, time.Second, false},
+	{"5000/m", 5000, time.Minute, false},
+	{"10 / h", 10, time.Hour, false},
+	{"1/day", 1, 24 * time.Hour, false},
+	{"100/30s", 100, 30 * time.Second, false},
+	{"0/s", 0, time.Second, false},
+	{map[string]interface{}{"limit": 20, "window": "10s"}, 20, 10 * time.Second, false},
+	{map[string]interface{}{"limit": "20", "window": 60}, 20, time.Minute, false},
+	{map[string]interface{}{"limit": 20}, 0, 0, true},
+	{"100", 0, 0, true},
+	{"many/s", 0, 0, true},
+	{"100/fortnight", 0, 0, true},
+	{"-1/s", 0, 0, true},
+	{"100/0s", 0, 0, true},
+	{100, 0, 0, true},
+	{nil, 0, 0, true},
+}
+
+func TestRate(t *testing.T) {
+	for _, test := range rateTests {
+		m := NewMapPath(map[string]interface{}{"rate": test.val})
+		limit, window, err := m.Rate("rate")
+		assert.Equal(t, test.err, err != nil, "Error of %v", test.val)
+		assert.Equal(t, test.limit, limit, "Limit of %v", test.val)
+		assert.Equal(t, test.window, window, "Window of %v", test.val)
+	}
+	_, _, err := NewMapPath(map[string]interface{}{}).Rate("missing")
+	assert.IsType(t, NotFoundError(""), err, "Missing path")
+}
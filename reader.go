@@ -0,0 +1,148 @@
+package mappath
+
+import (
+	"time"
+)
+
+// Reader is the read only access to a configuration, which is implemented by MapPath. Application code
+// can depend on Reader instead of MapPath, so that tests can provide lightweight fakes like StaticReader.
+type Reader interface {
+	Get(path string, fallback ...interface{}) (interface{}, error)
+	Has(path string) bool
+	Bool(path string, fallback ...bool) (bool, error)
+	BoolV(path string, fallback ...bool) bool
+	Int(path string, fallback ...int) (int, error)
+	IntV(path string, fallback ...int) int
+	Int64(path string, fallback ...int64) (int64, error)
+	Int64V(path string, fallback ...int64) int64
+	Float(path string, fallback ...float64) (float64, error)
+	FloatV(path string, fallback ...float64) float64
+	String(path string, fallback ...string) (string, error)
+	StringV(path string, fallback ...string) string
+	Strings(path string, fallback ...[]string) ([]string, error)
+	StringsV(path string, fallback ...[]string) []string
+	Duration(path string, fallback ...time.Duration) (time.Duration, error)
+	DurationV(path string, fallback ...time.Duration) time.Duration
+}
+
+var _ Reader = &MapPath{}
+var _ Reader = StaticReader{}
+
+// StaticReader is a Reader of a flat map of paths to values, eg for tests:
+//
+//	r := StaticReader{"db/host": "localhost", "db/port": 5432}
+//
+// Only the exact paths exist, so that "db" does not exist in the example. The values are converted
+// like the ones of a MapPath.
+type StaticReader map[string]interface{}
+
+// staticKey is the key of the value in the MapPath used for the conversion of a value of a StaticReader
+const staticKey = "value"
+
+// value returns a MapPath containing the value of path under staticKey, if it exists
+func (this StaticReader) value(path string) *MapPath {
+	if val, ok := this[path]; ok {
+		return NewMapPath(map[string]interface{}{staticKey: val})
+	}
+	return NewMapPath(map[string]interface{}{})
+}
+
+// error returns err with the path instead of staticKey
+func (this StaticReader) error(err error, path string) error {
+	switch err.(type) {
+	case NotFoundError:
+		return NotFoundError(path)
+	case NullValueError:
+		return NullValueError(path)
+	}
+	return err
+}
+
+// Get returns the value of path, see MapPath.Get
+func (this StaticReader) Get(path string, fallback ...interface{}) (interface{}, error) {
+	val, err := this.value(path).Get(staticKey, fallback...)
+	return val, this.error(err, path)
+}
+
+// Has returns whether the path exists
+func (this StaticReader) Has(path string) bool {
+	_, ok := this[path]
+	return ok
+}
+
+// Bool returns the bool value of path, see MapPath.Bool
+func (this StaticReader) Bool(path string, fallback ...bool) (bool, error) {
+	val, err := this.value(path).Bool(staticKey, fallback...)
+	return val, this.error(err, path)
+}
+
+// BoolV returns the bool value of path, see MapPath.BoolV
+func (this StaticReader) BoolV(path string, fallback ...bool) bool {
+	return this.value(path).BoolV(staticKey, fallback...)
+}
+
+// Int returns the int value of path, see MapPath.Int
+func (this StaticReader) Int(path string, fallback ...int) (int, error) {
+	val, err := this.value(path).Int(staticKey, fallback...)
+	return val, this.error(err, path)
+}
+
+// IntV returns the int value of path, see MapPath.IntV
+func (this StaticReader) IntV(path string, fallback ...int) int {
+	return this.value(path).IntV(staticKey, fallback...)
+}
+
+// Int64 returns the int64 value of path, see MapPath.Int64
+func (this StaticReader) Int64(path string, fallback ...int64) (int64, error) {
+	val, err := this.value(path).Int64(staticKey, fallback...)
+	return val, this.error(err, path)
+}
+
+// Int64V returns the int64 value of path, see MapPath.Int64V
+func (this StaticReader) Int64V(path string, fallback ...int64) int64 {
+	return this.value(path).Int64V(staticKey, fallback...)
+}
+
+// Float returns the float64 value of path, see MapPath.Float
+func (this StaticReader) Float(path string, fallback ...float64) (float64, error) {
+	val, err := this.value(path).Float(staticKey, fallback...)
+	return val, this.error(err, path)
+}
+
+// FloatV returns the float64 value of path, see MapPath.FloatV
+func (this StaticReader) FloatV(path string, fallback ...float64) float64 {
+	return this.value(path).FloatV(staticKey, fallback...)
+}
+
+// String returns the string value of path, see MapPath.String
+func (this StaticReader) String(path string, fallback ...string) (string, error) {
+	val, err := this.value(path).String(staticKey, fallback...)
+	return val, this.error(err, path)
+}
+
+// StringV returns the string value of path, see MapPath.StringV
+func (this StaticReader) StringV(path string, fallback ...string) string {
+	return this.value(path).StringV(staticKey, fallback...)
+}
+
+// Strings returns the []string value of path, see MapPath.Strings
+func (this StaticReader) Strings(path string, fallback ...[]string) ([]string, error) {
+	val, err := this.value(path).Strings(staticKey, fallback...)
+	return val, this.error(err, path)
+}
+
+// StringsV returns the []string value of path, see MapPath.StringsV
+func (this StaticReader) StringsV(path string, fallback ...[]string) []string {
+	return this.value(path).StringsV(staticKey, fallback...)
+}
+
+// Duration returns the time.Duration value of path, see MapPath.Duration
+func (this StaticReader) Duration(path string, fallback ...time.Duration) (time.Duration, error) {
+	val, err := this.value(path).Duration(staticKey, fallback...)
+	return val, this.error(err, path)
+}
+
+// DurationV returns the time.Duration value of path, see MapPath.DurationV
+func (this StaticReader) DurationV(path string, fallback ...time.Duration) time.Duration {
+	return this.value(path).DurationV(staticKey, fallback...)
+}
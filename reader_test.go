@@ -0,0 +1,61 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * Reader
+ * -------
+ */
+
+func readerTestPort(r Reader) int {
+	return r.IntV("db/port", 5432)
+}
+
+func TestReader(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{"db": map[string]interface{}{"port": 3306}})
+	assert.Equal(t, 3306, readerTestPort(m), "MapPath as Reader")
+	assert.Equal(t, 1234, readerTestPort(StaticReader{"db/port": "1234"}), "StaticReader as Reader")
+	assert.Equal(t, 5432, readerTestPort(StaticReader{}), "Fallback of StaticReader")
+}
+
+func TestStaticReader(t *testing.T) {
+	r := StaticReader{
+		"db/host":  "localhost",
+		"db/port":  5432,
+		"debug":    "true",
+		"ratio":    0.5,
+		"timeout":  "1m",
+		"hosts":    []interface{}{"a", "b"},
+		"null":     nil,
+		"big":      int64(1) << 40,
+		"db/users": "many",
+	}
+	assert.True(t, r.Has("db/host"), "Exact path exists")
+	assert.False(t, r.Has("db"), "Parent path does not exist")
+	assert.Equal(t, "localhost", r.StringV("db/host"), "String")
+	assert.Equal(t, "5432", r.StringV("db/port"), "String conversion")
+	assert.Equal(t, 5432, r.IntV("db/port"), "Int")
+	assert.Equal(t, int64(1)<<40, r.Int64V("big"), "Int64")
+	assert.Equal(t, true, r.BoolV("debug"), "Bool conversion")
+	assert.Equal(t, 0.5, r.FloatV("ratio"), "Float")
+	assert.Equal(t, time.Minute, r.DurationV("timeout"), "Duration")
+	assert.Equal(t, []string{"a", "b"}, r.StringsV("hosts"), "Strings")
+
+	val, err := r.Get("db/host")
+	assert.Nil(t, err, "No error on existing path")
+	assert.Equal(t, "localhost", val, "Raw value")
+	_, err = r.Int("missing")
+	assert.Equal(t, NotFoundError("missing"), err, "Missing path")
+	_, err = r.String("null")
+	assert.Equal(t, NullValueError("null"), err, "Null value")
+	_, err = r.Int("db/users")
+	assert.NotNil(t, err, "Invalid value")
+	s, err := r.String("missing", "fallback")
+	assert.Nil(t, err, "No error with fallback")
+	assert.Equal(t, "fallback", s, "Fallback")
+}
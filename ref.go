@@ -0,0 +1,236 @@
+package mappath
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// RefKey is the key of reference objects, eg {"$ref": "#/components/schemas/Pet"}, as used by
+// OpenAPI and JSON Schema documents
+const RefKey = "$ref"
+
+// RefLoader loads the document of file references, eg "pets.json" of "pets.json#/Pet"
+type RefLoader func(file string) (*MapPath, error)
+
+// FileRefLoader returns a RefLoader reading JSON files relative to dir. Each file is loaded only once.
+func FileRefLoader(dir string, opts ...Option) RefLoader {
+	var mu sync.Mutex
+	docs := map[string]*MapPath{}
+	return func(file string) (*MapPath, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if doc, ok := docs[file]; ok {
+			return doc, nil
+		}
+		doc, err := FromJsonFile(filepath.Join(dir, filepath.FromSlash(file)), opts...)
+		if err != nil {
+			return nil, err
+		}
+		docs[file] = doc
+		return doc, nil
+	}
+}
+
+// errRefCycle is returned for references which (indirectly) reference themselves
+var errRefCycle = fmt.Errorf("Cannot resolve %s: reference cycle", RefKey)
+
+// refResolver follows references within a document and, given a loader, into other documents
+type refResolver struct {
+	loader RefLoader
+	depth  int
+	done   map[string]bool
+}
+
+// maxRefDepth limits the nesting of references, which are followed within references
+const maxRefDepth = 100
+
+// refTarget is a resolved reference: the document and the location of the value within it
+type refTarget struct {
+	doc  *MapPath
+	file string
+	keys []string
+}
+
+// pointer returns the canonical location of the target, used to detect cycles
+func (this refTarget) pointer() string {
+	return this.file + "#/" + formatKeys(this.keys)
+}
+
+// Deref returns the value of path, following references on the way and of the value itself. Local
+// references ("#/components/schemas/Pet") are JSON pointers within the document, file references
+// ("pets.json#/Pet") are only followed if a loader is given. Keys containing slashes, like the paths
+// of an OpenAPI document, have to be quoted, eg `paths/"/pets"/get`.
+func (this *MapPath) Deref(path string, loader ...RefLoader) (interface{}, error) {
+	if this == nil {
+		this = empty
+	}
+	keys, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &refResolver{}
+	if len(loader) > 0 {
+		r.loader = loader[0]
+	}
+	_, val, err := r.walk(refTarget{doc: this, keys: keys})
+	return val, err
+}
+
+// DerefChild returns the sub structure of path, following references like Deref
+func (this *MapPath) DerefChild(path string, loader ...RefLoader) (*MapPath, error) {
+	val, err := this.Deref(path, loader...)
+	if err != nil {
+		return nil, err
+	}
+	branch, ok := toStringMap(val)
+	if !ok {
+		return nil, &InvalidTypeError{path, "map"}
+	}
+	return this.child(branch), nil
+}
+
+// ResolveRefs replaces all reference objects in place with the referenced values, which are not copied.
+// References which would create a cycle (eg a schema referencing itself) are kept as they are. File
+// references are only resolved if a loader is given. Stops on the first error, references resolved
+// before keep their new values.
+func (this *MapPath) ResolveRefs(loader ...RefLoader) error {
+	if this == nil {
+		this = empty
+	}
+	defer this.changed()
+	r := &refResolver{done: map[string]bool{}}
+	if len(loader) > 0 {
+		r.loader = loader[0]
+	}
+	defer this.recordReplace(this.snapshot())
+	return r.resolveAll(refTarget{doc: this}, map[string]interface{}(this.root), nil)
+}
+
+// resolveAll replaces the references within container, which is located at current. The stack
+// contains the locations of all containers currently being resolved.
+func (this *refResolver) resolveAll(current refTarget, container interface{}, stack []string) error {
+	stack = append(stack, current.pointer())
+	keys, values := childrenOf(container)
+	for i, key := range keys {
+		location := current
+		location.keys = append(current.keys[:len(current.keys):len(current.keys)], key)
+		target, val, err := this.follow(location, values[i])
+		if err == errRefCycle || err == nil && refInStack(target, stack) {
+			continue
+		} else if err != nil {
+			return err
+		} else if target.pointer() != location.pointer() {
+			if err := assignChild(container, key, val); err != nil {
+				return err
+			}
+		}
+		if (isMap(val) || isSlice(val)) && !this.done[target.pointer()] {
+			if err := this.resolveAll(target, val, stack); err != nil {
+				return err
+			}
+		}
+	}
+	this.done[current.pointer()] = true
+	return nil
+}
+
+// refInStack returns whether the target contains any location of the stack
+func refInStack(target refTarget, stack []string) bool {
+	pointer := target.pointer()
+	for _, location := range stack {
+		if location == pointer || strings.HasPrefix(location, strings.TrimSuffix(pointer, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// walk returns the value at the location of target, following references on the way and of the value
+// itself, and its canonical location
+func (this *refResolver) walk(target refTarget) (refTarget, interface{}, error) {
+	if this.depth++; this.depth > maxRefDepth {
+		return target, nil, errRefCycle
+	}
+	defer func() { this.depth-- }()
+	current := refTarget{doc: target.doc, file: target.file}
+	var val interface{} = map[string]interface{}(target.doc.root)
+	var err error
+	for i, key := range target.keys {
+		if current, val, err = this.follow(current, val); err != nil {
+			return current, nil, err
+		}
+		next, ok := childOf(val, key)
+		if !ok {
+			return current, nil, NotFoundError(formatKeys(target.keys[:i+1]))
+		}
+		current.keys = append(current.keys[:len(current.keys):len(current.keys)], key)
+		val = next
+	}
+	return this.follow(current, val)
+}
+
+// follow returns the value referenced by val, following chains of references, and its location.
+// Values which are no reference objects are returned as they are.
+func (this *refResolver) follow(current refTarget, val interface{}) (refTarget, interface{}, error) {
+	seen := map[string]bool{}
+	for {
+		ref, ok := refOf(val)
+		if !ok {
+			return current, val, nil
+		} else if seen[ref] {
+			return current, nil, errRefCycle
+		}
+		seen[ref] = true
+		target, err := this.target(current, ref)
+		if err != nil {
+			return current, nil, err
+		}
+		if target, val, err = this.walk(target); err != nil {
+			if err != errRefCycle {
+				err = fmt.Errorf("Cannot resolve %s \"%s\": %s", RefKey, ref, err)
+			}
+			return current, nil, err
+		}
+		current = target
+	}
+}
+
+// target parses the reference, relative to the document of current
+func (this *refResolver) target(current refTarget, ref string) (refTarget, error) {
+	file, pointer := ref, ""
+	if idx := strings.Index(ref, "#"); idx >= 0 {
+		file, pointer = ref[:idx], ref[idx+1:]
+	}
+	target := refTarget{doc: current.doc, file: current.file}
+	if file != "" {
+		if this.loader == nil {
+			return target, fmt.Errorf("Cannot resolve %s \"%s\": file references require a loader", RefKey, ref)
+		}
+		doc, err := this.loader(file)
+		if err != nil {
+			return target, fmt.Errorf("Cannot resolve %s \"%s\": %s", RefKey, ref, err)
+		}
+		target.doc, target.file = doc, file
+	}
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		return target, fmt.Errorf("Cannot resolve %s \"%s\": invalid JSON pointer", RefKey, ref)
+	} else if pointer != "" {
+		target.keys = strings.Split(pointer[1:], "/")
+		for i, key := range target.keys {
+			target.keys[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(key)
+		}
+	}
+	return target, nil
+}
+
+// refOf returns the reference of val, if it is a reference object
+func refOf(val interface{}) (string, bool) {
+	m, ok := val.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	ref, ok := m[RefKey].(string)
+	return ref, ok
+}
@@ -0,0 +1,104 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/*
+ * -------
+ * References
+ * -------
+ */
+
+const refTestSpec = `{
+	"paths": {
+		"/pets": {
+			"get": {"responses": {"200": {"$ref": "#/components/responses/Pets"}}}
+		}
+	},
+	"components": {
+		"responses": {
+			"Pets": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/PetList"}}}}
+		},
+		"schemas": {
+			"PetList": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}},
+			"Pet": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"parent": {"$ref": "#/components/schemas/Pet"},
+					"owner": {"$ref": "owner.json#/Owner"}
+				}
+			},
+			"Alias": {"$ref": "#/components/schemas/Pet"},
+			"Loop": {"$ref": "#/components/schemas/Loop2"},
+			"Loop2": {"$ref": "#/components/schemas/Loop"},
+			"Broken": {"$ref": "#/components/schemas/Missing"},
+			"Tilde": {"$ref": "#/components/responses/Pets/content/application~1json"}
+		}
+	}
+}`
+
+func TestDeref(t *testing.T) {
+	m, _ := FromJson([]byte(refTestSpec))
+
+	val, err := m.Deref(`paths/"/pets"/get/responses/200/content/"application/json"/schema/items/properties/name/type`)
+	assert.Nil(t, err, "No error following references on the way")
+	assert.Equal(t, "string", val, "Value behind references")
+
+	pet, err := m.DerefChild("components/schemas/Alias")
+	assert.Nil(t, err, "No error on reference value")
+	assert.Equal(t, "object", pet.StringV("type"), "Referenced sub structure")
+
+	val, err = m.Deref("components/schemas/Tilde/schema/type")
+	assert.Nil(t, err, "No error on escaped pointer")
+	assert.Equal(t, "array", val, "Escaped pointer followed")
+
+	_, err = m.Deref("components/schemas/Loop")
+	assert.Equal(t, errRefCycle, err, "Cycle detected")
+	_, err = m.Deref("components/schemas/Broken")
+	assert.EqualError(t, err, `Cannot resolve $ref "#/components/schemas/Missing": The path "components/schemas/Missing" does not exist`, "Missing target")
+	_, err = m.Deref("components/schemas/Pet/properties/owner")
+	assert.NotNil(t, err, "File reference without loader")
+	_, err = m.Deref("components/missing")
+	assert.IsType(t, NotFoundError(""), err, "Missing path")
+}
+
+func TestDerefFile(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "mappath")
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "owner.json"), []byte(`{
+		"Owner": {"properties": {"name": {"$ref": "#/Name"}}},
+		"Name": {"type": "string"}
+	}`), 0644)
+
+	m, _ := FromJson([]byte(refTestSpec))
+	loader := FileRefLoader(dir)
+	val, err := m.Deref("components/schemas/Pet/properties/owner/properties/name/type", loader)
+	assert.Nil(t, err, "No error following file reference")
+	assert.Equal(t, "string", val, "Local reference within referenced file")
+
+	_, err = m.Deref("components/schemas/Pet/properties/owner", FileRefLoader(os.TempDir()+"/missing"))
+	assert.NotNil(t, err, "Missing file")
+}
+
+func TestResolveRefs(t *testing.T) {
+	m, _ := FromJson([]byte(refTestSpec))
+	assert.NotNil(t, m.ResolveRefs(), "File reference without loader")
+
+	m, _ = FromJson([]byte(refTestSpec))
+	delete(m.ChildV("components/schemas/Pet/properties").Root(), "owner")
+	delete(m.ChildV("components/schemas").Root(), "Broken")
+	assert.Nil(t, m.ResolveRefs(), "References resolved")
+
+	assert.Equal(t, "array", m.StringV(`paths/"/pets"/get/responses/200/content/"application/json"/schema/type`), "Nested references resolved")
+	assert.Equal(t, "object", m.StringV("components/schemas/PetList/items/type"), "Reference replaced")
+	assert.Equal(t, "object", m.StringV("components/schemas/Alias/type"), "Reference to reference replaced")
+	assert.Equal(t, "#/components/schemas/Pet", m.StringV("components/schemas/Pet/properties/parent/$ref"), "Cyclic reference kept")
+	assert.Equal(t, "#/components/schemas/Loop2", m.StringV("components/schemas/Loop/$ref"), "Reference loop kept")
+	assert.Nil(t, m.Walk(func(string, interface{}) error { return nil }), "No cycles in structure")
+}
@@ -0,0 +1,94 @@
+package mappath
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WhenKey is the key of conditional sections, see Resolve
+const WhenKey = "$when"
+
+// Resolve evaluates all conditional sections of the structure in place. A conditional section is
+// a map containing the key WhenKey ("$when") with either a boolean or an expression, eg
+//
+//	{"debug": {"$when": "env(\"APP_ENV\") != 'prod' && ${features/debug}", "level": "trace"}}
+//
+// If the condition is true then only the "$when" key is removed, otherwise the whole section is
+// removed from its parent map or array. See Eval for the expression syntax.
+func (this *MapPath) Resolve() error {
+	if this == nil {
+		this = empty
+	}
+	defer this.changed()
+	if _, ok := this.root[WhenKey]; ok {
+		return fmt.Errorf("Cannot use \"%s\" on the root", WhenKey)
+	}
+	defer this.recordReplace(this.snapshot())
+	_, _, err := this.resolveNode(map[string]interface{}(this.root), "")
+	return err
+}
+
+// resolveNode resolves the conditional sections within val and returns the, possibly replaced,
+// value and whether it is to be kept
+func (this *MapPath) resolveNode(val interface{}, path string) (interface{}, bool, error) {
+	if val == nil {
+		return val, true, nil
+	}
+	ref := reflect.ValueOf(val)
+	switch ref.Kind() {
+	case reflect.Map:
+		if cond, ok := childOf(val, WhenKey); ok {
+			keep, err := this.condition(cond, joinPath(path, WhenKey))
+			if err != nil || !keep {
+				return val, false, err
+			}
+			removeChild(val, WhenKey)
+		}
+		keys, values := childrenOf(val)
+		for i, key := range keys {
+			newVal, keep, err := this.resolveNode(values[i], joinPath(path, key))
+			if err != nil {
+				return val, false, err
+			} else if !keep {
+				removeChild(val, key)
+			} else if err = assignChild(val, key, newVal); err != nil {
+				return val, false, err
+			}
+		}
+	case reflect.Slice:
+		kept := reflect.MakeSlice(ref.Type(), 0, ref.Len())
+		for i := 0; i < ref.Len(); i++ {
+			newVal, keep, err := this.resolveNode(ref.Index(i).Interface(), joinPath(path, fmt.Sprintf("%d", i)))
+			if err != nil {
+				return val, false, err
+			} else if keep {
+				v, err := assignableValue(newVal, ref.Type().Elem())
+				if err != nil {
+					return val, false, err
+				}
+				kept = reflect.Append(kept, v)
+			}
+		}
+		if kept.Len() < ref.Len() {
+			return kept.Interface(), true, nil
+		}
+	}
+	return val, true, nil
+}
+
+// condition evaluates the value of a "$when" key
+func (this *MapPath) condition(cond interface{}, path string) (bool, error) {
+	if expr, ok := cond.(string); ok {
+		val, err := evalExpr(expr, func(p string) (interface{}, error) {
+			return this.Get(p)
+		})
+		if err != nil {
+			return false, err
+		}
+		cond = val
+	}
+	if b, ok := cond.(bool); ok {
+		return b, nil
+	}
+	return false, &InvalidTypeError{cond, "bool condition of " + path}
+}
@@ -0,0 +1,120 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+/*
+ * -------
+ * Resolve
+ * -------
+ */
+
+func resolveTest() map[string]interface{} {
+	return map[string]interface{}{
+		"env": "prod",
+		"debug": map[string]interface{}{
+			"$when": "${env} != 'prod'",
+			"level": "trace",
+		},
+		"metrics": map[string]interface{}{
+			"$when": "${env} == 'prod' && ${workers} > 2",
+			"port":  9100,
+		},
+		"workers": 4,
+		"plugins": []interface{}{
+			map[string]interface{}{"name": "a", "$when": true},
+			map[string]interface{}{"name": "b", "$when": false},
+			map[string]interface{}{"name": "c"},
+			"d",
+		},
+	}
+}
+
+func TestResolve(t *testing.T) {
+	m := NewMapPath(resolveTest())
+	assert.Nil(t, m.Resolve(), "No error returned")
+	assert.False(t, m.Has("debug"), "False section removed")
+	assert.Equal(t, 9100, m.IntV("metrics/port"), "True section kept")
+	assert.False(t, m.Has("metrics/$when"), "Condition removed")
+	plugins, _ := m.Get("plugins")
+	assert.Equal(t, 3, len(plugins.([]interface{})), "False array element removed")
+	assert.Equal(t, "c", m.StringV("plugins/1/name"), "Remaining elements kept in order")
+	assert.False(t, m.Has("plugins/0/$when"), "Condition removed from array element")
+}
+
+func TestResolveEnv(t *testing.T) {
+	os.Setenv("MAPPATH_RESOLVE_TEST", "staging")
+	defer os.Unsetenv("MAPPATH_RESOLVE_TEST")
+	m := NewMapPath(map[string]interface{}{
+		"staging": map[string]interface{}{"$when": "env('MAPPATH_RESOLVE_TEST') == 'staging'"},
+		"prod":    map[string]interface{}{"$when": "env('MAPPATH_RESOLVE_TEST') == 'prod'"},
+	})
+	assert.Nil(t, m.Resolve(), "No error returned")
+	assert.True(t, m.Has("staging"), "Section of environment kept")
+	assert.False(t, m.Has("prod"), "Section of other environment removed")
+}
+
+func TestResolveErrors(t *testing.T) {
+	tests := []map[string]interface{}{
+		{"a": map[string]interface{}{"$when": "${missing} == 1"}},
+		{"a": map[string]interface{}{"$when": "1 +"}},
+		{"a": map[string]interface{}{"$when": "1 + 2"}},
+		{"a": map[string]interface{}{"$when": 1}},
+		{"$when": true},
+	}
+	for _, test := range tests {
+		assert.NotNil(t, NewMapPath(test).Resolve(), "Error returned")
+	}
+}
+
+/*
+ * -------
+ * Expressions
+ * -------
+ */
+
+var evalExprTests = []struct {
+	expr   string
+	err    bool
+	expect interface{}
+}{
+	{"1 + 2 * 3", false, 7},
+	{"(1 + 2) * 3", false, 9},
+	{"7 / 2", false, 3.5},
+	{"8 / 2", false, 4},
+	{"7 % 3", false, 1},
+	{"-3 + 1", false, -2},
+	{"1.5 * 2", false, 3.0},
+	{"${workers} * 2", false, 8},
+	{"'a' + \"b\"", false, "ab"},
+	{"${env} == 'prod'", false, true},
+	{"${workers} >= 4 && !(${workers} > 4)", false, true},
+	{"'a' < 'b'", false, true},
+	{"2 == 2.0", false, true},
+	{"null == null", false, true},
+	{"1 / 0", true, nil},
+	{"1 && true", true, nil},
+	{"'a' - 1", true, nil},
+	{"(1", true, nil},
+	{"1 2", true, nil},
+	{"'open", true, nil},
+	{"${missing}", true, nil},
+}
+
+func TestEvalExpr(t *testing.T) {
+	m := NewMapPath(resolveTest())
+	for _, test := range evalExprTests {
+		val, err := evalExpr(test.expr, func(path string) (interface{}, error) {
+			return m.Get(path)
+		})
+		if test.err {
+			assert.NotNil(t, err, "Error returned for "+test.expr)
+		} else {
+			assert.Nil(t, err, "No error returned for "+test.expr)
+			assert.Equal(t, test.expect, val, "Expected value for "+test.expr)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package mappath
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Restrict returns a copy of the structure, which contains only the paths matching any of the allow glob
+// expressions (see Paths), or all paths if allow is empty, and none of the paths matching any of the deny
+// glob expressions. All other paths act as if they do not exist, eg to hand the configuration to a third
+// party plugin, which must not see any credentials:
+//
+//	plugin := m.Restrict([]string{"plugins/acme/**", "http/*"}, []string{"**/password", "**/token"})
+//
+// Paths are matched including their descendants, so allowing "db" allows "db/host" as well. Array
+// elements within partially visible arrays, which are not visible, are replaced with nil to keep the
+// indices of the visible elements. The copy is a snapshot: later modifications of the structure are
+// not reflected, modifications of the copy are neither recorded in the changelog nor can they be undone.
+func (this *MapPath) Restrict(allow []string, deny []string) *MapPath {
+	if this == nil {
+		this = empty
+	}
+	r := &restriction{}
+	for _, glob := range allow {
+		r.allow = append(r.allow, strings.Split(strings.Trim(glob, "/"), "/"))
+	}
+	for _, glob := range deny {
+		r.deny = append(r.deny, strings.Split(strings.Trim(glob, "/"), "/"))
+	}
+	root, _ := r.filter(map[string]interface{}(this.root), nil, len(r.allow) == 0)
+	m, _ := toStringMap(root)
+	if m == nil {
+		m = map[string]interface{}{}
+	}
+	this.auditTree("", m)
+	return this.detached(m)
+}
+
+// restriction are the split glob expressions of Restrict
+type restriction struct {
+	allow [][]string
+	deny  [][]string
+}
+
+// filter returns a copy of val at the path parts, containing only the visible paths, and whether any
+// of it is visible
+func (this *restriction) filter(val interface{}, parts []string, allowed bool) (interface{}, bool) {
+	if len(parts) > 0 && this.matches(this.deny, parts, false) {
+		return nil, false
+	}
+	allowed = allowed || len(parts) > 0 && this.matches(this.allow, parts, false)
+	if !allowed && !this.matches(this.allow, parts, true) {
+		return nil, false
+	}
+	keys, values := childrenOf(val)
+	switch {
+	case isMap(val):
+		result := map[string]interface{}{}
+		for i, key := range keys {
+			if child, ok := this.filter(values[i], append(parts[:len(parts):len(parts)], key), allowed); ok {
+				result[key] = child
+			}
+		}
+		return result, allowed || len(result) > 0
+	case val != nil && reflect.TypeOf(val).Kind() == reflect.Slice:
+		result := make([]interface{}, len(keys))
+		visible := false
+		for i, key := range keys {
+			if child, ok := this.filter(values[i], append(parts[:len(parts):len(parts)], key), allowed); ok {
+				result[i], visible = child, true
+			}
+		}
+		return result, allowed || visible
+	}
+	return deepCopy(val), allowed
+}
+
+// matches checks whether the path matches any of the globs, see matchGlob
+func (this *restriction) matches(globs [][]string, parts []string, ancestors bool) bool {
+	for _, glob := range globs {
+		if matchGlob(glob, parts, ancestors) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * Restrict
+ * -------
+ */
+
+func restrictTestMapPath() *MapPath {
+	return NewMapPath(map[string]interface{}{
+		"name": "app",
+		"db":   map[string]interface{}{"host": "localhost", "password": "secret"},
+		"plugins": map[string]interface{}{
+			"acme":  map[string]interface{}{"url": "https://acme", "token": "t0k3n"},
+			"other": map[string]interface{}{"url": "https://other"},
+		},
+		"servers": []interface{}{
+			map[string]interface{}{"host": "a", "password": "pa"},
+			map[string]interface{}{"host": "b"},
+		},
+	})
+}
+
+func TestRestrict(t *testing.T) {
+	m := restrictTestMapPath()
+	view := m.Restrict([]string{"plugins/acme", "db", "servers/1"}, []string{"**/password", "**/token"})
+
+	assert.Equal(t, "https://acme", view.StringV("plugins/acme/url"), "Descendant of allowed path visible")
+	assert.Equal(t, "localhost", view.StringV("db/host"), "Allowed path visible")
+	assert.False(t, view.Has("db/password"), "Denied path hidden")
+	assert.False(t, view.Has("plugins/acme/token"), "Denied path in allowed path hidden")
+	assert.False(t, view.Has("plugins/other"), "Sibling of allowed path hidden")
+	assert.False(t, view.Has("name"), "Path not allowed hidden")
+	_, err := view.Get("name")
+	assert.IsType(t, NotFoundError(""), err, "Hidden path not found")
+	assert.Equal(t, []interface{}{nil, map[string]interface{}{"host": "b"}}, view.Root()["servers"], "Indices of array kept")
+
+	assert.Nil(t, view.Set("db/host", "changed"), "View modified")
+	assert.Equal(t, "localhost", m.StringV("db/host"), "View is a copy")
+	assert.Equal(t, "secret", m.StringV("db/password"), "Structure untouched")
+}
+
+func TestRestrictDenyOnly(t *testing.T) {
+	view := restrictTestMapPath().Restrict(nil, []string{"**/password", "**/token"})
+	assert.Equal(t, map[string]interface{}{
+		"name": "app",
+		"db":   map[string]interface{}{"host": "localhost"},
+		"plugins": map[string]interface{}{
+			"acme":  map[string]interface{}{"url": "https://acme"},
+			"other": map[string]interface{}{"url": "https://other"},
+		},
+		"servers": []interface{}{
+			map[string]interface{}{"host": "a"},
+			map[string]interface{}{"host": "b"},
+		},
+	}, view.Root(), "Everything but denied paths")
+	assert.Equal(t, map[string]interface{}{}, restrictTestMapPath().Restrict([]string{"missing"}, nil).Root(), "Nothing allowed")
+}
+
+func TestRestrictDetached(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{"a": 1, "secret": "s"}, WithHistory(0), WithChangelog(0))
+	m.Set("a", 5)
+	r := m.Restrict(nil, []string{"secret"})
+	assert.Nil(t, r.Set("a", 2), "Snapshot modifiable")
+	assert.Equal(t, 1, len(m.Changes(time.Time{})), "Modification of snapshot not recorded")
+	assert.False(t, r.CanUndo(), "Nothing to undo in snapshot")
+	assert.Nil(t, m.Undo(), "No error on undo")
+	assert.Equal(t, 1, m.IntV("a"), "Modification of structure undone")
+	assert.Equal(t, 2, r.IntV("a"), "Snapshot untouched")
+}
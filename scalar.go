@@ -0,0 +1,195 @@
+package mappath
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScalarParser converts a (non null) scalar value of the structure, eg "#ff0000" or "10MB", into a typed value
+type ScalarParser func(val interface{}) (interface{}, error)
+
+var scalarParsers = struct {
+	sync.RWMutex
+	byName map[string]ScalarParser
+	byType map[reflect.Type]string
+}{
+	byName: map[string]ScalarParser{},
+	byType: map[reflect.Type]string{},
+}
+
+func init() {
+	RegisterScalarParser("duration", parseDuration, reflect.TypeOf(time.Duration(0)))
+	RegisterScalarParser("time", parseTime, reflect.TypeOf(time.Time{}))
+	RegisterScalarParser("color", parseColor, reflect.TypeOf(color.RGBA{}))
+	RegisterScalarParser("size", parseSize)
+}
+
+// RegisterScalarParser registers the parser under name, replacing any parser of the same name. Parsers
+// can be used explicitly with Parse, by Bind for fields with a `parser:"name"` tag and are used by GetAs
+// and Bind for all values of the given types. The following parsers are registered by default:
+//
+//	duration    time.Duration from strings like "1m30s" or numbers of seconds, see Duration
+//	time        time.Time from RFC 3339 strings, dates or unix timestamps, see Time
+//	color       color.RGBA from hex strings like "#f00", "#ff0000" or "#ff000080"
+//	size        int64 amount of bytes from strings like "512", "10KB" (1000) or "1.5GiB" (1024), or numbers
+func RegisterScalarParser(name string, fn ScalarParser, types ...reflect.Type) {
+	scalarParsers.Lock()
+	defer scalarParsers.Unlock()
+	scalarParsers.byName[name] = fn
+	for _, typ := range types {
+		scalarParsers.byType[typ] = name
+	}
+}
+
+// scalarParserOf returns the name of the parser registered for the type, if any
+func scalarParserOf(typ reflect.Type) string {
+	scalarParsers.RLock()
+	defer scalarParsers.RUnlock()
+	return scalarParsers.byType[typ]
+}
+
+// scalarParserNamed returns the parser registered under name
+func scalarParserNamed(name string) (ScalarParser, error) {
+	scalarParsers.RLock()
+	defer scalarParsers.RUnlock()
+	if fn, ok := scalarParsers.byName[name]; ok {
+		return fn, nil
+	}
+	return nil, fmt.Errorf("Unknown scalar parser \"%s\"", name)
+}
+
+// parseScalar converts val with the parser registered under name
+func parseScalar(name string, val interface{}) (interface{}, error) {
+	fn, err := scalarParserNamed(name)
+	if err != nil {
+		return nil, err
+	}
+	return fn(val)
+}
+
+// Parse returns the value of path converted by the registered parser of the given name, see
+// RegisterScalarParser. Fallback is returned as is, if the path does not exist.
+func (this *MapPath) Parse(path, parser string, fallback ...interface{}) (interface{}, error) {
+	if _, err := scalarParserNamed(parser); err != nil {
+		return nil, err
+	}
+	val, err := this.Get(path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return nil, err
+	} else if val == nil {
+		return nil, NullValueError(path)
+	}
+	return parseScalar(parser, val)
+}
+
+// ParseV returns the value of path converted by the registered parser of the given name. If the value cannot be parsed then fallback or nil is returned. Handy in single value context.
+func (this *MapPath) ParseV(path, parser string, fallback ...interface{}) interface{} {
+	if val, err := this.Parse(path, parser, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return nil
+	} else {
+		return val
+	}
+}
+
+// bindParsed sets target to the value of path converted by the named parser
+func (this *MapPath) bindParsed(target reflect.Value, path, parser string) error {
+	val, err := this.Parse(path, parser)
+	if err != nil {
+		return err
+	}
+	ref := reflect.ValueOf(val)
+	if target.Kind() == reflect.Ptr && ref.Type().ConvertibleTo(target.Type().Elem()) {
+		elem := reflect.New(target.Type().Elem())
+		elem.Elem().Set(ref.Convert(target.Type().Elem()))
+		target.Set(elem)
+	} else if ref.Type().ConvertibleTo(target.Type()) {
+		target.Set(ref.Convert(target.Type()))
+	} else {
+		return &InvalidTypeError{val, target.Type().String()}
+	}
+	return nil
+}
+
+func parseDuration(val interface{}) (interface{}, error) {
+	if d, ok := toDuration(val); ok {
+		return d, nil
+	}
+	return nil, &InvalidTypeError{val, "duration"}
+}
+
+func parseTime(val interface{}) (interface{}, error) {
+	if t, ok := toTime(val); ok {
+		return t, nil
+	}
+	return nil, &InvalidTypeError{val, "time"}
+}
+
+func parseColor(val interface{}) (interface{}, error) {
+	str, ok := val.(string)
+	hex := strings.TrimPrefix(str, "#")
+	if !ok || len(hex) == len(str) {
+		return nil, &InvalidTypeError{val, "color"}
+	}
+	if len(hex) == 3 || len(hex) == 4 {
+		long := make([]byte, 0, len(hex)*2)
+		for i := 0; i < len(hex); i++ {
+			long = append(long, hex[i], hex[i])
+		}
+		hex = string(long)
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(hex) != 8 {
+		return nil, &InvalidTypeError{val, "color"}
+	}
+	return color.RGBA{R: uint8(n >> 24), G: uint8(n >> 16), B: uint8(n >> 8), A: uint8(n)}, nil
+}
+
+var sizeUnits = []struct {
+	suffix string
+	factor float64
+}{
+	{"kib", 1 << 10}, {"mib", 1 << 20}, {"gib", 1 << 30}, {"tib", 1 << 40},
+	{"kb", 1e3}, {"mb", 1e6}, {"gb", 1e9}, {"tb", 1e12},
+	{"k", 1e3}, {"m", 1e6}, {"g", 1e9}, {"t", 1e12},
+	{"b", 1},
+}
+
+func parseSize(val interface{}) (interface{}, error) {
+	str, ok := val.(string)
+	if !ok {
+		kind := reflect.ValueOf(val).Kind()
+		f, err := toFloat(val)
+		if err != nil || f < 0 || !isOfKind(kind, kindsInt) && !isOfKind(kind, kindsFloat) {
+			return nil, &InvalidTypeError{val, "size"}
+		}
+		return int64(f), nil
+	}
+	str = strings.ToLower(strings.TrimSpace(str))
+	factor := 1.0
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(str, unit.suffix) {
+			str, factor = strings.TrimSpace(strings.TrimSuffix(str, unit.suffix)), unit.factor
+			break
+		}
+	}
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil || f < 0 || f*factor > math.MaxInt64 {
+		return nil, &InvalidTypeError{val, "size"}
+	}
+	return int64(f * factor), nil
+}
@@ -0,0 +1,125 @@
+package mappath
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"image/color"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * Scalar parsers
+ * -------
+ */
+
+var parseColorTests = []struct {
+	val      interface{}
+	expected interface{}
+}{
+	{"#f00", color.RGBA{255, 0, 0, 255}},
+	{"#f008", color.RGBA{255, 0, 0, 136}},
+	{"#00ff7f", color.RGBA{0, 255, 127, 255}},
+	{"#00FF7F80", color.RGBA{0, 255, 127, 128}},
+	{"00ff7f", nil},
+	{"#00ff7", nil},
+	{"#gg0000", nil},
+	{123, nil},
+}
+
+func TestParseColor(t *testing.T) {
+	for _, test := range parseColorTests {
+		val, err := parseColor(test.val)
+		assert.Equal(t, test.expected, val, fmt.Sprintf("Color of %v", test.val))
+		assert.Equal(t, test.expected == nil, err != nil, fmt.Sprintf("Error of %v", test.val))
+	}
+}
+
+var parseSizeTests = []struct {
+	val      interface{}
+	expected interface{}
+}{
+	{"512", int64(512)},
+	{"512B", int64(512)},
+	{"10KB", int64(10000)},
+	{"10 kb", int64(10000)},
+	{"1.5GiB", int64(1610612736)},
+	{"2M", int64(2000000)},
+	{1024, int64(1024)},
+	{"-1KB", nil},
+	{"many", nil},
+	{"99999999TB", nil},
+	{true, nil},
+}
+
+func TestParseSize(t *testing.T) {
+	for _, test := range parseSizeTests {
+		val, err := parseSize(test.val)
+		assert.Equal(t, test.expected, val, fmt.Sprintf("Size of %v", test.val))
+		assert.Equal(t, test.expected == nil, err != nil, fmt.Sprintf("Error of %v", test.val))
+	}
+}
+
+type testLevel int
+
+func TestParse(t *testing.T) {
+	RegisterScalarParser("level", func(val interface{}) (interface{}, error) {
+		switch strings.ToLower(fmt.Sprint(val)) {
+		case "low":
+			return testLevel(1), nil
+		case "high":
+			return testLevel(2), nil
+		}
+		return nil, &InvalidTypeError{val, "level"}
+	}, reflect.TypeOf(testLevel(0)))
+
+	m := NewMapPath(map[string]interface{}{
+		"color":   "#00ff00",
+		"upload":  "5MB",
+		"timeout": "1m30s",
+		"level":   "HIGH",
+		"null":    nil,
+	})
+	assert.Equal(t, color.RGBA{0, 255, 0, 255}, m.ParseV("color", "color"), "Color parsed")
+	assert.Equal(t, int64(5000000), m.ParseV("upload", "size"), "Size parsed")
+	assert.Equal(t, testLevel(2), m.ParseV("level", "level"), "Custom parser used")
+	assert.Equal(t, "fallback", m.ParseV("missing", "size", "fallback"), "Fallback used")
+
+	_, err := m.Parse("color", "unknown")
+	assert.EqualError(t, err, `Unknown scalar parser "unknown"`, "Unknown parser")
+	_, err = m.Parse("null", "size")
+	assert.IsType(t, NullValueError(""), err, "Null value")
+	_, err = m.Parse("upload", "color")
+	assert.IsType(t, &InvalidTypeError{}, err, "Parser error")
+
+	val, err := m.GetAs("timeout", reflect.TypeOf(time.Duration(0)))
+	assert.Nil(t, err, "No error on registered type")
+	assert.Equal(t, 90*time.Second, val, "GetAs uses parser of type")
+	val, err = m.GetAs("level", reflect.TypeOf(testLevel(0)))
+	assert.Nil(t, err, "No error on custom type")
+	assert.Equal(t, testLevel(2), val, "GetAs uses custom parser of type")
+	val, err = m.GetAs("missing", reflect.TypeOf(color.RGBA{}), color.RGBA{1, 2, 3, 4})
+	assert.Nil(t, err, "No error on fallback")
+	assert.Equal(t, color.RGBA{1, 2, 3, 4}, val, "Fallback of registered type")
+
+	var cfg struct {
+		Color   color.RGBA
+		Upload  int64 `parser:"size"`
+		Timeout *time.Duration
+		Level   testLevel
+		Limit   int64 `parser:"size" default:"1KiB"`
+	}
+	assert.Nil(t, m.Bind(&cfg), "Bind with parsers")
+	assert.Equal(t, color.RGBA{0, 255, 0, 255}, cfg.Color, "Field of registered type")
+	assert.Equal(t, int64(5000000), cfg.Upload, "Field with parser tag")
+	assert.Equal(t, 90*time.Second, *cfg.Timeout, "Pointer field of registered type")
+	assert.Equal(t, testLevel(2), cfg.Level, "Field of custom type")
+	assert.Equal(t, int64(1024), cfg.Limit, "Default parsed with parser tag")
+
+	defaults := NewMapPath(map[string]interface{}{})
+	assert.Nil(t, defaults.ApplyDefaults(&cfg), "Defaults with parser tag applied")
+	assert.Equal(t, int64(1024), defaults.Int64V("Limit"), "Default parsed with parser tag")
+}
@@ -0,0 +1,39 @@
+package mappath
+
+import (
+	"crypto/ed25519"
+	"errors"
+)
+
+// ErrInvalidSignature is returned by loaders if the detached signature of a document does not verify,
+// see WithEd25519Signature
+var ErrInvalidSignature = errors.New("The signature of the document is invalid")
+
+// Verifier authenticates the raw bytes of a document before it is parsed, eg by checking a detached
+// signature. A non nil error refuses the document.
+type Verifier func(data []byte) error
+
+// WithVerifier makes the loaders (eg FromJson, FromJsonFile, FromYaml, FromFile, FromURL) call the
+// verifier with the raw bytes of the document, before parsing it. Documents the verifier returns an
+// error for are refused with that error. Replaces any verifier set before.
+func WithVerifier(verifier Verifier) Option {
+	return func(o *options) {
+		o.verifier = verifier
+	}
+}
+
+// WithEd25519Signature makes the loaders verify the raw document against the detached ed25519 signature
+// with the public key, see WithVerifier. Keys and signatures are usually distributed separately from the
+// document, eg the signature next to a remote configuration and the key with the application:
+//
+//	m, err := mappath.FromURL(url, mappath.WithEd25519Signature(publicKey, signature))
+//
+// Documents with invalid signatures, or if the key is invalid, are refused with ErrInvalidSignature.
+func WithEd25519Signature(key ed25519.PublicKey, signature []byte) Option {
+	return WithVerifier(func(data []byte) error {
+		if len(key) != ed25519.PublicKeySize || !ed25519.Verify(key, data, signature) {
+			return ErrInvalidSignature
+		}
+		return nil
+	})
+}
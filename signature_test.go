@@ -0,0 +1,109 @@
+package mappath
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/*
+ * -------
+ * Signature
+ * -------
+ */
+
+func TestWithEd25519Signature(t *testing.T) {
+	public, private, _ := ed25519.GenerateKey(nil)
+	other, _, _ := ed25519.GenerateKey(nil)
+	doc := []byte(`{"foo":"bar"}`)
+	signature := ed25519.Sign(private, doc)
+
+	dir, _ := ioutil.TempDir("", "mappath")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "config.json")
+	ioutil.WriteFile(file, doc, 0644)
+
+	m, err := FromJsonFile(file, WithEd25519Signature(public, signature))
+	assert.Nil(t, err, "No error on valid signature")
+	assert.Equal(t, "bar", m.StringV("foo"), "File loaded")
+
+	_, err = FromJsonFile(file, WithEd25519Signature(other, signature))
+	assert.Equal(t, ErrInvalidSignature, err, "Other key refused")
+	_, err = FromFile(file, WithEd25519Signature(public, signature[1:]))
+	assert.True(t, errors.Is(err, ErrInvalidSignature), "Broken signature refused")
+	_, err = FromFile(file, WithEd25519Signature(nil, signature))
+	assert.True(t, errors.Is(err, ErrInvalidSignature), "Missing key refused")
+
+	ioutil.WriteFile(file, []byte(`{"foo":"baz"}`), 0644)
+	_, err = FromJsonFile(file, WithEd25519Signature(public, signature))
+	assert.Equal(t, ErrInvalidSignature, err, "Modified document refused")
+}
+
+func TestWithVerifier(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"foo":"bar"}`))
+	}))
+	defer srv.Close()
+
+	var verified []byte
+	_, err := FromURL(srv.URL, WithVerifier(func(data []byte) error {
+		verified = data
+		return nil
+	}))
+	assert.Nil(t, err, "No error on verified document")
+	assert.Equal(t, `{"foo":"bar"}`, string(verified), "Raw bytes verified")
+
+	refused := errors.New("Refused")
+	_, err = FromURL(srv.URL, WithVerifier(func([]byte) error { return refused }))
+	assert.Equal(t, refused, err, "Error of verifier returned")
+}
+
+func TestWithEd25519SignatureLoaders(t *testing.T) {
+	public, private, _ := ed25519.GenerateKey(nil)
+	docs := map[string][]byte{
+		"json": []byte(`{"foo":"bar"}`),
+		"jsonc": []byte(`{"foo":"bar", // comment
+		}`),
+		"yaml": []byte("foo: bar\n"),
+		"xml":  []byte(`<foo>bar</foo>`),
+	}
+	loaders := map[string]func([]byte, ...Option) (*MapPath, error){
+		"json":  FromJson,
+		"jsonc": FromJsonc,
+		"yaml":  FromYaml,
+		"xml":   FromXml,
+	}
+	for name, load := range loaders {
+		signature := ed25519.Sign(private, docs[name])
+		_, err := load(docs[name], WithEd25519Signature(public, signature))
+		assert.Nil(t, err, "Signed document loaded by "+name)
+		tampered := append([]byte(" "), docs[name]...)
+		_, err = load(tampered, WithEd25519Signature(public, signature))
+		assert.Equal(t, ErrInvalidSignature, err, "Tampered document refused by "+name)
+	}
+
+	dir, _ := ioutil.TempDir("", "mappath")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "config.jsonc")
+	ioutil.WriteFile(file, docs["jsonc"], 0644)
+	signature := ed25519.Sign(private, docs["jsonc"])
+	_, err := FromJsoncFile(file, WithEd25519Signature(public, signature))
+	assert.Nil(t, err, "Signed file loaded")
+	ioutil.WriteFile(file, []byte(`{"foo":"baz"}`), 0644)
+	_, err = FromJsoncFile(file, WithEd25519Signature(public, signature))
+	assert.Equal(t, ErrInvalidSignature, err, "Tampered file refused")
+
+	calls := 0
+	_, err = FromJsoncFile(file, WithVerifier(func([]byte) error {
+		calls++
+		return nil
+	}))
+	assert.Nil(t, err, "No error on verified document")
+	assert.Equal(t, 1, calls, "Document verified once")
+}
@@ -0,0 +1,139 @@
+//go:build go1.21
+// +build go1.21
+
+package mappath
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// SlogLevel returns the slog.Level of path. Strings are the level names (case insensitive) "debug",
+// "info", "warn" (or "warning") and "error", with an optional offset like "info+2". Numbers are used as
+// is. If the value cannot be converted then an InvalidTypeError is returned. Like all slog integration it
+// requires Go 1.21 or later.
+func (this *MapPath) SlogLevel(path string, fallback ...slog.Level) (slog.Level, error) {
+	val, err := this.Get(path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return 0, err
+	} else if val == nil {
+		return 0, NullValueError(path)
+	}
+	if s, ok := val.(string); ok {
+		s = strings.TrimSpace(s)
+		if strings.HasPrefix(strings.ToLower(s), "warning") {
+			s = "warn" + s[len("warning"):]
+		}
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(s)); err != nil {
+			return 0, &InvalidTypeError{val, "slog level"}
+		}
+		return level, nil
+	}
+	kind := reflect.ValueOf(val).Kind()
+	i, err := exactInteger(val, this.opts())
+	if err != nil || !isOfKind(kind, kindsInt) && !isOfKind(kind, kindsFloat) {
+		return 0, &InvalidTypeError{val, "slog level"}
+	}
+	return slog.Level(i), nil
+}
+
+// SlogLevelV returns the slog.Level of path. If value cannot be converted then fallback or slog.LevelInfo is returned. Handy in single value context.
+func (this *MapPath) SlogLevelV(path string, fallback ...slog.Level) slog.Level {
+	if val, err := this.SlogLevel(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return slog.LevelInfo
+	} else {
+		return val
+	}
+}
+
+// LoggerConfig is the logging configuration of an application, see MapPath.LoggerConfig
+type LoggerConfig struct {
+	// Level is the minimum level of logged records
+	Level slog.Level
+	// Format is either "text" or "json"
+	Format string
+	// Output is "stderr", "stdout" or the path of a file the logs are appended to
+	Output string
+	// AddSource adds the source code position of the log statement to the records
+	AddSource bool
+}
+
+// LoggerConfig returns the logging configuration of the map at path. All keys are optional:
+//
+//	level     minimum level, see SlogLevel, defaults to "info"
+//	format    "text" (default) or "json"
+//	output    "stderr" (default), "stdout" or the path of a file
+//	source    whether to add the source code position, defaults to false
+//
+// Use its Handler to create the slog.Handler:
+//
+//	cfg, err := m.LoggerConfig("log")
+//	handler, closer, err := cfg.Handler()
+//	defer closer.Close()
+//	slog.SetDefault(slog.New(handler))
+func (this *MapPath) LoggerConfig(path string) (*LoggerConfig, error) {
+	child, err := this.Child(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &LoggerConfig{}
+	if cfg.Level, err = child.SlogLevel("level", slog.LevelInfo); err != nil {
+		return nil, fmt.Errorf("Invalid logger configuration \"%s\": %s", path, err)
+	} else if cfg.Format, err = child.String("format", "text"); err != nil {
+		return nil, fmt.Errorf("Invalid logger configuration \"%s\": %s", path, err)
+	} else if cfg.Output, err = child.String("output", "stderr"); err != nil {
+		return nil, fmt.Errorf("Invalid logger configuration \"%s\": %s", path, err)
+	} else if cfg.AddSource, err = child.Bool("source", false); err != nil {
+		return nil, fmt.Errorf("Invalid logger configuration \"%s\": %s", path, err)
+	}
+	cfg.Format = strings.ToLower(cfg.Format)
+	if cfg.Format != "text" && cfg.Format != "json" {
+		return nil, fmt.Errorf("Invalid logger configuration \"%s\": unsupported format \"%s\"", path, cfg.Format)
+	}
+	return cfg, nil
+}
+
+// HandlerOptions returns the options of slog handlers
+func (this *LoggerConfig) HandlerOptions() *slog.HandlerOptions {
+	return &slog.HandlerOptions{Level: this.Level, AddSource: this.AddSource}
+}
+
+// Handler returns a new slog.Handler writing to the output. The returned io.Closer closes the output
+// file, if any, and must be called once the handler is not used anymore.
+func (this *LoggerConfig) Handler() (slog.Handler, io.Closer, error) {
+	var out io.Writer
+	var closer io.Closer = nopCloser{}
+	switch this.Output {
+	case "", "stderr":
+		out = os.Stderr
+	case "stdout":
+		out = os.Stdout
+	default:
+		file, err := os.OpenFile(this.Output, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			return nil, nil, err
+		}
+		out, closer = file, file
+	}
+	if this.Format == "json" {
+		return slog.NewJSONHandler(out, this.HandlerOptions()), closer, nil
+	}
+	return slog.NewTextHandler(out, this.HandlerOptions()), closer, nil
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,82 @@
+//go:build go1.21
+// +build go1.21
+
+package mappath
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+/*
+ * -------
+ * Slog
+ * -------
+ */
+
+var slogLevelTests = []struct {
+	val      interface{}
+	expected slog.Level
+	err      bool
+}{
+	{"debug", slog.LevelDebug, false},
+	{"INFO", slog.LevelInfo, false},
+	{"warn", slog.LevelWarn, false},
+	{"Warning", slog.LevelWarn, false},
+	{"error", slog.LevelError, false},
+	{"info+2", slog.LevelInfo + 2, false},
+	{-4, slog.LevelDebug, false},
+	{"verbose", 0, true},
+	{1.5, 0, true},
+	{true, 0, true},
+}
+
+func TestSlogLevel(t *testing.T) {
+	for _, test := range slogLevelTests {
+		m := NewMapPath(map[string]interface{}{"level": test.val})
+		level, err := m.SlogLevel("level")
+		assert.Equal(t, test.err, err != nil, "Error of %v", test.val)
+		assert.Equal(t, test.expected, level, "Level of %v", test.val)
+	}
+	m := NewMapPath(map[string]interface{}{})
+	assert.Equal(t, slog.LevelWarn, m.SlogLevelV("missing", slog.LevelWarn), "Fallback used")
+	assert.Equal(t, slog.LevelInfo, m.SlogLevelV("missing"), "Info by default")
+}
+
+func TestLoggerConfig(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "mappath")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "app.log")
+	m := NewMapPath(map[string]interface{}{
+		"log":     map[string]interface{}{"level": "warn", "format": "JSON", "output": file, "source": true},
+		"empty":   map[string]interface{}{},
+		"invalid": map[string]interface{}{"format": "xml"},
+	})
+
+	cfg, err := m.LoggerConfig("log")
+	assert.Nil(t, err, "No error on valid configuration")
+	assert.Equal(t, &LoggerConfig{Level: slog.LevelWarn, Format: "json", Output: file, AddSource: true}, cfg, "Configuration")
+	handler, closer, err := cfg.Handler()
+	assert.Nil(t, err, "No error creating handler")
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelInfo), "Level applied")
+	slog.New(handler).Warn("hello", "key", "value")
+	closer.Close()
+	written, _ := ioutil.ReadFile(file)
+	assert.True(t, strings.Contains(string(written), `"msg":"hello","key":"value"`), "JSON written to file")
+
+	cfg, err = m.LoggerConfig("empty")
+	assert.Nil(t, err, "No error on empty configuration")
+	assert.Equal(t, &LoggerConfig{Level: slog.LevelInfo, Format: "text", Output: "stderr"}, cfg, "Defaults")
+
+	_, err = m.LoggerConfig("invalid")
+	assert.NotNil(t, err, "Unsupported format")
+	cfg.Output = filepath.Join(dir, "missing", "app.log")
+	_, _, err = cfg.Handler()
+	assert.NotNil(t, err, "Output cannot be opened")
+}
@@ -0,0 +1,107 @@
+package mappath
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Source is anything a MapPath can be loaded from
+type Source interface {
+	Load(ctx context.Context) (*MapPath, error)
+}
+
+// SourceFunc is an adapter to use ordinary functions as Source
+type SourceFunc func(ctx context.Context) (*MapPath, error)
+
+// Load calls the function
+func (this SourceFunc) Load(ctx context.Context) (*MapPath, error) {
+	return this(ctx)
+}
+
+// FileSource is a Source of a JSON file
+type FileSource string
+
+// Load reads and parses the file, unless the context is already done
+func (this FileSource) Load(ctx context.Context) (*MapPath, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return FromJsonFile(string(this))
+}
+
+// URLSource is a Source of a JSON document served via HTTP(S)
+type URLSource string
+
+// Load fetches and parses the document
+func (this URLSource) Load(ctx context.Context) (*MapPath, error) {
+	return FromURLContext(ctx, string(this))
+}
+
+// FromURL is a factory method to create a MapPath from a JSON document fetched with a GET request
+func FromURL(url string, opts ...Option) (*MapPath, error) {
+	return FromURLContext(context.Background(), url, opts...)
+}
+
+// FromURLContext is a factory method to create a MapPath from a JSON document fetched with a GET
+// request, which is aborted if the context is cancelled or its deadline exceeds
+func FromURLContext(ctx context.Context, url string, opts ...Option) (*MapPath, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("Cannot load \"%s\": %s", url, res.Status)
+	}
+	in, err := readDocument(res.Body, newOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return FromJson(in, verified(opts)...)
+}
+
+// WatchFile loads the JSON file and calls fn with the result. Afterwards the file is checked every
+// interval and reloaded when its modification time or size changes. Blocks until the context is done
+// and returns its error then. An interval which is not positive is refused with an error right away.
+func WatchFile(ctx context.Context, file string, interval time.Duration, fn func(*MapPath, error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("Watch interval must be positive, got %s", interval)
+	}
+	var lastMod time.Time
+	var lastSize int64 = -1
+	check := func() {
+		info, err := os.Stat(file)
+		if err != nil {
+			if lastSize != -2 {
+				lastSize = -2
+				fn(nil, err)
+			}
+			return
+		}
+		if info.ModTime().Equal(lastMod) && info.Size() == lastSize {
+			return
+		}
+		lastMod, lastSize = info.ModTime(), info.Size()
+		fn(FileSource(file).Load(ctx))
+	}
+
+	check()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			check()
+		}
+	}
+}
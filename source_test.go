@@ -0,0 +1,141 @@
+package mappath
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * URL
+ * -------
+ */
+
+func sourceTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok.json":
+			fmt.Fprint(w, `{"foo":"bar"}`)
+		case "/slow.json":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			fmt.Fprint(w, `{"foo":"bar"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestFromURL(t *testing.T) {
+	srv := sourceTestServer()
+	defer srv.Close()
+
+	m, err := FromURL(srv.URL + "/ok.json")
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, "bar", m.StringV("foo"), "Document loaded")
+
+	m, err = FromURL(srv.URL + "/missing.json")
+	assert.NotNil(t, err, "Error on status code")
+	assert.Nil(t, m, "No result returned")
+}
+
+func TestFromURLMaxBytes(t *testing.T) {
+	srv := sourceTestServer()
+	defer srv.Close()
+
+	_, err := FromURL(srv.URL+"/ok.json", WithMaxBytes(5))
+	_, ok := err.(*LimitError)
+	assert.True(t, ok, "Limit error returned")
+	m, err := FromURL(srv.URL+"/ok.json", WithMaxBytes(13))
+	assert.Nil(t, err, "No error within limit")
+	assert.Equal(t, "bar", m.StringV("foo"), "Document loaded")
+}
+
+func TestFromURLContextCancel(t *testing.T) {
+	srv := sourceTestServer()
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	m, err := URLSource(srv.URL + "/slow.json").Load(ctx)
+	assert.NotNil(t, err, "Error on exceeded deadline")
+	assert.Nil(t, m, "No result returned")
+}
+
+/*
+ * -------
+ * Source
+ * -------
+ */
+
+func TestFileSource(t *testing.T) {
+	m, err := FileSource("resources/ok.json").Load(context.Background())
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, "bar", m.StringV("foo"), "File loaded")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = FileSource("resources/ok.json").Load(ctx)
+	assert.Equal(t, context.Canceled, err, "Cancelled context is respected")
+}
+
+func TestSourceFunc(t *testing.T) {
+	var src Source = SourceFunc(func(ctx context.Context) (*MapPath, error) {
+		return NewMapPath(map[string]interface{}{"foo": "bar"}), nil
+	})
+	m, err := src.Load(context.Background())
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, "bar", m.StringV("foo"), "Function called")
+}
+
+/*
+ * -------
+ * WatchFile
+ * -------
+ */
+
+func TestWatchFile(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "mappath")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "config.json")
+	ioutil.WriteFile(file, []byte(`{"foo":"one"}`), 0644)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	values := make(chan string, 10)
+	done := make(chan error)
+	go func() {
+		done <- WatchFile(ctx, file, 5*time.Millisecond, func(m *MapPath, err error) {
+			if err == nil {
+				values <- m.StringV("foo")
+			}
+		})
+	}()
+
+	assert.Equal(t, "one", <-values, "Initial load reported")
+	ioutil.WriteFile(file, []byte(`{"foo":"three"}`), 0644)
+	assert.Equal(t, "three", <-values, "Change reported")
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done, "Watch stops on cancel")
+}
+
+func TestWatchFileInterval(t *testing.T) {
+	calls := 0
+	for _, interval := range []time.Duration{0, -time.Second} {
+		err := WatchFile(context.Background(), "missing.json", interval, func(m *MapPath, err error) {
+			calls++
+		})
+		assert.NotNil(t, err, "Error for interval "+interval.String())
+	}
+	assert.Equal(t, 0, calls, "Nothing loaded")
+}
@@ -0,0 +1,77 @@
+package mappath
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RowsKey is the root key under which FromRows stores the array of rows
+const RowsKey = "rows"
+
+// FromRows is a factory method to create a MapPath from SQL query results. All rows are read and
+// stored as an array of maps, keyed by column names, under RowsKey. Columns with a database type of
+// JSON or JSONB and all columns named in jsonColumns are JSON decoded. Byte values of other columns
+// become strings and int64 values become int. The rows are not closed.
+func FromRows(rows *sql.Rows, jsonColumns ...string) (*MapPath, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	decode := make([]bool, len(columns))
+	for i, column := range columns {
+		for _, name := range jsonColumns {
+			if name == column {
+				decode[i] = true
+			}
+		}
+	}
+	if types, err := rows.ColumnTypes(); err == nil {
+		for i, typ := range types {
+			switch strings.ToUpper(typ.DatabaseTypeName()) {
+			case "JSON", "JSONB":
+				decode[i] = true
+			}
+		}
+	}
+
+	result := []interface{}{}
+	values := make([]interface{}, len(columns))
+	scan := make([]interface{}, len(columns))
+	for i := range values {
+		scan[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(scan...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]interface{})
+		for i, column := range columns {
+			val := values[i]
+			switch v := val.(type) {
+			case []byte:
+				val = string(v)
+			case int64:
+				// drivers return int64, getters work with int
+				if int64(int(v)) == v {
+					val = int(v)
+				}
+			}
+			if s, ok := val.(string); ok && decode[i] {
+				var data interface{}
+				if err := json.Unmarshal([]byte(s), &data); err != nil {
+					return nil, fmt.Errorf("Cannot decode JSON of column \"%s\": %s", column, err)
+				}
+				val = data
+			}
+			row[column] = val
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewMapPath(map[string]interface{}{RowsKey: result}), nil
+}
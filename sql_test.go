@@ -0,0 +1,98 @@
+package mappath
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+/*
+ * -------
+ * Fake SQL driver returning a fixed result set
+ * -------
+ */
+
+type fakeSqlDriver struct{}
+type fakeSqlConn struct{}
+type fakeSqlStmt struct{}
+type fakeSqlRows struct {
+	idx int
+}
+
+var fakeSqlColumns = []string{"id", "name", "meta", "tags"}
+var fakeSqlTypes = []string{"INT", "TEXT", "JSONB", "TEXT"}
+var fakeSqlData = [][]driver.Value{
+	{int64(1), []byte("alice"), []byte(`{"admin":true}`), []byte(`["a","b"]`)},
+	{int64(2), "bob", nil, []byte(`[]`)},
+}
+
+func (d fakeSqlDriver) Open(name string) (driver.Conn, error) { return fakeSqlConn{}, nil }
+
+func (c fakeSqlConn) Prepare(query string) (driver.Stmt, error) { return fakeSqlStmt{}, nil }
+func (c fakeSqlConn) Close() error                              { return nil }
+func (c fakeSqlConn) Begin() (driver.Tx, error)                 { return nil, errors.New("no tx") }
+
+func (s fakeSqlStmt) Close() error  { return nil }
+func (s fakeSqlStmt) NumInput() int { return -1 }
+func (s fakeSqlStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("no exec")
+}
+func (s fakeSqlStmt) Query(args []driver.Value) (driver.Rows, error) { return &fakeSqlRows{}, nil }
+
+func (r *fakeSqlRows) Columns() []string { return fakeSqlColumns }
+func (r *fakeSqlRows) Close() error      { return nil }
+func (r *fakeSqlRows) ColumnTypeDatabaseTypeName(i int) string {
+	return fakeSqlTypes[i]
+}
+func (r *fakeSqlRows) Next(dest []driver.Value) error {
+	if r.idx >= len(fakeSqlData) {
+		return io.EOF
+	}
+	copy(dest, fakeSqlData[r.idx])
+	r.idx++
+	return nil
+}
+
+func init() {
+	sql.Register("mappath-fake", fakeSqlDriver{})
+}
+
+/*
+ * -------
+ * FromRows
+ * -------
+ */
+
+func TestFromRows(t *testing.T) {
+	db, _ := sql.Open("mappath-fake", "")
+	defer db.Close()
+	rows, err := db.Query("SELECT")
+	assert.Nil(t, err, "Query succeeded")
+	defer rows.Close()
+
+	m, err := FromRows(rows, "tags")
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, 1, m.IntV("rows/0/id"), "Int column read")
+	assert.Equal(t, "alice", m.StringV("rows/0/name"), "Byte column becomes string")
+	assert.Equal(t, true, m.BoolV("rows/0/meta/admin"), "JSONB column decoded automatically")
+	assert.Equal(t, []string{"a", "b"}, m.StringsV("rows/0/tags"), "Named JSON column decoded")
+	assert.Equal(t, "bob", m.StringV("rows/1/name"), "Second row read")
+	assert.True(t, m.Has("rows/1/meta"), "NULL column is kept")
+	subs, err := m.Childs("rows")
+	assert.Nil(t, err, "Rows are array of maps")
+	assert.Equal(t, 2, len(subs), "All rows read")
+}
+
+func TestFromRowsInvalidJson(t *testing.T) {
+	db, _ := sql.Open("mappath-fake", "")
+	defer db.Close()
+	rows, _ := db.Query("SELECT")
+	defer rows.Close()
+
+	m, err := FromRows(rows, "name")
+	assert.NotNil(t, err, "Error returned on invalid JSON column")
+	assert.Nil(t, m, "No result returned")
+}
@@ -0,0 +1,30 @@
+package mappath
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// StringTemplate treats the string value of path as text/template and returns the result of the
+// execution with data, eg "log/{{.Hostname}}.log". Values of the structure itself are accessible
+// with the template function "path", eg "{{path \"log/dir\"}}/app.log".
+func (this *MapPath) StringTemplate(path string, data interface{}) (string, error) {
+	str, err := this.String(path)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New(path).Option("missingkey=error").Funcs(template.FuncMap{
+		"path": func(p string) (interface{}, error) {
+			return this.Get(p)
+		},
+	}).Parse(str)
+	if err != nil {
+		return "", fmt.Errorf("Cannot parse template of path \"%s\": %s", path, err)
+	}
+	buf := new(bytes.Buffer)
+	if err = tmpl.Execute(buf, data); err != nil {
+		return "", fmt.Errorf("Cannot execute template of path \"%s\": %s", path, err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,44 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * StringTemplate
+ * -------
+ */
+
+var templateTest = map[string]interface{}{
+	"log": map[string]interface{}{
+		"dir":  "/var/log",
+		"file": "{{path \"log/dir\"}}/{{.Hostname}}.log",
+	},
+	"broken":  "{{.Hostname",
+	"missing": "{{path \"log/missing\"}}",
+	"number":  123,
+}
+
+func TestStringTemplate(t *testing.T) {
+	m := NewMapPath(templateTest)
+	data := map[string]string{"Hostname": "web-1"}
+	s, err := m.StringTemplate("log/file", data)
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, "/var/log/web-1.log", s, "Template rendered")
+
+	s, err = m.StringTemplate("number", nil)
+	assert.Nil(t, err, "No error for non template values")
+	assert.Equal(t, "123", s, "Value returned as is")
+
+	_, err = m.StringTemplate("broken", data)
+	assert.NotNil(t, err, "Error on invalid template")
+	_, err = m.StringTemplate("missing", data)
+	assert.NotNil(t, err, "Error on missing path")
+	_, err = m.StringTemplate("log/file", map[string]string{})
+	assert.NotNil(t, err, "Error on missing data key")
+	_, err = m.StringTemplate("nope", data)
+	_, ok := err.(NotFoundError)
+	assert.True(t, ok, "Not found error returned")
+}
@@ -0,0 +1,27 @@
+package mappath
+
+// TenantsKey and DefaultsKey are the keys of the tenant specific and the default sections, see Tenant
+const (
+	TenantsKey  = "tenants"
+	DefaultsKey = "defaults"
+)
+
+// Tenant returns the sub structure "tenants/<id>", in which every path which is missing falls back
+// to the same path within "defaults", eg Get("db/host") returns "tenants/<id>/db/host" if it exists
+// and "defaults/db/host" otherwise. Maps are not merged: Map("db") returns the map of the tenant, if
+// it exists. Sub structures (eg Child("db")) keep falling back to the respective defaults. If the
+// tenant does not exist then the defaults are used entirely. The id is a single key, "/" within it is
+// not a path separator.
+func (this *MapPath) Tenant(id string) *MapPath {
+	if this == nil {
+		this = empty
+	}
+	tenant, err := this.Child(TenantsKey + "/" + escapeKey(id))
+	if err != nil {
+		tenant = this.child(map[string]interface{}{})
+	}
+	if defaults, err := this.Child(DefaultsKey); err == nil {
+		tenant.fallback = defaults
+	}
+	return tenant
+}
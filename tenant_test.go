@@ -0,0 +1,49 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Tenant
+ * -------
+ */
+
+var tenantTest = map[string]interface{}{
+	"defaults": map[string]interface{}{
+		"plan": "free",
+		"db":   map[string]interface{}{"host": "shared", "pool": 5},
+	},
+	"tenants": map[string]interface{}{
+		"acme": map[string]interface{}{
+			"plan": "enterprise",
+			"db":   map[string]interface{}{"host": "acme-db"},
+		},
+	},
+}
+
+func TestTenant(t *testing.T) {
+	m := NewMapPath(tenantTest)
+	acme := m.Tenant("acme")
+	assert.Equal(t, "enterprise", acme.StringV("plan"), "Tenant value used")
+	assert.Equal(t, "acme-db", acme.StringV("db/host"), "Nested tenant value used")
+	assert.Equal(t, 5, acme.IntV("db/pool"), "Default used per path")
+	assert.Equal(t, 5, acme.ChildV("db").IntV("pool"), "Sub structures fall back to defaults")
+	assert.False(t, acme.Has("missing"), "Missing in both")
+
+	other := m.Tenant("other")
+	assert.Equal(t, "free", other.StringV("plan"), "Defaults used for unknown tenant")
+	assert.Equal(t, "shared", other.StringV("db/host"), "Nested defaults used for unknown tenant")
+
+	slashed := NewMapPath(map[string]interface{}{"tenants": map[string]interface{}{
+		"org/a": map[string]interface{}{"x": 1},
+		"org":   map[string]interface{}{"a": map[string]interface{}{"x": 2}},
+	}})
+	assert.Equal(t, 1, slashed.Tenant("org/a").IntV("x"), "Id used as single key")
+
+	plain := NewMapPath(map[string]interface{}{"tenants": map[string]interface{}{"a": map[string]interface{}{"x": 1}}})
+	assert.Equal(t, 1, plain.Tenant("a").IntV("x"), "Works without defaults")
+	assert.False(t, plain.Tenant("a").Has("y"), "Nothing to fall back to")
+}
@@ -0,0 +1,167 @@
+package mappath
+
+import (
+	"reflect"
+	"time"
+)
+
+// Clock provides the current time to the time arithmetic helpers (eg TimeSince). It can be replaced
+// with WithClock, eg to use a fixed time in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc is an adapter to use ordinary functions as Clock
+type ClockFunc func() time.Time
+
+// Now calls the function
+func (this ClockFunc) Now() time.Time {
+	return this()
+}
+
+// timeLayouts are the accepted layouts of time strings, in order of precedence
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// Time returns time.Time value of path. Strings are parsed as RFC 3339 (eg "2006-01-02T15:04:05Z07:00")
+// or date ("2006-01-02"), numbers are interpreted as unix timestamp in seconds. If value cannot be parsed
+// or converted then an InvalidTypeError is returned
+func (this *MapPath) Time(path string, fallback ...time.Time) (time.Time, error) {
+	val, err := this.Get(path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return time.Time{}, err
+	} else if val == nil {
+		return time.Time{}, NullValueError(path)
+	}
+
+	if t, ok := toTime(val); ok {
+		return t, nil
+	}
+	return time.Time{}, &InvalidTypeError{val, "time"}
+}
+
+// toTime converts time values, strings and numbers (see Time) into time.Time
+func toTime(val interface{}) (time.Time, bool) {
+	switch v := val.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	case nil:
+	default:
+		switch kind := reflect.ValueOf(val).Kind(); {
+		case isOfKind(kind, kindsInt), isOfKind(kind, kindsFloat):
+			f, _ := toFloat(val)
+			sec := int64(f)
+			return time.Unix(sec, int64((f-float64(sec))*1e9)).UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// TimeV returns time.Time value of path. If value cannot be parsed or converted then fallback or zero time is returned. Handy in single value context.
+func (this *MapPath) TimeV(path string, fallback ...time.Time) time.Time {
+	if val, err := this.Time(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return time.Time{}
+	} else {
+		return val
+	}
+}
+
+// Duration returns time.Duration value of path. Strings are parsed with time.ParseDuration (eg "1m30s"),
+// numbers are interpreted as seconds. If value cannot be parsed or converted then an InvalidTypeError is returned
+func (this *MapPath) Duration(path string, fallback ...time.Duration) (time.Duration, error) {
+	val, err := this.Get(path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return 0, err
+	} else if val == nil {
+		return 0, NullValueError(path)
+	}
+
+	if d, ok := toDuration(val); ok {
+		return d, nil
+	}
+	return 0, &InvalidTypeError{val, "duration"}
+}
+
+// toDuration converts duration strings (see time.ParseDuration) and numbers of seconds into time.Duration
+func toDuration(val interface{}) (time.Duration, bool) {
+	switch v := val.(type) {
+	case time.Duration:
+		return v, true
+	case string:
+		if d, err := time.ParseDuration(v); err == nil {
+			return d, true
+		}
+	default:
+		switch kind := reflect.ValueOf(val).Kind(); {
+		case isOfKind(kind, kindsInt), isOfKind(kind, kindsFloat):
+			f, _ := toFloat(val)
+			return time.Duration(f * float64(time.Second)), true
+		}
+	}
+	return 0, false
+}
+
+// DurationV returns time.Duration value of path. If value cannot be parsed or converted then fallback or 0 is returned. Handy in single value context.
+func (this *MapPath) DurationV(path string, fallback ...time.Duration) time.Duration {
+	if val, err := this.Duration(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return 0
+	} else {
+		return val
+	}
+}
+
+// TimeSince returns the time elapsed since the time value of path (see Time), measured with the
+// Clock of the MapPath. Times in the future result in negative durations.
+func (this *MapPath) TimeSince(path string) (time.Duration, error) {
+	t, err := this.Time(path)
+	if err != nil {
+		return 0, err
+	}
+	return this.now().Sub(t), nil
+}
+
+// DurationUntil returns the time left until the time value of path (see Time), eg an expiration,
+// measured with the Clock of the MapPath. Times in the past result in negative durations.
+func (this *MapPath) DurationUntil(path string) (time.Duration, error) {
+	t, err := this.Time(path)
+	if err != nil {
+		return 0, err
+	}
+	return t.Sub(this.now()), nil
+}
+
+// Expired checks whether the time value of path (see Time) lies in the past. Missing or invalid
+// values count as expired.
+func (this *MapPath) Expired(path string) bool {
+	d, err := this.DurationUntil(path)
+	return err != nil || d <= 0
+}
+
+func (this *MapPath) now() time.Time {
+	if this.opts().clock != nil {
+		return this.opts().clock.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,88 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * Time / Duration
+ * -------
+ */
+
+var timeTestNow = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+var timeTest = map[string]interface{}{
+	"rfc3339":  "2020-06-01T10:00:00Z",
+	"offset":   "2020-06-01T14:00:00+02:00",
+	"date":     "2020-06-02",
+	"unix":     1590998400,
+	"real":     timeTestNow,
+	"duration": "1m30s",
+	"seconds":  90,
+	"fraction": 1.5,
+	"native":   time.Hour,
+	"text":     "foo",
+	"null":     nil,
+}
+
+func TestGetTime(t *testing.T) {
+	m := NewMapPath(timeTest)
+	tests := map[string]time.Time{
+		"rfc3339": time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC),
+		"offset":  time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+		"date":    time.Date(2020, 6, 2, 0, 0, 0, 0, time.UTC),
+		"unix":    time.Date(2020, 6, 1, 8, 0, 0, 0, time.UTC),
+		"real":    timeTestNow,
+	}
+	for path, expect := range tests {
+		r, err := m.Time(path)
+		assert.Nil(t, err, "No error for "+path)
+		assert.True(t, expect.Equal(r), "Expected time for "+path)
+	}
+	_, err := m.Time("text")
+	_, ok := err.(*InvalidTypeError)
+	assert.True(t, ok, "Invalid type error returned")
+	_, err = m.Time("null")
+	_, ok = err.(NullValueError)
+	assert.True(t, ok, "Null value error returned")
+	assert.Equal(t, timeTestNow, m.TimeV("missing", timeTestNow), "Fallback returned")
+}
+
+func TestGetDuration(t *testing.T) {
+	m := NewMapPath(timeTest)
+	tests := map[string]time.Duration{
+		"duration": 90 * time.Second,
+		"seconds":  90 * time.Second,
+		"fraction": 1500 * time.Millisecond,
+		"native":   time.Hour,
+	}
+	for path, expect := range tests {
+		r, err := m.Duration(path)
+		assert.Nil(t, err, "No error for "+path)
+		assert.Equal(t, expect, r, "Expected duration for "+path)
+	}
+	_, err := m.Duration("text")
+	_, ok := err.(*InvalidTypeError)
+	assert.True(t, ok, "Invalid type error returned")
+	assert.Equal(t, time.Minute, m.DurationV("text", time.Minute), "Fallback returned")
+}
+
+func TestTimeArithmetic(t *testing.T) {
+	m := NewMapPath(timeTest, WithClock(ClockFunc(func() time.Time { return timeTestNow })))
+	d, err := m.TimeSince("rfc3339")
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, 2*time.Hour, d, "Time since measured with clock")
+
+	d, err = m.DurationUntil("date")
+	assert.Nil(t, err, "No error returned")
+	assert.Equal(t, 12*time.Hour, d, "Duration until measured with clock")
+
+	assert.True(t, m.Expired("rfc3339"), "Past time expired")
+	assert.False(t, m.Expired("date"), "Future time not expired")
+	assert.True(t, m.Expired("text"), "Invalid time expired")
+	_, err = m.TimeSince("missing")
+	assert.NotNil(t, err, "Error on missing path")
+}
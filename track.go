@@ -0,0 +1,89 @@
+package mappath
+
+import (
+	"reflect"
+	"sync"
+)
+
+type accessKey struct {
+	container uintptr
+	key       string
+}
+
+type accessTracker struct {
+	mu       sync.Mutex
+	accessed map[accessKey]bool
+}
+
+// track records the read access of path
+func (this *MapPath) track(path string) {
+	parts, err := splitPath(path)
+	if err != nil {
+		return
+	}
+	var container interface{} = map[string]interface{}(this.root)
+	if len(parts) > 1 {
+		var found bool
+		if container, found = this.getBranch(parts[:len(parts)-1], this.root); !found {
+			return
+		}
+	}
+	this.opts().tracker.mu.Lock()
+	defer this.opts().tracker.mu.Unlock()
+	this.opts().tracker.accessed[accessKey{containerOf(container), parts[len(parts)-1]}] = true
+}
+
+// UnusedPaths returns all paths of the structure, which were never read since the MapPath was created
+// with WithAccessTracking. Reading a whole map or array with Map or the array getters (eg Strings)
+// counts as reading everything within, while sub structures (eg Child or Childs) only track what is
+// read from them. Only the topmost unused path of a section is returned, eg "db" instead of "db/host" and
+// "db/port" if nothing within "db" was read. Returns nil if access tracking is not enabled.
+func (this *MapPath) UnusedPaths() []string {
+	if this == nil {
+		this = empty
+	}
+	if this.opts().tracker == nil {
+		return nil
+	}
+	this.opts().tracker.mu.Lock()
+	defer this.opts().tracker.mu.Unlock()
+	result := []string{}
+	this.unused(map[string]interface{}(this.root), "", &result)
+	return result
+}
+
+// unused collects the unused paths within the container and reports whether any path was used
+func (this *MapPath) unused(container interface{}, prefix string, result *[]string) bool {
+	keys, values := childrenOf(container)
+	used := false
+	var unused []string
+	for i, key := range keys {
+		path := joinPath(prefix, key)
+		if this.opts().tracker.accessed[accessKey{containerOf(container), key}] {
+			used = true
+		} else if _, children := childrenOf(values[i]); len(children) > 0 {
+			var sub []string
+			if this.unused(values[i], path, &sub) {
+				used = true
+				unused = append(unused, sub...)
+			} else {
+				unused = append(unused, path)
+			}
+		} else {
+			unused = append(unused, path)
+		}
+	}
+	if used || prefix == "" {
+		*result = append(*result, unused...)
+	}
+	return used
+}
+
+func containerOf(val interface{}) uintptr {
+	ref := reflect.ValueOf(val)
+	switch ref.Kind() {
+	case reflect.Map, reflect.Slice:
+		return ref.Pointer()
+	}
+	return 0
+}
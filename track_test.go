@@ -0,0 +1,43 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * UnusedPaths
+ * -------
+ */
+
+func trackTest() map[string]interface{} {
+	return map[string]interface{}{
+		"server": map[string]interface{}{"host": "localhost", "port": 80, "legacy": true},
+		"db":     map[string]interface{}{"host": "db", "port": 5432},
+		"log":    map[string]interface{}{"level": "info"},
+		"users":  []interface{}{map[string]interface{}{"name": "a", "old": 1}},
+		"name":   "app",
+		"tags":   []interface{}{"a", "b"},
+	}
+}
+
+func TestUnusedPaths(t *testing.T) {
+	m := NewMapPath(trackTest(), WithAccessTracking())
+	m.StringV("server/host")
+	m.IntV("server/port")
+	m.Map("log")
+	m.ChildsV("users")[0].StringV("name")
+	m.StringsV("tags")
+	m.StringV("missing/path")
+	assert.Equal(t, []string{"db", "name", "server/legacy", "users/0/old"}, m.UnusedPaths(), "Unused paths reported")
+
+	m.ChildV("db").StringV("host")
+	assert.Equal(t, []string{"db/port", "name", "server/legacy", "users/0/old"}, m.UnusedPaths(), "Access of sub structures tracked")
+}
+
+func TestUnusedPathsDisabled(t *testing.T) {
+	m := NewMapPath(trackTest())
+	m.StringV("name")
+	assert.Nil(t, m.UnusedPaths(), "Nothing reported without tracking")
+}
@@ -0,0 +1,36 @@
+package mappath
+
+import (
+	"strings"
+)
+
+// StringTrimmed returns string value of path, like String, with surrounding whitespace and a pair of surrounding
+// quotes (" or ') removed, eg ` "value" ` becomes `value`. See WithTrimStrings to apply this to all strings.
+func (this *MapPath) StringTrimmed(path string, fallback ...string) (string, error) {
+	val, err := this.String(path, fallback...)
+	if err != nil {
+		return "", err
+	}
+	return trimString(val), nil
+}
+
+// StringTrimmedV returns trimmed string value of path. If value cannot be parsed or converted then fallback or "" is returned. Handy in single value context.
+func (this *MapPath) StringTrimmedV(path string, fallback ...string) string {
+	if val, err := this.StringTrimmed(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return ""
+	} else {
+		return val
+	}
+}
+
+// trimString removes surrounding whitespace and a pair of surrounding quotes, including whitespace within them
+func trimString(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	return s
+}
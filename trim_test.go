@@ -0,0 +1,53 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Trimmed strings
+ * -------
+ */
+
+var trimStringTests = []struct {
+	in       string
+	expected string
+}{
+	{"value", "value"},
+	{"  value \t\n", "value"},
+	{`"value"`, "value"},
+	{` 'value' `, "value"},
+	{`" value "`, "value"},
+	{`"value'`, `"value'`},
+	{`"`, `"`},
+	{`""`, ""},
+	{`"a" and "b"`, `a" and "b`},
+	{`""value""`, `"value"`},
+}
+
+func TestTrimString(t *testing.T) {
+	for _, test := range trimStringTests {
+		assert.Equal(t, test.expected, trimString(test.in), "Trimmed "+test.in)
+	}
+}
+
+func TestStringTrimmed(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"host":  " localhost  ",
+		"name":  ` "app" `,
+		"hosts": []interface{}{" a", "'b' "},
+		"port":  8080,
+	})
+	assert.Equal(t, "localhost", m.StringTrimmedV("host"), "Whitespace removed")
+	assert.Equal(t, "app", m.StringTrimmedV("name"), "Quotes removed")
+	assert.Equal(t, "8080", m.StringTrimmedV("port"), "Converted value")
+	assert.Equal(t, "x", m.StringTrimmedV("missing", "x"), "Fallback used")
+	assert.Equal(t, " localhost  ", m.StringV("host"), "String unchanged by default")
+
+	trimmed := m.With(WithTrimStrings())
+	assert.Equal(t, "localhost", trimmed.StringV("host"), "String trimmed with option")
+	assert.Equal(t, []string{"a", "b"}, trimmed.StringsV("hosts"), "Strings trimmed with option")
+	assert.Equal(t, " a", m.StringsV("hosts")[0], "Strings unchanged by default")
+}
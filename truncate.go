@@ -0,0 +1,49 @@
+package mappath
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Ellipsis is appended to strings truncated with WithMaxStringBytes
+const Ellipsis = "…"
+
+// WithMaxStringBytes makes String and Strings truncate string values longer than max bytes, so that
+// the result, including the appended Ellipsis, has at most max bytes. Strings are cut at character
+// boundaries. If report is not nil, it is called with the path (eg "names/3" for array elements) and the
+// original size of each truncated string, eg to log them. The structure itself is not modified.
+func WithMaxStringBytes(max int, report func(path string, size int)) Option {
+	return func(o *options) {
+		o.maxStringBytes = max
+		o.truncated = report
+	}
+}
+
+// truncateString caps the string value of path, see WithMaxStringBytes
+func (this *options) truncateString(path string, s string) string {
+	if this.maxStringBytes <= 0 || len(s) <= this.maxStringBytes {
+		return s
+	}
+	if this.truncated != nil {
+		this.truncated(path, len(s))
+	}
+	suffix := Ellipsis
+	if this.maxStringBytes < len(suffix) {
+		suffix = ""
+	}
+	cut := this.maxStringBytes - len(suffix)
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + suffix
+}
+
+// truncateStrings caps the elements of the array of path, see WithMaxStringBytes
+func (this *options) truncateStrings(path string, strs []string) {
+	if this.maxStringBytes <= 0 {
+		return
+	}
+	for i := range strs {
+		strs[i] = this.truncateString(fmt.Sprintf("%s/%d", path, i), strs[i])
+	}
+}
@@ -0,0 +1,57 @@
+package mappath
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+/*
+ * -------
+ * Truncation
+ * -------
+ */
+
+var truncateStringTests = []struct {
+	max      int
+	val      string
+	expected string
+}{
+	{10, "short", "short"},
+	{10, "exactly 10", "exactly 10"},
+	{10, "longer than ten", "longer " + Ellipsis},
+	{8, "äöüäöü", "äö" + Ellipsis},
+	{7, "äöüäöü", "äö" + Ellipsis},
+	{6, "äöüäöü", "ä" + Ellipsis},
+	{2, "abc", "ab"},
+	{2, "äbc", "ä"},
+	{0, strings.Repeat("x", 100), strings.Repeat("x", 100)},
+}
+
+func TestTruncateString(t *testing.T) {
+	for _, test := range truncateStringTests {
+		o := newOptions([]Option{WithMaxStringBytes(test.max, nil)})
+		result := o.truncateString("path", test.val)
+		assert.Equal(t, test.expected, result, fmt.Sprintf("Truncated %q to %d", test.val, test.max))
+		if test.max > 0 {
+			assert.True(t, len(result) <= test.max, fmt.Sprintf("Size of %q within %d", test.val, test.max))
+		}
+	}
+}
+
+func TestWithMaxStringBytes(t *testing.T) {
+	reported := map[string]int{}
+	m := NewMapPath(map[string]interface{}{
+		"short": "ok",
+		"long":  strings.Repeat("x", 1000),
+		"names": []interface{}{"a", strings.Repeat("y", 20)},
+	}, WithMaxStringBytes(10, func(path string, size int) {
+		reported[path] = size
+	}))
+	assert.Equal(t, "ok", m.StringV("short"), "Short string untouched")
+	assert.Equal(t, "xxxxxxx"+Ellipsis, m.StringV("long"), "Long string truncated")
+	assert.Equal(t, []string{"a", "yyyyyyy" + Ellipsis}, m.StringsV("names"), "Long element truncated")
+	assert.Equal(t, map[string]int{"long": 1000, "names/1": 20}, reported, "Truncations reported")
+	assert.Equal(t, 1000, len(m.Root()["long"].(string)), "Structure untouched")
+}
@@ -0,0 +1,138 @@
+package mappath
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrTxDone is returned by the methods of a Tx, which was already committed or rolled back
+var ErrTxDone = errors.New("The transaction is already committed or rolled back")
+
+// Tx is a batch of modifications of a MapPath, which are applied as a unit by Commit, see Begin
+type Tx struct {
+	mu   sync.Mutex
+	m    *MapPath
+	work *MapPath
+	ops  []txOp
+	done bool
+}
+
+// txOp is a modification of a transaction
+type txOp struct {
+	path   string
+	value  interface{}
+	delete bool
+}
+
+// Begin starts a transaction on the structure. Modifications of the transaction (Tx.Set, Tx.Delete)
+// are performed on a copy of the structure, so that errors are returned right away, and only applied
+// to the structure by Tx.Commit, as a single modification (eg reverted by a single Undo). Tx.Rollback
+// discards them.
+func (this *MapPath) Begin() *Tx {
+	if this == nil {
+		this = empty
+	}
+	o := *this.opts()
+	o.changelog, o.history = nil, nil
+	work := deepCopy(map[string]interface{}(this.root)).(map[string]interface{})
+	return &Tx{m: this, work: &MapPath{root: work, config: &o}}
+}
+
+// Get returns the value of path within the transaction, ie with all modifications of the transaction applied
+func (this *Tx) Get(path string, fallback ...interface{}) (interface{}, error) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	if this.done {
+		return nil, ErrTxDone
+	}
+	return this.work.Get(path, fallback...)
+}
+
+// Set stores value at path within the transaction, see MapPath.Set
+func (this *Tx) Set(path string, value interface{}) error {
+	return this.add(txOp{path: path, value: value})
+}
+
+// Delete removes the map key or array element at path within the transaction, see MapPath.Delete
+func (this *Tx) Delete(path string) error {
+	return this.add(txOp{path: path, delete: true})
+}
+
+// add performs the modification on the copy and remembers it for Commit
+func (this *Tx) add(op txOp) error {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	if this.done {
+		return ErrTxDone
+	} else if this.m == empty {
+		return ErrEmptyReadOnly
+	} else if _, err := op.copied().apply(this.work.root); err != nil {
+		return err
+	}
+	this.ops = append(this.ops, op)
+	return nil
+}
+
+// Commit applies all modifications of the transaction to the structure, as a single modification. If the
+// structure was modified since Begin, so that a modification of the transaction fails (eg the parent of
+// a path became a scalar), then nothing is applied, the error is returned and the transaction stays open.
+func (this *Tx) Commit() error {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	if this.done {
+		return ErrTxDone
+	} else if len(this.ops) == 0 {
+		this.done = true
+		return nil
+	}
+
+	// dry run on a copy of the current structure, so that either all modifications are applied or none
+	trial := deepCopy(map[string]interface{}(this.m.root)).(map[string]interface{})
+	for _, op := range this.ops {
+		if _, err := op.copied().apply(trial); err != nil {
+			return err
+		}
+	}
+
+	defer this.m.changed()
+	changes := []Change{}
+	defer func() { this.m.record(changes...) }()
+	for _, op := range this.ops {
+		applied, err := op.apply(this.m.root)
+		changes = append(changes, applied...)
+		if err != nil {
+			return err
+		}
+	}
+	this.done = true
+	return nil
+}
+
+// Rollback discards all modifications of the transaction
+func (this *Tx) Rollback() error {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	if this.done {
+		return ErrTxDone
+	}
+	this.done, this.ops = true, nil
+	return nil
+}
+
+// copied returns the modification with a deep copy of the value, so that dry runs do not modify it
+func (this txOp) copied() txOp {
+	this.value = deepCopy(this.value)
+	return this
+}
+
+// apply performs the modification on root and returns the changes
+func (this txOp) apply(root Branch) ([]Change, error) {
+	if this.delete {
+		return remove(root, this.path, false)
+	}
+	change, err := set(root, this.path, this.value)
+	if err != nil {
+		return nil, err
+	}
+	return []Change{change}, nil
+}
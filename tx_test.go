@@ -0,0 +1,66 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Transactions
+ * -------
+ */
+
+func TestTx(t *testing.T) {
+	m := NewMapPath(mutateTest(), WithHistory(0))
+	tx := m.Begin()
+	assert.Nil(t, tx.Set("foo", "baz"), "Value set")
+	assert.Nil(t, tx.Set("new", map[string]interface{}{}), "Map set")
+	assert.Nil(t, tx.Set("new/sub", 1), "Value set in new map")
+	assert.Nil(t, tx.Delete("map/baz"), "Value deleted")
+	assert.Nil(t, tx.Delete("ints/0"), "Element deleted")
+
+	val, err := tx.Get("new/sub")
+	assert.Nil(t, err, "No error reading within transaction")
+	assert.Equal(t, 1, val, "Value within transaction")
+	assert.Equal(t, mutateTest(), map[string]interface{}(m.Root()), "Structure untouched before commit")
+
+	assert.Equal(t, NotFoundError("missing"), tx.Delete("missing"), "Error returned right away")
+	assert.IsType(t, &InvalidTypeError{}, tx.Set("ints/0", "one"), "Invalid type returned right away")
+
+	assert.Nil(t, tx.Commit(), "Committed")
+	assert.Equal(t, "baz", m.StringV("foo"), "Value applied")
+	assert.Equal(t, map[string]interface{}{"sub": 1}, m.MapV("new"), "Map applied")
+	assert.False(t, m.Has("map/baz"), "Deletion applied")
+	assert.Equal(t, []int{2, 3}, m.IntsV("ints"), "Element deletion applied")
+	assert.Equal(t, ErrTxDone, tx.Commit(), "Committed only once")
+	assert.Equal(t, ErrTxDone, tx.Set("foo", 1), "No modifications after commit")
+
+	assert.Nil(t, m.Undo(), "Undo")
+	assert.Equal(t, mutateTest(), map[string]interface{}(m.Root()), "Transaction reverted as unit")
+}
+
+func TestTxRollback(t *testing.T) {
+	m := NewMapPath(mutateTest())
+	tx := m.Begin()
+	assert.Nil(t, tx.Set("foo", "baz"), "Value set")
+	assert.Nil(t, tx.Rollback(), "Rolled back")
+	assert.Equal(t, "bar", m.StringV("foo"), "Structure untouched")
+	assert.Equal(t, ErrTxDone, tx.Rollback(), "Rolled back only once")
+	_, err := tx.Get("foo")
+	assert.Equal(t, ErrTxDone, err, "No reads after rollback")
+}
+
+func TestTxConflict(t *testing.T) {
+	m := NewMapPath(mutateTest())
+	tx := m.Begin()
+	assert.Nil(t, tx.Set("foo", "baz"), "Value set")
+	assert.Nil(t, tx.Set("map/baz", 2), "Nested value set")
+	assert.Nil(t, m.Set("map", "scalar"), "Structure modified concurrently")
+
+	assert.NotNil(t, tx.Commit(), "Conflicting modification fails")
+	assert.Equal(t, "bar", m.StringV("foo"), "Nothing applied")
+	assert.Nil(t, tx.Rollback(), "Transaction still open")
+
+	assert.Equal(t, ErrEmptyReadOnly, Empty().Begin().Set("foo", 1), "Empty is read only")
+}
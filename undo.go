@@ -0,0 +1,185 @@
+package mappath
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// ErrNothingToUndo is returned by Undo if there is no modification to revert
+var ErrNothingToUndo = errors.New("Nothing to undo")
+
+// ErrNothingToRedo is returned by Redo if there is no reverted modification to re-apply
+var ErrNothingToRedo = errors.New("Nothing to redo")
+
+// history keeps the recorded modifications for Undo and Redo. Each entry contains the changes of one
+// modification, eg all renames of a RenameKey call.
+type history struct {
+	mu   sync.Mutex
+	max  int
+	root Branch
+	undo [][]Change
+	redo [][]Change
+}
+
+// WithHistory makes the MapPath remember the last max modifications (Set, RenameKey, Resolve, ..) done
+// through it, MapPaths created by With or its sub structures, so that they can be reverted with Undo and
+// re-applied with Redo. All modifications are kept if max is 0. Modifications of the underlying root map
+// done without the MapPath are not tracked and may conflict with the history.
+func WithHistory(max int) Option {
+	return func(o *options) {
+		o.history = &history{max: max}
+	}
+}
+
+// attach binds the history to the root of the MapPath it was created with
+func (this *history) attach(root Branch) {
+	if this != nil && this.root == nil {
+		this.root = root
+	}
+}
+
+// push adds the changes of a modification, which invalidates all reverted modifications
+func (this *history) push(changes []Change) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	this.undo = append(this.undo, append([]Change{}, changes...))
+	if this.max > 0 && len(this.undo) > this.max {
+		this.undo = append([][]Change{}, this.undo[len(this.undo)-this.max:]...)
+	}
+	this.redo = nil
+}
+
+// CanUndo returns whether there is a modification which can be reverted with Undo
+func (this *MapPath) CanUndo() bool {
+	if this == nil || this.opts().history == nil {
+		return false
+	}
+	this.opts().history.mu.Lock()
+	defer this.opts().history.mu.Unlock()
+	return len(this.opts().history.undo) > 0
+}
+
+// CanRedo returns whether there is a reverted modification which can be re-applied with Redo
+func (this *MapPath) CanRedo() bool {
+	if this == nil || this.opts().history == nil {
+		return false
+	}
+	this.opts().history.mu.Lock()
+	defer this.opts().history.mu.Unlock()
+	return len(this.opts().history.redo) > 0
+}
+
+// Undo reverts the last modification, see WithHistory. Returns ErrNothingToUndo if there is none. The
+// reversal is recorded in the changelog (see WithChangelog), but not in the history.
+func (this *MapPath) Undo() error {
+	if this == nil || this.opts().history == nil {
+		return ErrNothingToUndo
+	}
+	hist := this.opts().history
+	hist.mu.Lock()
+	defer hist.mu.Unlock()
+	if len(hist.undo) == 0 {
+		return ErrNothingToUndo
+	}
+	changes := hist.undo[len(hist.undo)-1]
+	reverse := make([]Change, 0, len(changes))
+	for i := len(changes) - 1; i >= 0; i-- {
+		reverse = append(reverse, hist.inverse(changes[i]))
+	}
+	if err := this.replay(reverse); err != nil {
+		return err
+	}
+	hist.undo = hist.undo[:len(hist.undo)-1]
+	hist.redo = append(hist.redo, changes)
+	return nil
+}
+
+// Redo re-applies the last modification reverted by Undo. Returns ErrNothingToRedo if there is none, or
+// if a new modification was made since.
+func (this *MapPath) Redo() error {
+	if this == nil || this.opts().history == nil {
+		return ErrNothingToRedo
+	}
+	hist := this.opts().history
+	hist.mu.Lock()
+	defer hist.mu.Unlock()
+	if len(hist.redo) == 0 {
+		return ErrNothingToRedo
+	}
+	changes := hist.redo[len(hist.redo)-1]
+	if err := this.replay(changes); err != nil {
+		return err
+	}
+	hist.redo = hist.redo[:len(hist.redo)-1]
+	hist.undo = append(hist.undo, changes)
+	return nil
+}
+
+// replay applies the changes to the root of the history and records them in the changelog
+func (this *MapPath) replay(changes []Change) error {
+	defer this.changed()
+	root := NewMapPath(this.opts().history.root)
+	for i := range changes {
+		change := &changes[i]
+		if err := root.apply(*change); err != nil {
+			return err
+		}
+		change.Time, change.Actor = this.now(), this.opts().actor
+	}
+	this.opts().changelog.add(changes)
+	return nil
+}
+
+// inverse returns the change reverting the given change
+func (this *history) inverse(change Change) Change {
+	switch change.Op {
+	case ChangeSet:
+		if change.Existed {
+			return Change{Op: ChangeSet, Path: change.Path, Old: change.New, New: change.Old, Existed: true}
+		}
+		old, _ := NewMapPath(this.root).Get(change.created)
+		return Change{Op: ChangeDelete, Path: change.created, Old: old, Existed: true}
+	case ChangeDelete:
+		return Change{Op: ChangeSet, Path: change.Path, New: change.Old, created: change.Path}
+	}
+	return Change{Op: ChangeReplace, Path: change.Path, Old: change.New, New: change.Old, Existed: true}
+}
+
+// apply performs the change on the MapPath, which must not record changes
+func (this *MapPath) apply(change Change) error {
+	switch change.Op {
+	case ChangeSet:
+		return this.Set(change.Path, change.New)
+	case ChangeDelete:
+		parts, err := splitPath(change.Path)
+		if err != nil {
+			return err
+		}
+		parent := interface{}(this.root)
+		if len(parts) > 1 {
+			if parent, err = this.Get(formatKeys(parts[:len(parts)-1])); err != nil {
+				return err
+			}
+		}
+		removeChild(parent, parts[len(parts)-1])
+		return nil
+	}
+	replacement := deepCopy(change.New)
+	var target interface{} = this.root
+	if change.Path != "" {
+		var err error
+		if target, err = this.Get(change.Path); err != nil {
+			return err
+		}
+	}
+	if isMap(target) && isMap(replacement) {
+		// replace the contents in place, so that MapPaths of the sub structure stay valid
+		ref := reflect.ValueOf(target)
+		for _, key := range ref.MapKeys() {
+			ref.SetMapIndex(key, reflect.Value{})
+		}
+		return mergeInto(target, replacement)
+	}
+	return this.Set(change.Path, replacement)
+}
@@ -0,0 +1,79 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+/*
+ * -------
+ * Undo
+ * -------
+ */
+
+func TestUndo(t *testing.T) {
+	orig := map[string]interface{}{
+		"name": "app",
+		"db":   map[string]interface{}{"host": "localhost"},
+	}
+	m := NewMapPath(deepCopy(orig).(map[string]interface{}), WithHistory(0))
+	assert.False(t, m.CanUndo(), "Nothing to undo initially")
+	assert.Equal(t, ErrNothingToUndo, m.Undo(), "Undo without modification")
+
+	db := m.ChildV("db")
+	assert.Nil(t, m.Set("name", "demo"), "Value replaced")
+	assert.Nil(t, m.Set("new/nested/key", 1), "Value created")
+	assert.Nil(t, db.Set("port", 5432), "Value of sub structure set")
+	assert.Nil(t, m.RenameKey("db", "host", "hostname"), "Key renamed")
+
+	for i := 0; i < 4; i++ {
+		assert.Nil(t, m.Undo(), "Modification reverted")
+	}
+	assert.Equal(t, orig, m.Root(), "All modifications reverted")
+	assert.Equal(t, ErrNothingToUndo, m.Undo(), "History exhausted")
+	assert.True(t, m.CanRedo(), "Reverted modifications can be re-applied")
+
+	assert.Nil(t, m.Redo(), "Value replaced again")
+	assert.Nil(t, m.Redo(), "Value created again")
+	assert.Equal(t, 1, m.IntV("new/nested/key"), "Intermediate maps created again")
+	assert.Nil(t, m.Set("name", "other"), "New modification")
+	assert.False(t, m.CanRedo(), "New modification discards reverted ones")
+	assert.Equal(t, ErrNothingToRedo, m.Redo(), "Nothing to redo")
+
+	assert.Nil(t, m.Undo(), "New modification reverted")
+	assert.Equal(t, "demo", m.StringV("name"), "Value before new modification")
+	assert.Equal(t, ErrNothingToUndo, NewMapPath(map[string]interface{}{}).Undo(), "No history by default")
+}
+
+func TestUndoBounded(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{}, WithHistory(2))
+	for i := 1; i <= 3; i++ {
+		assert.Nil(t, m.Set("counter", i), "Value set")
+	}
+	assert.Nil(t, m.Undo(), "Last modification reverted")
+	assert.Nil(t, m.Undo(), "Second last modification reverted")
+	assert.Equal(t, ErrNothingToUndo, m.Undo(), "Oldest modification dropped")
+	assert.Equal(t, 1, m.IntV("counter"), "State after oldest modification")
+}
+
+func TestUndoReplace(t *testing.T) {
+	m := NewMapPath(map[string]interface{}{
+		"debug":    false,
+		"profiles": map[string]interface{}{"dev": map[string]interface{}{"debug": true}},
+	}, WithHistory(0), WithChangelog(0), testChangelogClock())
+	profiles := m.ChildV("profiles")
+	assert.Nil(t, m.Profile("dev"), "Profile applied")
+	assert.Equal(t, map[string]interface{}{"debug": true}, m.Root(), "Structure replaced")
+
+	assert.Nil(t, m.Undo(), "Replacement reverted")
+	assert.Equal(t, false, m.BoolV("debug"), "Structure before replacement")
+	assert.True(t, m.Has("profiles/dev"), "Removed sub structure restored")
+	assert.Nil(t, m.Redo(), "Replacement re-applied")
+	assert.Equal(t, true, m.BoolV("debug"), "Structure after replacement")
+	assert.Equal(t, map[string]interface{}{"dev": map[string]interface{}{"debug": true}}, profiles.Root(), "Detached sub structure untouched")
+
+	changes := m.Changes(time.Time{})
+	assert.Len(t, changes, 3, "Undo and redo recorded in changelog")
+	assert.Equal(t, changes[0].Old, changes[1].New, "Reversal recorded")
+}
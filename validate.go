@@ -0,0 +1,30 @@
+package mappath
+
+import (
+	"strings"
+)
+
+// MissingPathsError is returned by Require and lists all paths which do not exist
+type MissingPathsError []string
+
+func (err MissingPathsError) Error() string {
+	if len(err) == 1 {
+		return NotFoundError(err[0]).Error()
+	}
+	return "The paths \"" + strings.Join(err, "\", \"") + "\" do not exist"
+}
+
+// Require checks that all given paths exist. If any is missing then a MissingPathsError
+// listing every missing path is returned.
+func (this *MapPath) Require(paths ...string) error {
+	var missing MissingPathsError
+	for _, path := range paths {
+		if !this.Has(path) {
+			missing = append(missing, path)
+		}
+	}
+	if len(missing) > 0 {
+		return missing
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Require
+ * -------
+ */
+
+func TestRequireExistingPaths(t *testing.T) {
+	m := NewMapPath(defaultTest)
+	assert.Nil(t, m.Require("hello", "foo/baz/bam", "array/realints/3"), "No error when all paths exist")
+	assert.Nil(t, m.Require(), "No error without paths")
+}
+
+func TestRequireMissingPaths(t *testing.T) {
+	m := NewMapPath(defaultTest)
+	err := m.Require("hello", "x/y", "foo/bar", "array/realints/9")
+	assert.Equal(t, MissingPathsError{"x/y", "array/realints/9"}, err, "All missing paths returned")
+	assert.Equal(t, "The paths \"x/y\", \"array/realints/9\" do not exist", err.Error(), "Error correctly formatted")
+
+	err = m.Require("x")
+	assert.Equal(t, "The path \"x\" does not exist", err.Error(), "Single path error correctly formatted")
+}
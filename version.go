@@ -0,0 +1,221 @@
+package mappath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version like "1.4.2-rc.1+build.5", see MapPath.Version
+type Version struct {
+	Major, Minor, Patch int
+	// Prerelease identifiers, eg "rc.1", if any
+	Prerelease string
+	// Build metadata, eg "build.5", which is ignored in comparisons
+	Build string
+}
+
+// ParseVersion parses the semantic version. A leading "v" and missing minor or patch numbers (eg "v2"
+// or "1.4") are accepted.
+func ParseVersion(str string) (Version, error) {
+	v := Version{}
+	s := strings.TrimPrefix(strings.TrimSpace(str), "v")
+	if i := strings.Index(s, "+"); i >= 0 {
+		s, v.Build = s[:i], s[i+1:]
+		if !validVersionIdentifiers(v.Build, false) {
+			return Version{}, fmt.Errorf("Invalid version \"%s\"", str)
+		}
+	}
+	if i := strings.Index(s, "-"); i >= 0 {
+		s, v.Prerelease = s[:i], s[i+1:]
+		if !validVersionIdentifiers(v.Prerelease, true) {
+			return Version{}, fmt.Errorf("Invalid version \"%s\"", str)
+		}
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return Version{}, fmt.Errorf("Invalid version \"%s\"", str)
+	}
+	numbers := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || len(part) > 1 && part[0] == '0' || !isDigits(part) {
+			return Version{}, fmt.Errorf("Invalid version \"%s\"", str)
+		}
+		*numbers[i] = n
+	}
+	return v, nil
+}
+
+// validVersionIdentifiers checks the dot separated identifiers of pre-release or build metadata
+func validVersionIdentifiers(s string, noLeadingZero bool) bool {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+		for _, c := range id {
+			if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-') {
+				return false
+			}
+		}
+		if noLeadingZero && len(id) > 1 && id[0] == '0' && isDigits(id) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the version in the canonical form, eg "1.4.0-rc.1"
+func (this Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", this.Major, this.Minor, this.Patch)
+	if this.Prerelease != "" {
+		s += "-" + this.Prerelease
+	}
+	if this.Build != "" {
+		s += "+" + this.Build
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 if the version is lower, equal or greater than other, by the precedence
+// of semantic versioning: pre-releases are lower than the release, build metadata is ignored
+func (this Version) Compare(other Version) int {
+	for _, diff := range []int{this.Major - other.Major, this.Minor - other.Minor, this.Patch - other.Patch} {
+		if diff != 0 {
+			return sign(diff)
+		}
+	}
+	if this.Prerelease == other.Prerelease {
+		return 0
+	} else if this.Prerelease == "" {
+		return 1
+	} else if other.Prerelease == "" {
+		return -1
+	}
+	a, b := strings.Split(this.Prerelease, "."), strings.Split(other.Prerelease, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+		an, aerr := strconv.Atoi(a[i])
+		bn, berr := strconv.Atoi(b[i])
+		switch {
+		case aerr == nil && berr == nil:
+			return sign(an - bn)
+		case aerr == nil:
+			return -1
+		case berr == nil:
+			return 1
+		}
+		return sign(strings.Compare(a[i], b[i]))
+	}
+	return sign(len(a) - len(b))
+}
+
+func sign(i int) int {
+	if i < 0 {
+		return -1
+	} else if i > 0 {
+		return 1
+	}
+	return 0
+}
+
+// Satisfies checks whether the version satisfies the constraint. Constraints consist of comparisons
+// with the operators =, !=, >, >=, <, <=, ~ (same minor version, eg "~1.4.2" is ">=1.4.2 <1.5.0") and
+// ^ (same major version, or minor for 0.x versions). Comparisons separated by spaces or commas must all
+// be satisfied, alternatives are separated by "||", eg ">=1.2, <2 || >=3". A version without operator
+// must be equal.
+func (this Version) Satisfies(constraint string) (bool, error) {
+	for _, alternative := range strings.Split(constraint, "||") {
+		comparisons := strings.Fields(strings.Replace(alternative, ",", " ", -1))
+		if len(comparisons) == 0 {
+			return false, fmt.Errorf("Invalid version constraint \"%s\"", constraint)
+		}
+		all := true
+		for _, comparison := range comparisons {
+			ok, err := this.satisfies(comparison)
+			if err != nil {
+				return false, fmt.Errorf("Invalid version constraint \"%s\": %s", constraint, err)
+			}
+			all = all && ok
+		}
+		if all {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// satisfies checks a single comparison like ">=1.2.0"
+func (this Version) satisfies(comparison string) (bool, error) {
+	op := strings.TrimRight(comparison, "0123456789.v-+abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	other, err := ParseVersion(comparison[len(op):])
+	if err != nil {
+		return false, err
+	}
+	cmp := this.Compare(other)
+	switch op {
+	case "", "=", "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case "~":
+		return cmp >= 0 && this.Major == other.Major && this.Minor == other.Minor, nil
+	case "^":
+		if other.Major == 0 {
+			return cmp >= 0 && this.Major == 0 && this.Minor == other.Minor, nil
+		}
+		return cmp >= 0 && this.Major == other.Major, nil
+	}
+	return false, fmt.Errorf("unknown operator \"%s\"", op)
+}
+
+// Version returns the semantic version of path, see ParseVersion. Numbers are accepted as well, eg 2
+// or 1.4. If the value cannot be parsed then an InvalidTypeError is returned.
+func (this *MapPath) Version(path string, fallback ...Version) (Version, error) {
+	str, err := this.String(path)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok && len(fallback) > 0 {
+			return fallback[0], nil
+		}
+		return Version{}, err
+	}
+	v, err := ParseVersion(str)
+	if err != nil {
+		return Version{}, &InvalidTypeError{str, "version"}
+	}
+	return v, nil
+}
+
+// VersionV returns the semantic version of path. If value cannot be parsed then fallback or the zero version is returned. Handy in single value context.
+func (this *MapPath) VersionV(path string, fallback ...Version) Version {
+	if val, err := this.Version(path, fallback...); err != nil {
+		if len(fallback) > 0 {
+			return fallback[0]
+		}
+		return Version{}
+	} else {
+		return val
+	}
+}
+
+// VersionSatisfies checks whether the semantic version of path satisfies the constraint, see
+// Version.Satisfies. To check a version against a constraint of the configuration use:
+//
+//	ok, err := client.Satisfies(m.StringV("minClient"))
+func (this *MapPath) VersionSatisfies(path, constraint string) (bool, error) {
+	v, err := this.Version(path)
+	if err != nil {
+		return false, err
+	}
+	return v.Satisfies(constraint)
+}
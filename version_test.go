@@ -0,0 +1,95 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+ * -------
+ * Version
+ * -------
+ */
+
+var parseVersionTests = []struct {
+	val      string
+	expected string
+}{
+	{"1.4.2", "1.4.2"},
+	{"v2", "2.0.0"},
+	{"1.4", "1.4.0"},
+	{"1.0.0-rc.1+build.5", "1.0.0-rc.1+build.5"},
+	{"1.0.0-alpha-beta", "1.0.0-alpha-beta"},
+	{"01.2.3", ""},
+	{"1.2.3.4", ""},
+	{"1.2.x", ""},
+	{"1.2.3-", ""},
+	{"1.2.3-01", ""},
+	{"1.2.3+b..1", ""},
+	{"", ""},
+}
+
+func TestParseVersion(t *testing.T) {
+	for _, test := range parseVersionTests {
+		v, err := ParseVersion(test.val)
+		assert.Equal(t, test.expected == "", err != nil, "Error of "+test.val)
+		if err == nil {
+			assert.Equal(t, test.expected, v.String(), "Version of "+test.val)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0"}
+	for i := 1; i < len(ordered); i++ {
+		a, _ := ParseVersion(ordered[i-1])
+		b, _ := ParseVersion(ordered[i])
+		assert.Equal(t, -1, a.Compare(b), ordered[i-1]+" < "+ordered[i])
+		assert.Equal(t, 1, b.Compare(a), ordered[i]+" > "+ordered[i-1])
+	}
+	a, _ := ParseVersion("1.0.0+a")
+	b, _ := ParseVersion("1.0.0+b")
+	assert.Equal(t, 0, a.Compare(b), "Build metadata ignored")
+}
+
+var versionSatisfiesTests = []struct {
+	version    string
+	constraint string
+	expected   bool
+}{
+	{"2.3.0", ">=2.3.0", true},
+	{"2.2.9", ">=2.3.0", false},
+	{"1.5.0", ">=1.2, <2", true},
+	{"2.0.0", ">=1.2, <2", false},
+	{"3.1.0", ">=1.2 <2 || >=3", true},
+	{"1.4.9", "~1.4.2", true},
+	{"1.5.0", "~1.4.2", false},
+	{"1.9.0", "^1.4.2", true},
+	{"2.0.0", "^1.4.2", false},
+	{"0.2.5", "^0.2.1", true},
+	{"0.3.0", "^0.2.1", false},
+	{"1.0.0", "1.0.0", true},
+	{"1.0.0", "!=1.0.0", false},
+	{"2.0.0-rc.1", "<2.0.0", true},
+}
+
+func TestVersionSatisfies(t *testing.T) {
+	for _, test := range versionSatisfiesTests {
+		m := NewMapPath(map[string]interface{}{"version": test.version})
+		ok, err := m.VersionSatisfies("version", test.constraint)
+		assert.Nil(t, err, "No error of "+test.constraint)
+		assert.Equal(t, test.expected, ok, test.version+" satisfies "+test.constraint)
+	}
+
+	m := NewMapPath(map[string]interface{}{"version": "1.0.0", "number": 2, "invalid": "latest"})
+	_, err := m.VersionSatisfies("version", "=>1.0")
+	assert.NotNil(t, err, "Unknown operator")
+	_, err = m.VersionSatisfies("version", ">=one")
+	assert.NotNil(t, err, "Invalid version in constraint")
+	_, err = m.VersionSatisfies("version", "")
+	assert.NotNil(t, err, "Empty constraint")
+	assert.Equal(t, Version{Major: 2}, m.VersionV("number"), "Version of number")
+	_, err = m.Version("invalid")
+	assert.IsType(t, &InvalidTypeError{}, err, "Invalid version")
+	assert.Equal(t, Version{Major: 1}, m.VersionV("missing", Version{Major: 1}), "Fallback used")
+}
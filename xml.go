@@ -0,0 +1,129 @@
+package mappath
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// XmlAttrKey is the default key under which FromXml stores the attributes of elements
+const XmlAttrKey = "@attr"
+
+// XmlTextKey is the key under which FromXml stores the text of elements, which also have attributes or
+// child elements
+const XmlTextKey = "#text"
+
+// WithXmlAttrKey sets the key under which FromXml stores the attributes of elements, instead of XmlAttrKey
+func WithXmlAttrKey(key string) Option {
+	return func(o *options) {
+		o.xmlAttrKey = key
+	}
+}
+
+// FromXml is a factory method to create a MapPath from XML byte data. The root element becomes the only
+// key of the structure. Elements are converted into maps of their child elements, with repeated child
+// elements collected in arrays, and attributes in a map under XmlAttrKey (see WithXmlAttrKey). Elements
+// with neither attributes nor child elements become their text, the text of all others is stored under
+// XmlTextKey. All values are strings, namespaces are dropped from names:
+//
+//	<config><server port="80">web</server><host>a</host><host>b</host></config>
+//
+// becomes {"config": {"server": {"@attr": {"port": "80"}, "#text": "web"}, "host": ["a", "b"]}}. The
+// limits of WithMaxBytes and WithMaxDepth apply.
+func FromXml(in []byte, opts ...Option) (*MapPath, error) {
+	o := newOptions(opts)
+	if o.maxBytes > 0 && len(in) > o.maxBytes {
+		return nil, &LimitError{"bytes", o.maxBytes}
+	} else if err := verifyDocument(in, o); err != nil {
+		return nil, err
+	}
+	attrKey := o.xmlAttrKey
+	if attrKey == "" {
+		attrKey = XmlAttrKey
+	}
+	dec := xml.NewDecoder(bytes.NewReader(in))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil, fmt.Errorf("Cannot XML without root element")
+		} else if err != nil {
+			return nil, err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			val, err := xmlElement(dec, start, attrKey, 1, o.maxDepth)
+			if err != nil {
+				return nil, err
+			}
+			return NewMapPath(map[string]interface{}{start.Name.Local: val}, opts...), nil
+		}
+	}
+}
+
+// FromXmlFile is a factory method to create a MapPath from an XML file
+func FromXmlFile(file string, opts ...Option) (*MapPath, error) {
+	fh, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+	in, err := readDocument(fh, newOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return FromXml(in, verified(opts)...)
+}
+
+// xmlElement reads the contents of the started element up to its end
+func xmlElement(dec *xml.Decoder, start xml.StartElement, attrKey string, depth, maxDepth int) (interface{}, error) {
+	if maxDepth > 0 && depth > maxDepth {
+		return nil, &LimitError{"depth", maxDepth}
+	}
+	result := map[string]interface{}{}
+	if len(start.Attr) > 0 {
+		attrs := make(map[string]interface{}, len(start.Attr))
+		for _, attr := range start.Attr {
+			if attr.Name.Space != "xmlns" && attr.Name.Local != "xmlns" {
+				attrs[attr.Name.Local] = attr.Value
+			}
+		}
+		if len(attrs) > 0 {
+			result[attrKey] = attrs
+		}
+	}
+	text := new(strings.Builder)
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			child, err := xmlElement(dec, tok, attrKey, depth+1, maxDepth)
+			if err != nil {
+				return nil, err
+			}
+			name := tok.Name.Local
+			if existing, ok := result[name]; !ok {
+				result[name] = child
+			} else if list, ok := existing.([]interface{}); ok {
+				result[name] = append(list, child)
+			} else {
+				result[name] = []interface{}{existing, child}
+			}
+		case xml.CharData:
+			text.Write(tok)
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+			if len(result) == 0 {
+				return content, nil
+			} else if content != "" {
+				result[XmlTextKey] = content
+			}
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/*
+ * -------
+ * XML
+ * -------
+ */
+
+const xmlTestDoc = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- legacy config -->
+<config xmlns="urn:example" version="2">
+	<name>app</name>
+	<server port="8080" tls="true">web</server>
+	<hosts>
+		<host>a</host>
+		<host>b</host>
+		<host>c</host>
+	</hosts>
+	<db><pool size="10"/></db>
+	<empty/>
+</config>`
+
+func TestFromXml(t *testing.T) {
+	m, err := FromXml([]byte(xmlTestDoc))
+	assert.Nil(t, err, "No error parsing XML")
+	assert.Equal(t, "app", m.StringV("config/name"), "Text element")
+	assert.Equal(t, "2", m.StringV("config/@attr/version"), "Attribute of root")
+	assert.False(t, m.Has("config/@attr/xmlns"), "Namespace declaration dropped")
+	assert.Equal(t, 8080, m.IntV("config/server/@attr/port"), "Attribute converted by getter")
+	assert.True(t, m.BoolV("config/server/@attr/tls"), "Bool attribute")
+	assert.Equal(t, "web", m.StringV("config/server/#text"), "Text of element with attributes")
+	assert.Equal(t, []string{"a", "b", "c"}, m.StringsV("config/hosts/host"), "Repeated elements as array")
+	assert.Equal(t, 10, m.IntV("config/db/pool/@attr/size"), "Nested attributes")
+	assert.Equal(t, "", m.StringV("config/empty", "fallback"), "Empty element")
+
+	m, err = FromXml([]byte(xmlTestDoc), WithXmlAttrKey("_"))
+	assert.Nil(t, err, "No error with attribute key")
+	assert.Equal(t, "8080", m.StringV("config/server/_/port"), "Custom attribute key")
+
+	_, err = FromXml([]byte("<a><b></a>"))
+	assert.NotNil(t, err, "Invalid XML")
+	_, err = FromXml([]byte("<!-- nothing -->"))
+	assert.EqualError(t, err, "Cannot XML without root element", "No root element")
+}
+
+func TestFromXmlLimits(t *testing.T) {
+	_, err := FromXml([]byte(xmlTestDoc), WithMaxBytes(10))
+	assert.Equal(t, &LimitError{"bytes", 10}, err, "Bytes limited")
+	_, err = FromXml([]byte(xmlTestDoc), WithMaxDepth(2))
+	assert.Equal(t, &LimitError{"depth", 2}, err, "Depth limited")
+	_, err = FromXml([]byte(xmlTestDoc), WithMaxDepth(3))
+	assert.Nil(t, err, "Depth within limit")
+}
+
+func TestFromXmlFile(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "mappath")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "config.xml")
+	ioutil.WriteFile(file, []byte(xmlTestDoc), 0644)
+
+	m, err := FromXmlFile(file)
+	assert.Nil(t, err, "Loaded XML file")
+	assert.Equal(t, "app", m.StringV("config/name"), "Value of XML file")
+	m, err = FromFile(file)
+	assert.Nil(t, err, "Loaded by extension")
+	assert.Equal(t, "app", m.StringV("config/name"), "Value by extension")
+	_, err = FromXmlFile(filepath.Join(dir, "missing.xml"))
+	assert.NotNil(t, err, "Missing file")
+}
@@ -0,0 +1,123 @@
+package mappath
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+
+	"gopkg.in/yaml.v2"
+)
+
+// FromYaml is a factory method to create a MapPath from YAML byte data. Maps with non-string keys, as
+// decoded from YAML (eg map[interface{}]interface{}), are converted into map[string]interface{} with
+// stringified keys, so that all getters work alike for JSON and YAML. An empty document results in an
+// empty MapPath. The limits of WithMaxBytes and WithMaxDepth apply, with WithStrictKeys duplicate keys
+// are refused with a DuplicateKeysError.
+func FromYaml(in []byte, opts ...Option) (*MapPath, error) {
+	o := newOptions(opts)
+	if o.maxBytes > 0 && len(in) > o.maxBytes {
+		return nil, &LimitError{"bytes", o.maxBytes}
+	} else if err := verifyDocument(in, o); err != nil {
+		return nil, err
+	}
+	var data interface{}
+	if err := yaml.Unmarshal(in, &data); err != nil {
+		return nil, err
+	}
+	if o.strictKeys {
+		// decoded into MapSlice, all mappings keep their duplicate keys
+		var doc yaml.MapSlice
+		var duplicates DuplicateKeysError
+		if yaml.Unmarshal(in, &doc) == nil {
+			yamlDuplicates(doc, "", &duplicates)
+		}
+		if len(duplicates) > 0 {
+			return nil, duplicates
+		}
+	}
+	if o.maxDepth > 0 && valueDepth(data) > o.maxDepth {
+		return nil, &LimitError{"depth", o.maxDepth}
+	}
+	switch data := normalizeYaml(data).(type) {
+	case nil:
+		return NewMapPath(map[string]interface{}{}, opts...), nil
+	case map[string]interface{}:
+		return NewMapPath(data, opts...), nil
+	}
+	return nil, fmt.Errorf("Cannot YAML which is unmarshalled to %+v. Must be unmarshallable to map[string]interface {}", reflect.TypeOf(data))
+}
+
+// FromYamlFile is a factory method to create a MapPath from a YAML file
+func FromYamlFile(file string, opts ...Option) (*MapPath, error) {
+	fh, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+	in, err := readDocument(fh, newOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return FromYaml(in, verified(opts)...)
+}
+
+// yamlDuplicates collects the paths of duplicate keys of the value decoded into yaml.MapSlice
+func yamlDuplicates(val interface{}, path string, duplicates *DuplicateKeysError) {
+	switch val := val.(type) {
+	case yaml.MapSlice:
+		seen := make(map[string]bool)
+		for _, item := range val {
+			key := joinPath(path, fmt.Sprintf("%v", item.Key))
+			if seen[key] {
+				*duplicates = append(*duplicates, key)
+			}
+			seen[key] = true
+			yamlDuplicates(item.Value, key, duplicates)
+		}
+	case []interface{}:
+		for i, value := range val {
+			yamlDuplicates(value, joinPath(path, strconv.Itoa(i)), duplicates)
+		}
+	}
+}
+
+// normalizeYaml converts all maps of the decoded YAML into map[string]interface{}
+func normalizeYaml(val interface{}) interface{} {
+	switch val := val.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(val))
+		for key, value := range val {
+			result[fmt.Sprintf("%v", key)] = normalizeYaml(value)
+		}
+		return result
+	case []interface{}:
+		for i, value := range val {
+			val[i] = normalizeYaml(value)
+		}
+	}
+	return val
+}
+
+// valueDepth returns the maximum nesting of maps and arrays of the decoded value
+func valueDepth(val interface{}) int {
+	max := 0
+	switch val := val.(type) {
+	case map[interface{}]interface{}:
+		for _, value := range val {
+			if d := valueDepth(value); d > max {
+				max = d
+			}
+		}
+	case []interface{}:
+		for _, value := range val {
+			if d := valueDepth(value); d > max {
+				max = d
+			}
+		}
+	default:
+		return 0
+	}
+	return max + 1
+}
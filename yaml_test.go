@@ -0,0 +1,85 @@
+package mappath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/*
+ * -------
+ * YAML
+ * -------
+ */
+
+const yamlTestDoc = `
+name: app
+server:
+  port: 8080
+  hosts: [a, b]
+codes:
+  200: ok
+  404: missing
+servers:
+  - host: a
+    weights: {1: 0.5}
+`
+
+func TestFromYaml(t *testing.T) {
+	m, err := FromYaml([]byte(yamlTestDoc))
+	assert.Nil(t, err, "No error parsing YAML")
+	assert.Equal(t, "app", m.StringV("name"), "String value")
+	assert.Equal(t, 8080, m.IntV("server/port"), "Int value")
+	assert.Equal(t, []string{"a", "b"}, m.StringsV("server/hosts"), "Array value")
+	assert.Equal(t, map[string]interface{}{"200": "ok", "404": "missing"}, m.MapV("codes"), "Non-string keys stringified")
+	assert.Equal(t, 0.5, m.FloatV("servers/0/weights/1"), "Maps within arrays normalized")
+	assert.IsType(t, map[string]interface{}{}, m.Root()["server"], "Maps normalized")
+
+	m, err = FromYaml([]byte(""))
+	assert.Nil(t, err, "No error on empty document")
+	assert.Equal(t, 0, len(m.Root()), "Empty document")
+
+	_, err = FromYaml([]byte("- a\n- b"))
+	assert.NotNil(t, err, "Top level array refused")
+	_, err = FromYaml([]byte("a: [b"))
+	assert.NotNil(t, err, "Invalid YAML")
+}
+
+func TestFromYamlLimits(t *testing.T) {
+	_, err := FromYaml([]byte(yamlTestDoc), WithMaxBytes(10))
+	assert.Equal(t, &LimitError{"bytes", 10}, err, "Bytes limited")
+	_, err = FromYaml([]byte(yamlTestDoc), WithMaxDepth(3))
+	assert.Equal(t, &LimitError{"depth", 3}, err, "Depth limited")
+	_, err = FromYaml([]byte(yamlTestDoc), WithMaxDepth(4))
+	assert.Nil(t, err, "Depth within limit")
+
+	dup := []byte("a: 1\nb: 2\na: 3\n")
+	m, err := FromYaml(dup)
+	assert.Nil(t, err, "Duplicate keys accepted by default")
+	assert.Equal(t, 3, m.IntV("a"), "Last duplicate wins")
+	_, err = FromYaml(dup, WithStrictKeys())
+	assert.Equal(t, DuplicateKeysError{"a"}, err, "Duplicate keys refused with strict keys")
+	_, err = FromYaml([]byte("b:\n  c: 1\n  c: 2\nl:\n  - x: 1\n    x: 2\n"), WithStrictKeys())
+	assert.Equal(t, DuplicateKeysError{"b/c", "l/0/x"}, err, "Duplicate keys reported with paths")
+	_, err = FromYaml([]byte("a: {b: 1}\nc: {b: 2}\n"), WithStrictKeys())
+	assert.Nil(t, err, "Same keys in different maps accepted")
+}
+
+func TestFromYamlFile(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "mappath")
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"config.yaml", "config.yml"} {
+		file := filepath.Join(dir, name)
+		ioutil.WriteFile(file, []byte(yamlTestDoc), 0644)
+		m, err := FromFile(file)
+		assert.Nil(t, err, "Loaded by extension "+name)
+		assert.Equal(t, 8080, m.IntV("server/port"), "Value of "+name)
+	}
+	m, err := FromYamlFile(filepath.Join(dir, "config.yaml"))
+	assert.Nil(t, err, "Loaded YAML file")
+	assert.Equal(t, "app", m.StringV("name"), "Value of YAML file")
+	_, err = FromYamlFile(filepath.Join(dir, "missing.yaml"))
+	assert.NotNil(t, err, "Missing file")
+}